@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"gambling/internal/app"
+	"gambling/internal/config"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+)
+
+// serve запускает HTTP API и останавливает его по SIGINT/SIGTERM
+func serve(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	fs.StringVar(&cfg.AppUrl, "host", cfg.AppUrl, "адрес для входящих соединений (APP_URL)")
+	fs.StringVar(&cfg.AppPort, "port", cfg.AppPort, "порт HTTP сервера (APP_PORT)")
+	fs.DurationVar(&cfg.ShutdownTimeout, "drain-timeout", cfg.ShutdownTimeout, "сколько ждать завершения активных запросов при остановке (SHUTDOWN_TIMEOUT)")
+	fs.DurationVar(&cfg.ReconcileInterval, "reconcile-interval", cfg.ReconcileInterval, "период фоновой сверки балансов, 0 - выключена (RECONCILE_INTERVAL)")
+	bindGameFlags(fs, cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateAuth(); err != nil {
+		return err
+	}
+
+	log := setupLogger(cfg.AppEnv, stdout)
+	log.Info("starting gambling http api", slog.String("env", cfg.AppEnv))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return app.NewApp(cfg, log).Run(ctx)
+}
+
+// console запускает интерактивный консольный клиент
+func console(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("console", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	bindGameFlags(fs, cfg)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+
+	log := setupLogger(cfg.AppEnv, stdout)
+	log.Info("starting console gambling app")
+
+	// Создаем и запускаем консольное приложение
+	consoleApp := app.NewConsoleApp(cfg, log)
+	consoleApp.Run()
+	return nil
+}
+
+// migrate управляет миграциями базы данных:
+// migrate [флаги] up | down [N] | status
+func migrate(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Использование: gambling migrate [флаги] up | down [N] | status")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	action, steps := app.MigrateUp, 1
+	switch rest := fs.Args(); {
+	case len(rest) == 0:
+	case len(rest) == 1:
+		action = rest[0]
+	case len(rest) == 2 && rest[0] == app.MigrateDown:
+		n, err := strconv.Atoi(rest[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("неверное число миграций для отката %q", rest[1])
+		}
+		action, steps = rest[0], n
+	default:
+		fs.Usage()
+		return errors.New("неверные аргументы migrate")
+	}
+
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+
+	return app.Migrate(cfg, setupLogger(cfg.AppEnv, stdout), action, steps, stdout)
+}
+
+// role назначает роль пользователю: role [флаги] -username NAME -role operator
+func role(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("role", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	username := fs.String("username", "", "имя пользователя")
+	roleName := fs.String("role", "", "роль: player или operator")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || *roleName == "" {
+		return errors.New("необходимо указать -username и -role")
+	}
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+
+	return app.SetRole(cfg, setupLogger(cfg.AppEnv, stdout), *username, *roleName)
+}
+
+// ledger работает с главной книгой: ledger [флаги] accounts | rebuild
+func ledger(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("ledger", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	dryRun := fs.Bool("dry-run", false, "rebuild: только показать расхождения, не исправляя балансы")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Использование: gambling ledger [флаги] accounts | rebuild")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("не указано действие ledger")
+	}
+	// Флаги разрешены и после действия: ledger rebuild -dry-run
+	action := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("неверные аргументы ledger")
+	}
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+
+	return app.Ledger(cfg, setupLogger(cfg.AppEnv, stdout), action, *dryRun, stdout)
+}
+
+// reconcile сверяет балансы с транзакциями, спинами и главной книгой
+func reconcile(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	bindCommonFlags(fs, cfg)
+	userID := fs.Uint("user", 0, "ID пользователя (по умолчанию все)")
+	latest := fs.Bool("latest", false, "показать последний сохраненный отчет без новой сверки")
+	asJSON := fs.Bool("json", false, "вывести отчет в формате JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cfg.ValidateDB(); err != nil {
+		return err
+	}
+
+	opts := app.ReconcileOptions{UserID: *userID, Latest: *latest, JSON: *asJSON}
+	// Логи пишутся в stderr, чтобы не смешиваться с JSON отчетом
+	return app.Reconcile(cfg, setupLogger(cfg.AppEnv, os.Stderr), opts, stdout)
+}
+
+// bindCommonFlags регистрирует флаги окружения и подключения к базе данных
+// Значения по умолчанию берутся из cfg, поэтому флаг переопределяет переменную окружения
+// Пароль базы данных флагом не передается, чтобы он не попадал в список процессов
+func bindCommonFlags(fs *flag.FlagSet, cfg *config.Config) {
+	fs.StringVar(&cfg.AppEnv, "env", cfg.AppEnv, "окружение: local, dev или prod (APP_ENV)")
+	fs.StringVar(&cfg.DBHost, "db-host", cfg.DBHost, "хост базы данных (DB_HOST)")
+	fs.IntVar(&cfg.DBPort, "db-port", cfg.DBPort, "порт базы данных (DB_PORT)")
+	fs.StringVar(&cfg.DBUser, "db-user", cfg.DBUser, "пользователь базы данных (DB_USER)")
+	fs.StringVar(&cfg.DBName, "db-name", cfg.DBName, "имя базы данных (DB_NAME)")
+	fs.StringVar(&cfg.DBSSLMode, "db-sslmode", cfg.DBSSLMode, "режим SSL базы данных (DB_SSLMODE)")
+}
+
+// bindGameFlags регистрирует флаги игрового движка
+func bindGameFlags(fs *flag.FlagSet, cfg *config.Config) {
+	fs.StringVar(&cfg.PaytablePath, "paytable", cfg.PaytablePath, "путь к JSON-таблице выплат (PAYTABLE_PATH)")
+	fs.StringVar(&cfg.VideoPaytablePath, "video-paytable", cfg.VideoPaytablePath, "путь к JSON-таблице видеослота (VIDEO_PAYTABLE_PATH)")
+	fs.BoolVar(&cfg.ProvablyFair, "provably-fair", cfg.ProvablyFair, "доказуемо честные спины (PROVABLY_FAIR)")
+}
+
+func setupLogger(env string, w io.Writer) *slog.Logger {
+	var log *slog.Logger
+
+	switch env {
+	case envLocal:
+		log = slog.New(
+			slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		)
+	case envDev:
+		log = slog.New(
+			slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		)
+	case envProd:
+		log = slog.New(
+			slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		)
+	default:
+		// Неизвестное окружение не должно оставлять приложение без логгера:
+		// используем самый строгий вариант и предупреждаем об опечатке
+		log = slog.New(
+			slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		)
+		log.Warn("unknown APP_ENV, falling back to prod logger", slog.String("env", env))
+	}
+
+	return log
+}
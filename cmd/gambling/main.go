@@ -1,9 +1,12 @@
 package main
 
 import (
-	"gambling/internal/app"
+	"errors"
+	"flag"
+	"fmt"
 	"gambling/internal/config"
-	"log/slog"
+	"gambling/internal/interfaces/cli"
+	"io"
 	"os"
 )
 
@@ -13,34 +16,75 @@ const (
 	envProd  = "prod"
 )
 
-func main() {
-	cfg := config.MustLoad()
+// command - подкоманда бинарника; флаги команды переопределяют значения cfg
+type command func(cfg *config.Config, args []string, stdout io.Writer) error
 
-	log := setupLogger(cfg.AppEnv)
-	log.Info("starting console gambling app")
+// offlineCommands - команды, которые работают без базы данных
+var offlineCommands = map[string]command{
+	"analyze":  cli.Analyze,
+	"simulate": cli.Simulate,
+	"verify":   cli.Verify,
+}
 
-	// Создаем и запускаем консольное приложение
-	consoleApp := app.NewConsoleApp(cfg, log)
-	consoleApp.Run()
+// databaseCommands - команды, которым нужна база данных
+var databaseCommands = map[string]command{
+	"serve":     serve,
+	"console":   console,
+	"migrate":   migrate,
+	"role":      role,
+	"ledger":    ledger,
+	"reconcile": reconcile,
 }
 
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
+// defaultCommand запускается, если подкоманда не указана
+const defaultCommand = "console"
+
+func main() {
+	name, args := defaultCommand, os.Args[1:]
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(os.Stdout)
+		return
 	}
 
-	return log
+	run, ok := offlineCommands[name]
+	if !ok {
+		run, ok = databaseCommands[name]
+	}
+	if !ok {
+		fmt.Fprintf(os.Stderr, "неизвестная команда %q\n\n", name)
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	if err := run(config.Load(), args, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if errors.Is(err, config.ErrMissingDBConfig) {
+			config.PrintDBConfigHelp()
+		}
+		fmt.Fprintln(os.Stderr, "ошибка:", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Использование: gambling <команда> [флаги]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Команды:")
+	fmt.Fprintln(w, "  serve     HTTP API с graceful shutdown по SIGINT/SIGTERM")
+	fmt.Fprintln(w, "  console   интерактивный консольный клиент (по умолчанию)")
+	fmt.Fprintln(w, "  migrate   миграции базы данных: up, down N, status")
+	fmt.Fprintln(w, "  role      назначить роль пользователю (player, operator)")
+	fmt.Fprintln(w, "  ledger    главная книга: accounts, rebuild [-dry-run]")
+	fmt.Fprintln(w, "  reconcile сверка балансов с транзакциями, спинами и главной книгой")
+	fmt.Fprintln(w, "  analyze   PAR sheet таблицы выплат")
+	fmt.Fprintln(w, "  simulate  Monte Carlo симуляция")
+	fmt.Fprintln(w, "  verify    проверка доказуемо честного спина")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Флаги команды: gambling <команда> -h")
 }
@@ -3,13 +3,23 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	idempotencyUseCase "gambling/internal/application/use_case/idempotency"
+	reconcileUseCase "gambling/internal/application/use_case/reconciliation"
 	"gambling/internal/config"
 	"gambling/internal/infrastructure/database/pgsql"
+	"gambling/internal/infrastructure/paytable"
+	"gambling/internal/infrastructure/repository"
 	"gambling/internal/interfaces/http/router"
 	"log/slog"
 	"net/http"
+	"sync"
+	"time"
 )
 
+// readHeaderTimeout ограничивает время чтения заголовков запроса (защита от slowloris)
+const readHeaderTimeout = 10 * time.Second
+
 type App struct {
 	cfg     *config.Config
 	log     *slog.Logger
@@ -17,39 +27,86 @@ type App struct {
 	port    string
 	routes  http.Handler
 	server  *http.Server
+	// reconcile - фоновая сверка балансов (nil, если выключена)
+	reconcile *reconcileUseCase.ReconcileUseCase
+	// idempotency - очистка ключей идемпотентности с истекшим сроком
+	idempotency *idempotencyUseCase.Guard
+	// stopJobs останавливает фоновые задачи и ждет завершения начатого запуска
+	stopJobs func()
 }
 
 func NewApp(cfg *config.Config, log *slog.Logger) *App {
 	storage := pgsql.New(cfg)
-	routes := router.New(storage, log)
+	// Приложение не запускается на устаревшей схеме: миграции применяются командой migrate
+	storage.MustCheckSchema()
+
+	routes := router.New(
+		storage,
+		cfg,
+		paytable.MustLoad(cfg.PaytablePath),
+		paytable.MustLoadVideo(cfg.VideoPaytablePath),
+		mustTokenSigner(cfg),
+		log,
+	)
 
 	server := &http.Server{
-		Addr:    cfg.AppUrl + ":" + cfg.AppPort,
-		Handler: routes,
+		Addr:              cfg.AppUrl + ":" + cfg.AppPort,
+		Handler:           routes,
+		ReadHeaderTimeout: readHeaderTimeout,
 	}
 
-	return &App{
+	a := &App{
 		cfg:     cfg,
 		log:     log,
 		storage: storage,
 		port:    cfg.AppPort,
 		routes:  routes,
 		server:  server,
+
+		idempotency: idempotencyUseCase.NewGuard(repository.NewIdempotencyRepository(storage.DB), cfg.IdempotencyTTL),
 	}
+	if cfg.ReconcileInterval > 0 {
+		a.reconcile = newReconcileUseCase(storage.DB)
+	}
+	return a
 }
 
-func (a *App) MustRun() {
-	const op = "app.MustRun"
+// Run запускает HTTP сервер и блокируется до отмены ctx или ошибки сервера
+// После отмены ctx сервер перестает принимать соединения и в течение
+// cfg.ShutdownTimeout дожидается завершения активных запросов
+func (a *App) Run(ctx context.Context) error {
+	const op = "app.Run"
 
 	log := a.log.With(slog.String("operation", op), slog.String("port", a.port))
 
+	serverErr := make(chan error, 1)
 	go func() {
 		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Error("failed to start server", slog.Any("error", err))
+			serverErr <- err
 		}
+		close(serverErr)
 	}()
+
+	log.Info("server started", slog.String("addr", a.server.Addr))
+
+	a.startJobs()
+
+	select {
+	case err := <-serverErr:
+		log.Error("failed to start server", slog.Any("error", err))
+		a.stopJobs()
+		return errors.Join(fmt.Errorf("%s: %w", op, err), a.storage.Close())
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+	defer cancel()
+
+	return a.Shutdown(shutdownCtx)
 }
 
+// Shutdown останавливает HTTP сервер и закрывает соединение с базой данных
+// Активные запросы завершаются, пока не истечет ctx
 func (a *App) Shutdown(ctx context.Context) error {
 	const op = "app.Shutdown"
 
@@ -60,5 +117,43 @@ func (a *App) Shutdown(ctx context.Context) error {
 		return errors.New("server is not initialized")
 	}
 
-	return a.server.Shutdown(ctx)
+	serverErr := a.server.Shutdown(ctx)
+	if serverErr != nil {
+		log.Error("server did not drain in time", slog.Any("error", serverErr))
+	} else {
+		log.Info("server stopped")
+	}
+
+	// Начатая сверка доводится до конца до закрытия соединения с базой данных
+	if a.stopJobs != nil {
+		a.stopJobs()
+	}
+
+	if serverErr != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, serverErr), a.storage.Close())
+	}
+	return a.storage.Close()
+}
+
+// startJobs запускает фоновые задачи; они работают до вызова a.stopJobs
+func (a *App) startJobs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		runIdempotencyCleanupJob(ctx, a.idempotency, a.log)
+	}()
+	if a.reconcile != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runReconcileJob(ctx, a.reconcile, a.cfg.ReconcileInterval, a.log)
+		}()
+	}
+
+	a.stopJobs = func() {
+		cancel()
+		wg.Wait()
+	}
 }
@@ -3,33 +3,74 @@ package app
 import (
 	"gambling/internal/application/use_case/auth"
 	"gambling/internal/application/use_case/balance"
+	"gambling/internal/application/use_case/exclusions"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/application/use_case/limits"
 	"gambling/internal/application/use_case/spin"
+	"gambling/internal/application/use_case/statement"
 	"gambling/internal/config"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/rng"
 	spinDomain "gambling/internal/domain/spin"
 	"gambling/internal/infrastructure/database/pgsql"
-	consoleInterface "gambling/internal/interfaces/console"
+	"gambling/internal/infrastructure/export"
+	"gambling/internal/infrastructure/paytable"
 	"gambling/internal/infrastructure/repository"
+	consoleInterface "gambling/internal/interfaces/console"
 	"log/slog"
 )
 
 // NewConsoleApp создает консольное приложение с использованием DDD архитектуры
 func NewConsoleApp(cfg *config.Config, log *slog.Logger) *consoleInterface.Console {
 	storage := pgsql.New(cfg)
+	// Приложение не запускается на устаревшей схеме: миграции применяются командой migrate
+	storage.MustCheckSchema()
 
 	// Инициализация инфраструктуры (репозитории)
 	userRepo := repository.NewUserRepository(storage.DB)
+	unitOfWork := repository.NewUnitOfWork(storage.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(storage.DB)
+	withdrawalRepo := repository.NewWithdrawalRepository(storage.DB)
 	transactionRepo := repository.NewTransactionRepository(storage.DB)
 	spinRepo := repository.NewSpinRepository(storage.DB)
+	exclusionRepo := repository.NewExclusionRepository(storage.DB)
 
 	// Инициализация доменного слоя
-	spinDomainService := spinDomain.NewService()
+	// Таблица выплат проверяется при старте: с неверной таблицей приложение не запустится
+	spinPaytable := paytable.MustLoad(cfg.PaytablePath)
+	spinDomainService := spinDomain.NewService(spinPaytable, rng.NewCryptoSource())
+	videoPaytable := paytable.MustLoadVideo(cfg.VideoPaytablePath)
+	games := game.NewRegistry(spinDomainService, spinDomain.NewVideoService(videoPaytable))
 
 	// Инициализация application слоя (use cases)
-	registerUseCase := auth.NewRegisterUseCase(userRepo)
-	loginUseCase := auth.NewLoginUseCase(userRepo)
-	depositUseCase := balance.NewDepositUseCase(userRepo, transactionRepo)
-	spinUC := spin.NewSpinUseCase(userRepo, transactionRepo, spinRepo, spinDomainService)
+	registerUseCase := auth.NewRegisterUseCase(userRepo, exclusionRepo)
+	tokenTTL := auth.TokenTTL{Access: cfg.AccessTokenTTL, Refresh: cfg.RefreshTokenTTL}
+	loginUseCase := auth.NewLoginUseCase(userRepo, refreshTokenRepo, consoleTokenSigner(cfg), tokenTTL)
+	depositUseCase := balance.NewDepositUseCase(unitOfWork)
+	jackpotRules := jackpot.Rules{
+		ContributionBPS: cfg.JackpotContributionBPS,
+		Seed:            cfg.JackpotSeed,
+		FullBet:         cfg.JackpotFullBet,
+	}
+	spinUC := spin.NewSpinUseCase(unitOfWork, games, cfg.ProvablyFair, jackpotRules)
 
 	// Создаем консольный интерфейс
-	return consoleInterface.NewConsole(registerUseCase, loginUseCase, depositUseCase, spinUC)
+	return consoleInterface.NewConsole(consoleInterface.UseCases{
+		Register:          registerUseCase,
+		Login:             loginUseCase,
+		Deposit:           depositUseCase,
+		Withdraw:          balance.NewWithdrawUseCase(unitOfWork),
+		ApproveWithdrawal: balance.NewApproveWithdrawalUseCase(unitOfWork),
+		RejectWithdrawal:  balance.NewRejectWithdrawalUseCase(unitOfWork),
+		ListWithdrawals:   balance.NewListWithdrawalsUseCase(withdrawalRepo),
+		ListTransactions:  history.NewListTransactionsUseCase(transactionRepo, userRepo),
+		GenerateStatement: statement.NewGenerateUseCase(transactionRepo, spinRepo, userRepo, export.NewExporter()),
+		SetLimit:          limits.NewSetLimitUseCase(unitOfWork, cfg.LimitCoolingPeriod),
+		ListLimits:        limits.NewListLimitsUseCase(unitOfWork),
+		Exclude:           exclusions.NewExcludeUseCase(unitOfWork),
+		Spin:              spinUC,
+		GetFreeSpins:      spin.NewGetFreeSpinsUseCase(userRepo),
+		Autoplay:          spin.NewAutoplayUseCase(spinUC),
+	}, spinPaytable, videoPaytable)
 }
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	idempotencyUseCase "gambling/internal/application/use_case/idempotency"
+	"log/slog"
+	"time"
+)
+
+// idempotencyCleanupInterval - как часто удаляются ключи идемпотентности с истекшим сроком
+const idempotencyCleanupInterval = time.Hour
+
+// runIdempotencyCleanupJob удаляет истекшие ключи идемпотентности, пока не отменен ctx
+func runIdempotencyCleanupJob(ctx context.Context, guard *idempotencyUseCase.Guard, log *slog.Logger) {
+	const op = "app.runIdempotencyCleanupJob"
+
+	log = log.With(slog.String("operation", op))
+
+	ticker := time.NewTicker(idempotencyCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := guard.DeleteExpired()
+			if err != nil {
+				log.Error("failed to delete expired idempotency keys", slog.Any("error", err))
+				continue
+			}
+			if deleted > 0 {
+				log.Info("expired idempotency keys deleted", slog.Int64("count", deleted))
+			}
+		}
+	}
+}
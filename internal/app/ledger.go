@@ -0,0 +1,91 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	ledgerUseCase "gambling/internal/application/use_case/ledger"
+	"gambling/internal/config"
+	"gambling/internal/infrastructure/database/pgsql"
+	"gambling/internal/infrastructure/repository"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+)
+
+// Действия команды ledger
+const (
+	LedgerAccounts = "accounts"
+	LedgerRebuild  = "rebuild"
+)
+
+// Ledger подключается к базе данных и выполняет действие с главной книгой
+// dryRun используется только для LedgerRebuild
+func Ledger(cfg *config.Config, log *slog.Logger, action string, dryRun bool, stdout io.Writer) error {
+	const op = "app.Ledger"
+
+	log = log.With(slog.String("operation", op), slog.String("action", action))
+
+	storage := pgsql.New(cfg)
+	if err := storage.CheckSchema(); err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+
+	var err error
+	switch action {
+	case LedgerAccounts:
+		uc := ledgerUseCase.NewSystemAccountsUseCase(repository.NewLedgerRepository(storage.DB))
+		err = writeSystemAccounts(uc, stdout)
+	case LedgerRebuild:
+		uc := ledgerUseCase.NewRebuildBalancesUseCase(repository.NewUnitOfWork(storage.DB))
+		var result *ledgerUseCase.RebuildBalancesResult
+		result, err = uc.Execute(ledgerUseCase.RebuildBalancesCommand{DryRun: dryRun})
+		if err == nil {
+			log.Info("balances checked",
+				slog.Int("users", result.Checked),
+				slog.Int("drifts", len(result.Drifts)),
+				slog.Bool("dry_run", dryRun),
+			)
+			err = writeBalanceDrifts(result.Drifts, dryRun, stdout)
+		}
+	default:
+		err = fmt.Errorf("неизвестное действие %q: ожидается accounts или rebuild", action)
+	}
+
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+	return storage.Close()
+}
+
+func writeSystemAccounts(uc *ledgerUseCase.SystemAccountsUseCase, w io.Writer) error {
+	accounts, err := uc.Execute()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "СЧЕТ\tОСТАТОК")
+	for _, a := range accounts {
+		fmt.Fprintf(tw, "%s\t%s\n", a.Account, a.Balance.Format())
+	}
+	return tw.Flush()
+}
+
+func writeBalanceDrifts(drifts []ledgerUseCase.BalanceDrift, dryRun bool, w io.Writer) error {
+	if len(drifts) == 0 {
+		fmt.Fprintln(w, "Балансы совпадают с главной книгой")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ПОЛЬЗОВАТЕЛЬ\tБАЛАНС\tПО КНИГЕ")
+	for _, d := range drifts {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", d.UserID, d.Cached.Format(), d.Ledger.Format())
+	}
+	if dryRun {
+		fmt.Fprintf(tw, "\nНайдено расхождений: %d (не исправлены, запуск с -dry-run)\n", len(drifts))
+	} else {
+		fmt.Fprintf(tw, "\nИсправлено расхождений: %d\n", len(drifts))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/config"
+	"gambling/internal/infrastructure/database/pgsql"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+)
+
+// Действия команды migrate
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+)
+
+// Migrate подключается к базе данных, выполняет действие с миграциями и закрывает соединение
+// steps используется только для MigrateDown
+func Migrate(cfg *config.Config, log *slog.Logger, action string, steps int, stdout io.Writer) error {
+	const op = "app.Migrate"
+
+	log = log.With(slog.String("operation", op), slog.String("action", action))
+
+	storage := pgsql.New(cfg)
+
+	var err error
+	switch action {
+	case MigrateUp:
+		var applied []pgsql.Migration
+		applied, err = storage.MigrateUp()
+		for _, m := range applied {
+			log.Info("migration applied", slog.Int("version", m.Version), slog.String("name", m.Name))
+		}
+		if err == nil && len(applied) == 0 {
+			log.Info("schema is up to date")
+		}
+	case MigrateDown:
+		var reverted []pgsql.Migration
+		reverted, err = storage.MigrateDown(steps)
+		for _, m := range reverted {
+			log.Info("migration reverted", slog.Int("version", m.Version), slog.String("name", m.Name))
+		}
+	case MigrateStatus:
+		err = writeMigrationStatus(storage, stdout)
+	default:
+		err = fmt.Errorf("неизвестное действие %q: ожидается up, down или status", action)
+	}
+
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+	return storage.Close()
+}
+
+func writeMigrationStatus(storage *pgsql.Storage, w io.Writer) error {
+	status, err := storage.MigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ВЕРСИЯ\tМИГРАЦИЯ\tПРИМЕНЕНА")
+	pending := 0
+	for _, s := range status {
+		appliedAt := "—"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		} else {
+			pending++
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	fmt.Fprintf(tw, "\nНе применено: %d\n", pending)
+	return tw.Flush()
+}
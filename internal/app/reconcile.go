@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	reconcileUseCase "gambling/internal/application/use_case/reconciliation"
+	"gambling/internal/config"
+	"gambling/internal/domain/money"
+	"gambling/internal/infrastructure/database/pgsql"
+	"gambling/internal/infrastructure/repository"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReconcileOptions задает параметры команды сверки
+type ReconcileOptions struct {
+	UserID uint // 0 - все пользователи
+	Latest bool // Показать последний сохраненный отчет, не запуская сверку
+	JSON   bool // Печатать отчет в формате JSON
+}
+
+// Reconcile подключается к базе данных, сверяет балансы и печатает отчет
+func Reconcile(cfg *config.Config, log *slog.Logger, opts ReconcileOptions, stdout io.Writer) error {
+	const op = "app.Reconcile"
+
+	storage := pgsql.New(cfg)
+	if err := storage.CheckSchema(); err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+
+	var report *reconcileUseCase.ReportResult
+	var err error
+	if opts.Latest {
+		uc := reconcileUseCase.NewLatestReportUseCase(repository.NewReconciliationRepository(storage.DB))
+		report, err = uc.Execute()
+	} else {
+		report, err = newReconcileUseCase(storage.DB).Execute(reconcileUseCase.ReconcileCommand{UserID: opts.UserID})
+		if err == nil {
+			logReport(log.With(slog.String("operation", op)), report)
+		}
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+
+	if opts.JSON {
+		err = writeReportJSON(report, stdout)
+	} else {
+		err = writeReport(report, stdout)
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+	return storage.Close()
+}
+
+// runReconcileJob сверяет балансы каждые interval, пока не отменен ctx
+// Ошибка одного запуска не останавливает задачу: следующий запуск пройдет по расписанию
+func runReconcileJob(ctx context.Context, uc *reconcileUseCase.ReconcileUseCase, interval time.Duration, log *slog.Logger) {
+	const op = "app.runReconcileJob"
+
+	log = log.With(slog.String("operation", op))
+	log.Info("reconciliation job scheduled", slog.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := uc.Execute(reconcileUseCase.ReconcileCommand{})
+			if err != nil {
+				log.Error("reconciliation failed", slog.Any("error", err))
+				continue
+			}
+			logReport(log, report)
+		}
+	}
+}
+
+func newReconcileUseCase(db *gorm.DB) *reconcileUseCase.ReconcileUseCase {
+	return reconcileUseCase.NewReconcileUseCase(
+		repository.NewUnitOfWork(db),
+		repository.NewUserRepository(db),
+		repository.NewReconciliationRepository(db),
+	)
+}
+
+func logReport(log *slog.Logger, report *reconcileUseCase.ReportResult) {
+	attrs := []any{
+		slog.Uint64("run_id", uint64(report.RunID)),
+		slog.Int("users", report.UsersChecked),
+		slog.Int("discrepancies", len(report.Discrepancies)),
+	}
+	if len(report.Discrepancies) > 0 {
+		log.Warn("reconciliation found discrepancies", attrs...)
+		return
+	}
+	log.Info("reconciliation clean", attrs...)
+}
+
+// reportJSON - отчет сверки в формате JSON
+type reportJSON struct {
+	RunID         uint              `json:"run_id"`
+	StartedAt     time.Time         `json:"started_at"`
+	FinishedAt    time.Time         `json:"finished_at"`
+	UsersChecked  int               `json:"users_checked"`
+	Discrepancies []discrepancyJSON `json:"discrepancies"`
+}
+
+type discrepancyJSON struct {
+	UserID        uint        `json:"user_id"`
+	Kind          string      `json:"kind"`
+	TransactionID uint        `json:"transaction_id,omitempty"`
+	SpinID        uint        `json:"spin_id,omitempty"`
+	Expected      money.Money `json:"expected"`
+	Actual        money.Money `json:"actual"`
+}
+
+func writeReportJSON(report *reconcileUseCase.ReportResult, w io.Writer) error {
+	out := reportJSON{
+		RunID:         report.RunID,
+		StartedAt:     report.StartedAt,
+		FinishedAt:    report.FinishedAt,
+		UsersChecked:  report.UsersChecked,
+		Discrepancies: make([]discrepancyJSON, len(report.Discrepancies)),
+	}
+	for i, d := range report.Discrepancies {
+		out.Discrepancies[i] = discrepancyJSON{
+			UserID:        d.UserID,
+			Kind:          string(d.Kind),
+			TransactionID: d.TransactionID,
+			SpinID:        d.SpinID,
+			Expected:      d.Expected,
+			Actual:        d.Actual,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func writeReport(report *reconcileUseCase.ReportResult, w io.Writer) error {
+	fmt.Fprintf(w, "Сверка #%d: проверено пользователей %d, расхождений %d\n",
+		report.RunID, report.UsersChecked, len(report.Discrepancies))
+	if len(report.Discrepancies) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nПОЛЬЗОВАТЕЛЬ\tВИД\tТРАНЗАКЦИЯ\tСПИН\tОЖИДАЛОСЬ\tФАКТИЧЕСКИ")
+	for _, d := range report.Discrepancies {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			d.UserID, d.Kind, optionalID(d.TransactionID), optionalID(d.SpinID),
+			d.Expected.Format(), d.Actual.Format())
+	}
+	return tw.Flush()
+}
+
+func optionalID(id uint) string {
+	if id == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("#%d", id)
+}
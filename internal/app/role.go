@@ -0,0 +1,29 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/auth"
+	"gambling/internal/config"
+	"gambling/internal/infrastructure/database/pgsql"
+	"gambling/internal/infrastructure/repository"
+	"log/slog"
+)
+
+// SetRole назначает роль пользователю (например, оператора для рассмотрения выводов)
+func SetRole(cfg *config.Config, log *slog.Logger, username, role string) error {
+	const op = "app.SetRole"
+
+	storage := pgsql.New(cfg)
+	if err := storage.CheckSchema(); err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+
+	setRoleUseCase := auth.NewSetRoleUseCase(repository.NewUserRepository(storage.DB))
+	if err := setRoleUseCase.Execute(auth.SetRoleCommand{Username: username, Role: role}); err != nil {
+		return errors.Join(fmt.Errorf("%s: %w", op, err), storage.Close())
+	}
+
+	log.Info("role updated", slog.String("operation", op), slog.String("username", username), slog.String("role", role))
+	return storage.Close()
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"crypto/rand"
+	"gambling/internal/config"
+	"gambling/internal/infrastructure/token"
+)
+
+// tokenIssuer - издатель (iss) access токенов
+const tokenIssuer = "gambling"
+
+// mustTokenSigner создает подписчик access токенов из JWT_SECRET
+// Паникует, если секрет слишком короткий: с таким секретом токены можно подделать
+func mustTokenSigner(cfg *config.Config) *token.JWTSigner {
+	signer, err := token.NewJWTSigner([]byte(cfg.JWTSecret), tokenIssuer)
+	if err != nil {
+		panic("failed to create token signer: " + err.Error())
+	}
+	return signer
+}
+
+// consoleTokenSigner создает подписчик для консольного клиента
+// Консоль не передает токены наружу, поэтому без JWT_SECRET используется
+// случайный секрет, который живет до завершения процесса
+func consoleTokenSigner(cfg *config.Config) *token.JWTSigner {
+	if cfg.JWTSecret != "" {
+		return mustTokenSigner(cfg)
+	}
+
+	secret := make([]byte, token.MinSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		panic("failed to generate token secret: " + err.Error())
+	}
+	signer, err := token.NewJWTSigner(secret, tokenIssuer)
+	if err != nil {
+		panic("failed to create token signer: " + err.Error())
+	}
+	return signer
+}
@@ -0,0 +1,184 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"math"
+	"math/big"
+)
+
+// volatilityZ - квантиль нормального распределения для индекса волатильности (доверие 90%)
+const volatilityZ = 1.645
+
+// AnalyzeUseCase представляет use case для точного расчета математики автомата (PAR sheet)
+// Перебирает все комбинации барабанов с их весами и применяет CalculateWin,
+// поэтому результат в точности соответствует тому, что платит игра
+type AnalyzeUseCase struct {
+	spinService *spin.Service
+}
+
+// NewAnalyzeUseCase создает новый use case для анализа таблицы выплат
+func NewAnalyzeUseCase(spinService *spin.Service) *AnalyzeUseCase {
+	return &AnalyzeUseCase{
+		spinService: spinService,
+	}
+}
+
+// AnalyzeCommand представляет команду для анализа
+// Ставка важна: выплаты округляются вниз до копейки, и на малых ставках RTP ниже
+type AnalyzeCommand struct {
+	BetAmount money.Money
+}
+
+// CombinationStat описывает вклад одной выигрышной комбинации
+type CombinationStat struct {
+	Label        string   // Комбинация: символы барабанов ("1-2-3") или символы на линии ("5 x 7")
+	Weight       *big.Int // Число исходов с этой комбинацией (с учетом весов)
+	Probability  *big.Rat // Вероятность комбинации
+	WinAmount    money.Money
+	Multiplier   *big.Rat // Фактический коэффициент: выигрыш / ставка
+	Contribution *big.Rat // Вклад в RTP: вероятность * коэффициент
+}
+
+// ParSheet представляет результат анализа (PAR sheet)
+type ParSheet struct {
+	PaytableVersion string
+	BetAmount       money.Money
+	TotalWeight     *big.Int // Число равновероятных исходов: произведение сумм весов или длин лент
+	// Lines - число линий выплат (0 у классического автомата)
+	// Комбинации линейной игры считаются для одной линии, а их коэффициент - к ставке на линию
+	Lines        int
+	Combinations []CombinationStat
+	// RTP не включает джекпот: пул в среднем возвращает игрокам ровно ту долю ставок,
+	// которая в него отчисляется, и эта доля добавляется к RTP сверху
+	// Выигрыши бесплатных спинов входят в RTP (см. FreeSpinsRTP)
+	RTP *big.Rat
+	// HitFrequency, дисперсия и максимальный выигрыш считаются для одного платного раунда,
+	// без бесплатных спинов
+	HitFrequency      *big.Rat
+	Variance          float64 // Дисперсия выплаты в единицах ставки
+	StdDev            float64
+	VolatilityIndex   float64 // volatilityZ * StdDev
+	MaxWin            money.Money
+	MaxWinProbability *big.Rat
+	// JackpotProbability - вероятность комбинации джекпота (nil, если джекпота в таблице нет)
+	JackpotProbability *big.Rat
+	// FreeSpinsProbability - вероятность запуска бесплатных спинов за раунд
+	// (nil, если бонуса в таблице нет); FreeSpinsPerBonus - среднее число
+	// бесплатных спинов за бонус с учетом повторных запусков;
+	// FreeSpinsRTP - вклад бонуса в RTP в единицах ставки платного раунда
+	FreeSpinsProbability *big.Rat
+	FreeSpinsPerBonus    *big.Rat
+	FreeSpinsRTP         *big.Rat
+}
+
+// Execute перебирает все комбинации символов и строит PAR sheet
+func (uc *AnalyzeUseCase) Execute(cmd AnalyzeCommand) (*ParSheet, error) {
+	if !cmd.BetAmount.IsPositive() {
+		return nil, errors.New("ставка для анализа должна быть положительной")
+	}
+
+	paytable := uc.spinService.Paytable()
+	bet := big.NewRat(cmd.BetAmount.Amount(), 1)
+
+	total := big.NewInt(int64(paytable.TotalWeight()))
+	total.Exp(total, big.NewInt(spin.ReelCount), nil)
+
+	sheet := &ParSheet{
+		PaytableVersion:   paytable.Version,
+		BetAmount:         cmd.BetAmount,
+		TotalWeight:       total,
+		RTP:               new(big.Rat),
+		HitFrequency:      new(big.Rat),
+		MaxWin:            money.Zero(cmd.BetAmount.Currency()),
+		MaxWinProbability: new(big.Rat),
+	}
+	secondMoment := new(big.Rat)
+	if paytable.Jackpot != nil {
+		sheet.JackpotProbability = new(big.Rat)
+	}
+
+	// Распределение выплаты раунда и вес исходов, запускающих бонус, нужны для
+	// расчета бесплатных спинов
+	wins := make(map[int64]*big.Int)
+	trigger := new(big.Int)
+
+	symbols := paytable.Symbols
+	for _, s1 := range symbols {
+		for _, s2 := range symbols {
+			for _, s3 := range symbols {
+				weight := big.NewInt(int64(s1.Weight))
+				weight.Mul(weight, big.NewInt(int64(s2.Weight)))
+				weight.Mul(weight, big.NewInt(int64(s3.Weight)))
+
+				win, err := uc.spinService.CalculateWin(s1.Symbol, s2.Symbol, s3.Symbol, cmd.BetAmount)
+				if err != nil {
+					return nil, err
+				}
+				if wins[win.Amount()] == nil {
+					wins[win.Amount()] = new(big.Int)
+				}
+				wins[win.Amount()].Add(wins[win.Amount()], weight)
+				scatter, err := uc.spinService.ScatterWin(s1.Symbol, s2.Symbol, s3.Symbol, cmd.BetAmount)
+				if err != nil {
+					return nil, err
+				}
+				if scatter != nil && scatter.FreeSpins > 0 {
+					trigger.Add(trigger, weight)
+				}
+
+				jackpot := uc.spinService.IsJackpot(s1.Symbol, s2.Symbol, s3.Symbol)
+				if !win.IsPositive() && !jackpot {
+					continue
+				}
+
+				probability := new(big.Rat).SetFrac(weight, total)
+				if jackpot {
+					sheet.JackpotProbability.Add(sheet.JackpotProbability, probability)
+				}
+				if !win.IsPositive() {
+					continue
+				}
+				multiplier := new(big.Rat).Quo(big.NewRat(win.Amount(), 1), bet)
+				contribution := new(big.Rat).Mul(probability, multiplier)
+
+				sheet.Combinations = append(sheet.Combinations, CombinationStat{
+					Label:        fmt.Sprintf("%d-%d-%d", s1.Symbol, s2.Symbol, s3.Symbol),
+					Weight:       weight,
+					Probability:  probability,
+					WinAmount:    win,
+					Multiplier:   multiplier,
+					Contribution: contribution,
+				})
+
+				sheet.RTP.Add(sheet.RTP, contribution)
+				sheet.HitFrequency.Add(sheet.HitFrequency, probability)
+				secondMoment.Add(secondMoment, new(big.Rat).Mul(contribution, multiplier))
+
+				switch {
+				case sheet.MaxWin.LessThan(win):
+					sheet.MaxWin = win
+					sheet.MaxWinProbability = new(big.Rat).Set(probability)
+				case sheet.MaxWin == win:
+					sheet.MaxWinProbability.Add(sheet.MaxWinProbability, probability)
+				}
+			}
+		}
+	}
+
+	// Var(X) = E[X^2] - E[X]^2, где X - выплата в единицах ставки
+	variance := new(big.Rat).Sub(secondMoment, new(big.Rat).Mul(sheet.RTP, sheet.RTP))
+	sheet.Variance, _ = variance.Float64()
+	sheet.StdDev = math.Sqrt(sheet.Variance)
+	sheet.VolatilityIndex = volatilityZ * sheet.StdDev
+
+	if paytable.Scatter != nil && paytable.Scatter.FreeSpins != nil {
+		if err := addFreeSpins(sheet, paytable.Scatter.FreeSpins, wins, trigger, cmd.BetAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	return sheet, nil
+}
@@ -0,0 +1,374 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"math"
+	"math/big"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// AnalyzeVideoUseCase представляет use case для точного расчета математики видеослота
+// Перебирает все позиции остановки лент и оценивает каждую линию выплат по правилам
+// VideoService, поэтому результат в точности соответствует тому, что платит игра
+type AnalyzeVideoUseCase struct {
+	video *spin.VideoService
+}
+
+// NewAnalyzeVideoUseCase создает новый use case для анализа таблицы видеослота
+func NewAnalyzeVideoUseCase(video *spin.VideoService) *AnalyzeVideoUseCase {
+	return &AnalyzeVideoUseCase{
+		video: video,
+	}
+}
+
+// videoLinePay - выигрыш линии в минорных единицах и оплаченная длина комбинации
+// rank - место коэффициента среди всех коэффициентов таблицы: комбинации с wild
+// сравниваются по коэффициенту, как в VideoPaytable.EvaluateLine
+type videoLinePay struct {
+	win  int64
+	paid int
+	rank int
+}
+
+// videoRules - данные таблицы, нужные перебору; символы заданы номерами, -1 - роли нет
+type videoRules struct {
+	paylines []spin.Payline
+	pays     [][spin.VideoReelCount + 1]videoLinePay
+	wild     int
+	scatter  int
+	// scatters[count] - выигрыш за count scatter в окне и признак запуска бонуса
+	scatters [spin.VideoReelCount*spin.VideoRowCount + 1]videoScatterPay
+}
+
+// videoScatterPay - выигрыш за scatter в минорных единицах и признак запуска бонуса
+type videoScatterPay struct {
+	win     int64
+	trigger bool
+}
+
+// videoStats накапливает статистику перебора для части позиций первой ленты
+type videoStats struct {
+	// wins - число исходов с данным суммарным выигрышем раунда
+	wins map[int64]int64
+	// lines[symbol][paid] - число пар (исход, линия), оплаченных как paid символов symbol
+	lines [][spin.VideoReelCount + 1]int64
+	// scatters[count] - число исходов с count scatter в окне
+	scatters [spin.VideoReelCount*spin.VideoRowCount + 1]int64
+	// bonuses - число исходов, запускающих бесплатные спины
+	bonuses int64
+}
+
+// Execute перебирает все позиции остановки лент и строит PAR sheet
+// Ставка - общая ставка на раунд, она должна делиться на число линий
+func (uc *AnalyzeVideoUseCase) Execute(cmd AnalyzeCommand) (*ParSheet, error) {
+	if !cmd.BetAmount.IsPositive() {
+		return nil, errors.New("ставка для анализа должна быть положительной")
+	}
+	lineBet, err := uc.video.LineBet(cmd.BetAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	paytable := uc.video.Paytable()
+
+	// Символы нумеруются по порядку, чтобы в переборе обращаться к срезам, а не к картам
+	var symbols []int
+	index := make(map[int]int)
+	for _, strip := range paytable.Reels {
+		for _, symbol := range strip {
+			if _, ok := index[symbol]; !ok {
+				index[symbol] = len(symbols)
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+
+	// ranks - коэффициенты таблицы по возрастанию
+	var ranks []spin.Multiplier
+	for _, lp := range paytable.Pays {
+		for _, m := range lp.Pays {
+			ranks = append(ranks, m)
+		}
+	}
+	slices.SortFunc(ranks, func(a, b spin.Multiplier) int {
+		return a.Rat().Cmp(b.Rat())
+	})
+
+	rules := videoRules{
+		paylines: paytable.Paylines,
+		wild:     -1,
+		scatter:  -1,
+	}
+	// pays[i][count] - выигрыш линии, на которой слева подряд стоят count символов symbols[i]
+	rules.pays = make([][spin.VideoReelCount + 1]videoLinePay, len(symbols))
+	for i, symbol := range symbols {
+		for count := 1; count <= spin.VideoReelCount; count++ {
+			win, paid, err := uc.video.LinePayout(symbol, count, lineBet)
+			if err != nil {
+				return nil, err
+			}
+			pay := videoLinePay{win: win.Amount(), paid: paid}
+			if paid > 0 {
+				m, _ := paytable.LinePay(symbol, count)
+				pay.rank = slices.IndexFunc(ranks, func(r spin.Multiplier) bool { return !r.Less(m) }) + 1
+			}
+			rules.pays[i][count] = pay
+		}
+	}
+	if paytable.Wild != nil {
+		rules.wild = index[paytable.Wild.Symbol]
+	}
+	if paytable.Scatter != nil {
+		rules.scatter = index[paytable.Scatter.Symbol]
+		for count := 1; count < len(rules.scatters); count++ {
+			win, err := paytable.Scatter.Evaluate(count, cmd.BetAmount)
+			if err != nil {
+				return nil, err
+			}
+			if win != nil {
+				rules.scatters[count] = videoScatterPay{win: win.Win.Amount(), trigger: win.FreeSpins > 0}
+			}
+		}
+	}
+
+	// windows[reel][stop] - номера символов в окне барабана при остановке ленты на stop
+	windows := make([][][spin.VideoRowCount]int, spin.VideoReelCount)
+	for reel, strip := range paytable.Reels {
+		windows[reel] = make([][spin.VideoRowCount]int, len(strip))
+		for stop := range strip {
+			for row := 0; row < spin.VideoRowCount; row++ {
+				windows[reel][stop][row] = index[strip[(stop+row)%len(strip)]]
+			}
+		}
+	}
+
+	// Позиции первой ленты распределяются между воркерами
+	workers := min(runtime.NumCPU(), len(windows[0]))
+	stops := make(chan int)
+	results := make([]*videoStats, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		results[w] = &videoStats{
+			wins:  make(map[int64]int64),
+			lines: make([][spin.VideoReelCount + 1]int64, len(symbols)),
+		}
+		wg.Add(1)
+		go func(stats *videoStats) {
+			defer wg.Done()
+			for stop := range stops {
+				enumerateVideo(stats, windows, stop, &rules)
+			}
+		}(results[w])
+	}
+	for stop := range windows[0] {
+		stops <- stop
+	}
+	close(stops)
+	wg.Wait()
+
+	total := big.NewInt(1)
+	for _, strip := range paytable.Reels {
+		total.Mul(total, big.NewInt(int64(len(strip))))
+	}
+	lines := len(paytable.Paylines)
+
+	sheet := &ParSheet{
+		PaytableVersion:   paytable.Version,
+		BetAmount:         cmd.BetAmount,
+		TotalWeight:       total,
+		Lines:             lines,
+		RTP:               new(big.Rat),
+		HitFrequency:      new(big.Rat),
+		MaxWin:            money.Zero(cmd.BetAmount.Currency()),
+		MaxWinProbability: new(big.Rat),
+	}
+
+	// Комбинации на линии: у всех линий одинаковое распределение, так как каждый ряд
+	// окна пробегает всю ленту, поэтому вес комбинации для одной линии - сумма по линиям / lines
+	lineBetRat := big.NewRat(lineBet.Amount(), 1)
+	for _, lp := range paytable.Pays {
+		i, ok := index[lp.Symbol]
+		if !ok {
+			continue
+		}
+		for paid := 1; paid <= spin.VideoReelCount; paid++ {
+			var count int64
+			for _, stats := range results {
+				count += stats.lines[i][paid]
+			}
+			if count == 0 {
+				continue
+			}
+
+			win, _, err := uc.video.LinePayout(lp.Symbol, paid, lineBet)
+			if err != nil {
+				return nil, err
+			}
+			weight := new(big.Int).Quo(big.NewInt(count), big.NewInt(int64(lines)))
+			probability := new(big.Rat).SetFrac(weight, total)
+			multiplier := new(big.Rat).Quo(big.NewRat(win.Amount(), 1), lineBetRat)
+
+			sheet.Combinations = append(sheet.Combinations, CombinationStat{
+				Label:        fmt.Sprintf("%d x %d", paid, lp.Symbol),
+				Weight:       weight,
+				Probability:  probability,
+				WinAmount:    win,
+				Multiplier:   multiplier,
+				Contribution: new(big.Rat).Mul(probability, multiplier),
+			})
+		}
+	}
+
+	// Scatter платит к общей ставке, поэтому и коэффициент его комбинаций - к общей ставке
+	bet := big.NewRat(cmd.BetAmount.Amount(), 1)
+	if paytable.Scatter != nil {
+		for count, pay := range rules.scatters {
+			if pay.win == 0 {
+				continue
+			}
+			weight := new(big.Int)
+			for _, stats := range results {
+				weight.Add(weight, big.NewInt(stats.scatters[count]))
+			}
+			if weight.Sign() == 0 {
+				continue
+			}
+
+			probability := new(big.Rat).SetFrac(weight, total)
+			multiplier := new(big.Rat).Quo(big.NewRat(pay.win, 1), bet)
+			sheet.Combinations = append(sheet.Combinations, CombinationStat{
+				Label:        fmt.Sprintf("%d x %d (scatter)", count, paytable.Scatter.Symbol),
+				Weight:       weight,
+				Probability:  probability,
+				WinAmount:    money.New(pay.win, cmd.BetAmount.Currency()),
+				Multiplier:   multiplier,
+				Contribution: new(big.Rat).Mul(probability, multiplier),
+			})
+		}
+	}
+
+	// Показатели раунда считаются по распределению суммарного выигрыша всех линий и scatter
+	secondMoment := new(big.Rat)
+	wins := make(map[int64]int64)
+	bonuses := new(big.Int)
+	for _, stats := range results {
+		for win, count := range stats.wins {
+			wins[win] += count
+		}
+		bonuses.Add(bonuses, big.NewInt(stats.bonuses))
+	}
+	for amount, count := range wins {
+		if amount == 0 {
+			continue
+		}
+		probability := new(big.Rat).SetFrac(big.NewInt(count), total)
+		multiplier := new(big.Rat).Quo(big.NewRat(amount, 1), bet)
+		contribution := new(big.Rat).Mul(probability, multiplier)
+
+		sheet.RTP.Add(sheet.RTP, contribution)
+		sheet.HitFrequency.Add(sheet.HitFrequency, probability)
+		secondMoment.Add(secondMoment, new(big.Rat).Mul(contribution, multiplier))
+
+		win := money.New(amount, cmd.BetAmount.Currency())
+		if sheet.MaxWin.LessThan(win) {
+			sheet.MaxWin = win
+			sheet.MaxWinProbability = probability
+		}
+	}
+
+	// Var(X) = E[X^2] - E[X]^2, где X - выплата раунда в единицах общей ставки
+	variance := new(big.Rat).Sub(secondMoment, new(big.Rat).Mul(sheet.RTP, sheet.RTP))
+	sheet.Variance, _ = variance.Float64()
+	sheet.StdDev = math.Sqrt(sheet.Variance)
+	sheet.VolatilityIndex = volatilityZ * sheet.StdDev
+
+	if paytable.Scatter != nil && paytable.Scatter.FreeSpins != nil {
+		distribution := make(map[int64]*big.Int, len(wins))
+		for amount, count := range wins {
+			distribution[amount] = big.NewInt(count)
+		}
+		if err := addFreeSpins(sheet, paytable.Scatter.FreeSpins, distribution, bonuses, cmd.BetAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	return sheet, nil
+}
+
+// enumerateVideo перебирает все исходы, в которых первая лента остановилась на stop0
+func enumerateVideo(stats *videoStats, windows [][][spin.VideoRowCount]int, stop0 int, rules *videoRules) {
+	var grid [spin.VideoReelCount][spin.VideoRowCount]int
+	grid[0] = windows[0][stop0]
+	for _, w1 := range windows[1] {
+		grid[1] = w1
+		for _, w2 := range windows[2] {
+			grid[2] = w2
+			for _, w3 := range windows[3] {
+				grid[3] = w3
+				for _, w4 := range windows[4] {
+					grid[4] = w4
+
+					var total int64
+					for _, line := range rules.paylines {
+						var symbols [spin.VideoReelCount]int
+						for reel, row := range line {
+							symbols[reel] = grid[reel][row]
+						}
+						symbol, pay := rules.evaluateLine(symbols)
+						if pay.paid > 0 {
+							total += pay.win
+							stats.lines[symbol][pay.paid]++
+						}
+					}
+
+					if rules.scatter >= 0 {
+						count := 0
+						for _, window := range grid {
+							for _, symbol := range window {
+								if symbol == rules.scatter {
+									count++
+								}
+							}
+						}
+						stats.scatters[count]++
+						total += rules.scatters[count].win
+						if rules.scatters[count].trigger {
+							stats.bonuses++
+						}
+					}
+					stats.wins[total]++
+				}
+			}
+		}
+	}
+}
+
+// evaluateLine повторяет VideoPaytable.EvaluateLine на номерах символов
+func (r *videoRules) evaluateLine(symbols [spin.VideoReelCount]int) (int, videoLinePay) {
+	wilds := 0
+	for wilds < spin.VideoReelCount && symbols[wilds] == r.wild {
+		wilds++
+	}
+
+	var best videoLinePay
+	bestSymbol := 0
+	if wilds > 0 {
+		best, bestSymbol = r.pays[r.wild][wilds], r.wild
+	}
+	if wilds < spin.VideoReelCount && symbols[wilds] != r.scatter {
+		symbol := symbols[wilds]
+		count := wilds + 1
+		for count < spin.VideoReelCount && (symbols[count] == symbol || symbols[count] == r.wild) {
+			count++
+		}
+		if pay := r.pays[symbol][count]; pay.paid > 0 && pay.rank > best.rank {
+			best, bestSymbol = pay, symbol
+		}
+	}
+	return bestSymbol, best
+}
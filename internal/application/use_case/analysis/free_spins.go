@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"fmt"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"math/big"
+)
+
+// addFreeSpins добавляет в PAR sheet показатели бонуса бесплатных спинов
+// wins - распределение выплаты раунда: выигрыш в минорных единицах -> число исходов,
+// trigger - число исходов, запускающих бонус. Бесплатные спины играются на тех же
+// барабанах по той же ставке, поэтому каждый из них снова запускает бонус с той же
+// вероятностью p, и среднее число спинов бонуса E = N + N*p*E = N / (1 - N*p)
+func addFreeSpins(sheet *ParSheet, rule *spin.FreeSpinsRule, wins map[int64]*big.Int, trigger *big.Int, bet money.Money) error {
+	p := new(big.Rat).SetFrac(trigger, sheet.TotalWeight)
+	sheet.FreeSpinsProbability = p
+
+	spins := big.NewRat(int64(rule.Spins), 1)
+	rest := new(big.Rat).Sub(big.NewRat(1, 1), new(big.Rat).Mul(spins, p))
+	if rest.Sign() <= 0 {
+		return fmt.Errorf("бонус бесплатных спинов не заканчивается: %d спинов при вероятности повторного запуска %s",
+			rule.Spins, p.FloatString(8))
+	}
+	sheet.FreeSpinsPerBonus = new(big.Rat).Quo(spins, rest)
+
+	// Средний выигрыш бесплатного спина в единицах ставки: коэффициент бонуса
+	// применяется к каждой выплате с тем же округлением, что и в игре
+	mean := new(big.Rat)
+	for amount, count := range wins {
+		win, err := spin.FreeSpinWin(money.New(amount, bet.Currency()), rule.Multiplier)
+		if err != nil {
+			return err
+		}
+		if !win.IsPositive() {
+			continue
+		}
+		mean.Add(mean, new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(win.Amount()), count), sheet.TotalWeight))
+	}
+	mean.Quo(mean, big.NewRat(bet.Amount(), 1))
+
+	sheet.FreeSpinsRTP = new(big.Rat).Mul(p, sheet.FreeSpinsPerBonus)
+	sheet.FreeSpinsRTP.Mul(sheet.FreeSpinsRTP, mean)
+	sheet.RTP.Add(sheet.RTP, sheet.FreeSpinsRTP)
+	return nil
+}
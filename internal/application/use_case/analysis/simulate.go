@@ -0,0 +1,331 @@
+package analysis
+
+import (
+	"errors"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
+	"gambling/internal/domain/spin"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// confidenceZ - квантиль нормального распределения для доверительных интервалов (95%)
+const confidenceZ = 1.96
+
+// Machine - математика игры, которую крутит симулятор
+// Реализуется spin.Service и spin.VideoService
+type Machine interface {
+	// Version возвращает версию таблицы выплат
+	Version() string
+	// ValidateBet проверяет, что ставку можно сыграть
+	ValidateBet(bet money.Money) error
+	// Payout разыгрывает раунд из src и возвращает исход без данных:
+	// выигрыш, признак джекпота и выигранные бесплатные спины
+	Payout(src rng.Source, bet money.Money) (game.Outcome, error)
+}
+
+var (
+	_ Machine = (*spin.Service)(nil)
+	_ Machine = (*spin.VideoService)(nil)
+)
+
+// SimulateUseCase представляет use case для Monte Carlo симуляции автомата
+// Работает напрямую с доменным сервисом, без базы данных: сервис не хранит состояния,
+// а каждый воркер получает собственный независимый поток случайных чисел
+type SimulateUseCase struct {
+	machine Machine
+}
+
+// NewSimulateUseCase создает новый use case для симуляции
+func NewSimulateUseCase(machine Machine) *SimulateUseCase {
+	return &SimulateUseCase{
+		machine: machine,
+	}
+}
+
+// SimulateCommand представляет команду для симуляции
+type SimulateCommand struct {
+	Spins     int64
+	Workers   int
+	Seed      int64 // Базовое зерно; потоки воркеров выводятся из него
+	BetAmount money.Money
+	// StartingBalance и SessionSpins задают сессии для оценки вероятности разорения:
+	// сессия заканчивается разорением, если баланс стал меньше ставки,
+	// или выживанием после SessionSpins спинов. Нулевой баланс отключает оценку
+	StartingBalance money.Money
+	SessionSpins    int64
+}
+
+// HistogramBucket описывает, сколько раз выпал выигрыш определенного размера
+type HistogramBucket struct {
+	WinAmount money.Money
+	Count     int64
+}
+
+// SimulateResult представляет результат симуляции
+type SimulateResult struct {
+	PaytableVersion string
+	Spins           int64
+	Workers         int
+	TotalBet        money.Money
+	TotalWin        money.Money
+	RTP             float64
+	RTPLow          float64 // Нижняя граница 95% доверительного интервала RTP
+	RTPHigh         float64 // Верхняя граница 95% доверительного интервала RTP
+	StdDev          float64 // Стандартное отклонение выплаты в единицах ставки
+	HitFrequency    float64
+	// JackpotHits - сколько раз выпала комбинация джекпота; выплаты из пула
+	// в TotalWin и RTP не входят, так как зависят от накопленной суммы
+	JackpotHits int64
+	// FreeSpinsTriggers - сколько раз запущен бонус, FreeSpinsPlayed - сколько сыграно бесплатных спинов
+	// Бесплатные спины не считаются в Spins, а их выигрыши входят в выигрыш запустившего их раунда
+	FreeSpinsTriggers   int64
+	FreeSpinsPlayed     int64
+	Histogram           []HistogramBucket
+	LongestLosingStreak int64
+	Sessions            int64
+	RuinedSessions      int64
+	RuinProbability     float64
+	RuinLow             float64
+	RuinHigh            float64
+	Duration            time.Duration
+}
+
+// workerStats накапливает статистику одного воркера
+type workerStats struct {
+	spins          int64
+	wins           int64
+	jackpots       int64
+	bonuses        int64
+	freeSpins      int64
+	totalWin       int64
+	sumSquares     float64
+	histogram      map[int64]int64
+	longestStreak  int64
+	sessions       int64
+	ruinedSessions int64
+}
+
+// Execute выполняет симуляцию в нескольких горутинах и объединяет результаты
+func (uc *SimulateUseCase) Execute(cmd SimulateCommand) (*SimulateResult, error) {
+	if cmd.Spins <= 0 {
+		return nil, errors.New("число спинов должно быть положительным")
+	}
+	if cmd.Workers <= 0 {
+		return nil, errors.New("число воркеров должно быть положительным")
+	}
+	if !cmd.BetAmount.IsPositive() {
+		return nil, errors.New("ставка должна быть положительной")
+	}
+	if cmd.StartingBalance.IsPositive() && cmd.SessionSpins <= 0 {
+		return nil, errors.New("длина сессии должна быть положительной")
+	}
+	if err := uc.machine.ValidateBet(cmd.BetAmount); err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	stats := make([]*workerStats, cmd.Workers)
+	errs := make([]error, cmd.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < cmd.Workers; i++ {
+		spins := cmd.Spins / int64(cmd.Workers)
+		if int64(i) < cmd.Spins%int64(cmd.Workers) {
+			spins++
+		}
+
+		src := rng.NewSeededSource(workerSeed(cmd.Seed, i))
+
+		wg.Add(1)
+		go func(i int, spins int64) {
+			defer wg.Done()
+			stats[i], errs[i] = simulateWorker(uc.machine, src, cmd, spins)
+		}(i, spins)
+	}
+	wg.Wait()
+
+	// Ошибка раунда искажает RTP, поэтому симуляция прерывается, а не считает раунд проигрышем
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return uc.merge(cmd, stats, time.Since(started)), nil
+}
+
+// simulateWorker крутит spins спинов на собственном потоке случайных чисел
+// Останавливается на первой ошибке раунда
+func simulateWorker(machine Machine, src rng.Source, cmd SimulateCommand, spins int64) (*workerStats, error) {
+	stats := &workerStats{histogram: make(map[int64]int64)}
+	bet := cmd.BetAmount.Amount()
+	trackRuin := cmd.StartingBalance.IsPositive()
+
+	var streak, sessionSpins int64
+	bankroll := cmd.StartingBalance.Amount()
+
+	for n := int64(0); n < spins; n++ {
+		if trackRuin && bankroll < bet {
+			stats.sessions++
+			stats.ruinedSessions++
+			bankroll, sessionSpins = cmd.StartingBalance.Amount(), 0
+		}
+
+		round, err := machine.Payout(src, cmd.BetAmount)
+		if err != nil {
+			return nil, err
+		}
+		win := round.Payout.Amount()
+		if round.Jackpot {
+			stats.jackpots++
+		}
+		if round.FreeSpins != nil {
+			stats.bonuses++
+			bonusWin, err := playFreeSpins(machine, src, cmd.BetAmount, round.FreeSpins, stats)
+			if err != nil {
+				return nil, err
+			}
+			win += bonusWin
+		}
+
+		stats.spins++
+		stats.totalWin += win
+		stats.histogram[win]++
+
+		multiplier := float64(win) / float64(bet)
+		stats.sumSquares += multiplier * multiplier
+
+		if win > 0 {
+			stats.wins++
+			streak = 0
+		} else {
+			streak++
+			if streak > stats.longestStreak {
+				stats.longestStreak = streak
+			}
+		}
+
+		if trackRuin {
+			bankroll += win - bet
+			sessionSpins++
+			if sessionSpins == cmd.SessionSpins {
+				stats.sessions++
+				bankroll, sessionSpins = cmd.StartingBalance.Amount(), 0
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// playFreeSpins разыгрывает бесплатные спины бонуса award и возвращает их суммарный выигрыш
+// Как и в SpinUseCase, повторный запуск добавляет спины, а коэффициент остается от первого запуска
+func playFreeSpins(machine Machine, src rng.Source, bet money.Money, award *game.FreeSpinsAward, stats *workerStats) (int64, error) {
+	multiplier, err := spin.NewMultiplier(award.Num, award.Den)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for remaining := award.Spins; remaining > 0; remaining-- {
+		round, err := machine.Payout(src, bet)
+		if err != nil {
+			return 0, err
+		}
+		win, err := spin.FreeSpinWin(round.Payout, multiplier)
+		if err != nil {
+			return 0, err
+		}
+		total += win.Amount()
+		stats.freeSpins++
+		if round.FreeSpins != nil {
+			remaining += round.FreeSpins.Spins
+		}
+	}
+	return total, nil
+}
+
+// merge объединяет статистику воркеров в итоговый результат
+func (uc *SimulateUseCase) merge(cmd SimulateCommand, stats []*workerStats, duration time.Duration) *SimulateResult {
+	currency := cmd.BetAmount.Currency()
+	bet := cmd.BetAmount.Amount()
+
+	var spins, wins, jackpots, bonuses, freeSpins, totalWin, longest, sessions, ruined int64
+	var sumSquares float64
+	histogram := make(map[int64]int64)
+	for _, s := range stats {
+		spins += s.spins
+		wins += s.wins
+		jackpots += s.jackpots
+		bonuses += s.bonuses
+		freeSpins += s.freeSpins
+		totalWin += s.totalWin
+		sumSquares += s.sumSquares
+		sessions += s.sessions
+		ruined += s.ruinedSessions
+		if s.longestStreak > longest {
+			longest = s.longestStreak
+		}
+		for win, count := range s.histogram {
+			histogram[win] += count
+		}
+	}
+
+	n := float64(spins)
+	rtp := float64(totalWin) / (float64(bet) * n)
+	variance := math.Max(sumSquares/n-rtp*rtp, 0)
+	stdDev := math.Sqrt(variance)
+	margin := confidenceZ * stdDev / math.Sqrt(n)
+
+	result := &SimulateResult{
+		PaytableVersion:     uc.machine.Version(),
+		Spins:               spins,
+		Workers:             len(stats),
+		TotalBet:            money.New(bet*spins, currency),
+		TotalWin:            money.New(totalWin, currency),
+		RTP:                 rtp,
+		RTPLow:              rtp - margin,
+		RTPHigh:             rtp + margin,
+		StdDev:              stdDev,
+		HitFrequency:        float64(wins) / n,
+		JackpotHits:         jackpots,
+		FreeSpinsTriggers:   bonuses,
+		FreeSpinsPlayed:     freeSpins,
+		LongestLosingStreak: longest,
+		Sessions:            sessions,
+		RuinedSessions:      ruined,
+		Duration:            duration,
+	}
+
+	if sessions > 0 {
+		// Интервал Вальда для доли разорившихся сессий
+		p := float64(ruined) / float64(sessions)
+		ruinMargin := confidenceZ * math.Sqrt(p*(1-p)/float64(sessions))
+		result.RuinProbability = p
+		result.RuinLow = math.Max(p-ruinMargin, 0)
+		result.RuinHigh = math.Min(p+ruinMargin, 1)
+	}
+
+	for win, count := range histogram {
+		result.Histogram = append(result.Histogram, HistogramBucket{
+			WinAmount: money.New(win, currency),
+			Count:     count,
+		})
+	}
+	sort.Slice(result.Histogram, func(i, j int) bool {
+		return result.Histogram[i].WinAmount.LessThan(result.Histogram[j].WinAmount)
+	})
+
+	return result
+}
+
+// workerSeed выводит зерно воркера из базового зерна (splitmix64),
+// чтобы потоки соседних воркеров не коррелировали
+func workerSeed(base int64, worker int) uint64 {
+	z := uint64(base) + uint64(worker+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
@@ -1,20 +1,30 @@
 package auth
 
 import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/session"
 	"gambling/internal/domain/user"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 // LoginUseCase представляет use case для входа пользователя
+// При успешном входе выпускает access токен и refresh токен новой сессии
 type LoginUseCase struct {
-	userRepo user.Repository
+	userRepo  user.Repository
+	tokenRepo session.Repository
+	signer    session.TokenSigner
+	ttl       TokenTTL
 }
 
 // NewLoginUseCase создает новый use case для входа
-func NewLoginUseCase(userRepo user.Repository) *LoginUseCase {
+func NewLoginUseCase(userRepo user.Repository, tokenRepo session.Repository, signer session.TokenSigner, ttl TokenTTL) *LoginUseCase {
 	return &LoginUseCase{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		signer:    signer,
+		ttl:       ttl,
 	}
 }
 
@@ -29,7 +39,12 @@ type LoginResult struct {
 	ID       uint
 	Username string
 	Email    string
-	Balance  float64
+	Balance  money.Money
+	Role     user.Role
+	Tokens   *Tokens
+	// Exclusion - действующее самоограничение или nil. Пока оно действует,
+	// игрок может только выводить средства: пополнения и спины запрещены
+	Exclusion *user.Exclusion
 }
 
 // Execute выполняет вход пользователя
@@ -45,10 +60,31 @@ func (uc *LoginUseCase) Execute(cmd LoginCommand) (*LoginResult, error) {
 		return nil, user.ErrInvalidCredentials
 	}
 
+	// Самоограничение не мешает входу: игрок должен иметь возможность вывести
+	// средства. Пополнения и спины проверяют самоограничение сами, а интерфейсы
+	// по Exclusion в результате скрывают игровые действия
+
+	// Создаем новую сессию: refresh токен хранится на сервере и может быть отозван
+	refresh, refreshToken, err := session.NewRefreshToken(u.ID, uc.ttl.Refresh)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.tokenRepo.Create(refresh); err != nil {
+		return nil, err
+	}
+
+	tokens, err := signAccessToken(uc.signer, uc.ttl, u, refresh, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
 	return &LoginResult{
-		ID:       u.ID,
-		Username: u.Username,
-		Email:    u.Email,
-		Balance:  u.Balance,
+		ID:        u.ID,
+		Username:  u.Username,
+		Email:     u.Email,
+		Balance:   u.Balance,
+		Role:      u.Role,
+		Tokens:    tokens,
+		Exclusion: u.ActiveExclusion(time.Now()),
 	}, nil
 }
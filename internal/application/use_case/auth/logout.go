@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/uow"
+	"time"
+)
+
+// LogoutUseCase представляет use case для завершения сессии
+// Отзывает все refresh токены сессии; выданные access токены действуют до истечения срока
+type LogoutUseCase struct {
+	unitOfWork uow.UnitOfWork
+}
+
+// NewLogoutUseCase создает новый use case для выхода
+func NewLogoutUseCase(unitOfWork uow.UnitOfWork) *LogoutUseCase {
+	return &LogoutUseCase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+// LogoutCommand представляет команду для выхода
+type LogoutCommand struct {
+	UserID       uint
+	RefreshToken string
+}
+
+// Execute завершает сессию, к которой относится refresh токен
+func (uc *LogoutUseCase) Execute(cmd LogoutCommand) error {
+	return uc.unitOfWork.Do(func(repos uow.Repositories) error {
+		current, err := repos.RefreshTokens().GetByHashForUpdate(session.HashToken(cmd.RefreshToken))
+		if err != nil {
+			return err
+		}
+		// Чужую сессию завершить нельзя
+		if current.UserID != cmd.UserID {
+			return session.ErrInvalidToken
+		}
+		return repos.RefreshTokens().RevokeFamily(current.FamilyID, time.Now())
+	})
+}
+
+// RevokeSessionsUseCase представляет use case для завершения всех сессий пользователя
+type RevokeSessionsUseCase struct {
+	tokenRepo session.Repository
+}
+
+// NewRevokeSessionsUseCase создает новый use case для отзыва всех сессий
+func NewRevokeSessionsUseCase(tokenRepo session.Repository) *RevokeSessionsUseCase {
+	return &RevokeSessionsUseCase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// RevokeSessionsCommand представляет команду для отзыва всех сессий
+type RevokeSessionsCommand struct {
+	UserID uint
+}
+
+// Execute отзывает все refresh токены пользователя
+func (uc *RevokeSessionsUseCase) Execute(cmd RevokeSessionsCommand) error {
+	return uc.tokenRepo.RevokeAllByUserID(cmd.UserID, time.Now())
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/uow"
+	"time"
+)
+
+// RefreshUseCase представляет use case для обмена refresh токена на новую пару токенов
+// Refresh токен одноразовый: при обмене он отзывается и заменяется новым
+type RefreshUseCase struct {
+	unitOfWork uow.UnitOfWork
+	signer     session.TokenSigner
+	ttl        TokenTTL
+}
+
+// NewRefreshUseCase создает новый use case для обновления токенов
+func NewRefreshUseCase(unitOfWork uow.UnitOfWork, signer session.TokenSigner, ttl TokenTTL) *RefreshUseCase {
+	return &RefreshUseCase{
+		unitOfWork: unitOfWork,
+		signer:     signer,
+		ttl:        ttl,
+	}
+}
+
+// RefreshCommand представляет команду для обновления токенов
+type RefreshCommand struct {
+	RefreshToken string
+}
+
+// Execute обменивает refresh токен на новую пару токенов
+func (uc *RefreshUseCase) Execute(cmd RefreshCommand) (*Tokens, error) {
+	var (
+		tokens *Tokens
+		reused bool
+	)
+
+	err := uc.unitOfWork.Do(func(repos uow.Repositories) error {
+		current, err := repos.RefreshTokens().GetByHashForUpdate(session.HashToken(cmd.RefreshToken))
+		if err != nil {
+			return err
+		}
+
+		// Отозванный токен предъявлен повторно: вероятно, его украли.
+		// Завершаем всю сессию, чтобы украденная цепочка токенов перестала работать
+		if current.IsRevoked() {
+			reused = true
+			return repos.RefreshTokens().RevokeFamily(current.FamilyID, time.Now())
+		}
+		if current.IsExpired(time.Now()) {
+			return session.ErrTokenExpired
+		}
+
+		// Роль читается заново: изменения прав вступают в силу при обновлении токена
+		u, err := repos.Users().GetByID(current.UserID)
+		if err != nil {
+			return err
+		}
+
+		next, refreshToken, err := current.Rotate(uc.ttl.Refresh)
+		if err != nil {
+			return err
+		}
+		if err := repos.RefreshTokens().Update(current); err != nil {
+			return err
+		}
+		if err := repos.RefreshTokens().Create(next); err != nil {
+			return err
+		}
+
+		tokens, err = signAccessToken(uc.signer, uc.ttl, u, next, refreshToken)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Отзыв семейства должен быть зафиксирован, поэтому ошибка возвращается после транзакции
+	if reused {
+		return nil, session.ErrTokenReused
+	}
+
+	return tokens, nil
+}
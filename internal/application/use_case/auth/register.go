@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/money"
 	"gambling/internal/domain/user"
 
 	"golang.org/x/crypto/bcrypt"
@@ -9,13 +11,15 @@ import (
 // RegisterUseCase представляет use case для регистрации пользователя
 // Use Case - это конкретная бизнес-операция, которую может выполнить пользователь
 type RegisterUseCase struct {
-	userRepo user.Repository
+	userRepo      user.Repository
+	exclusionRepo exclusion.Repository
 }
 
 // NewRegisterUseCase создает новый use case для регистрации
-func NewRegisterUseCase(userRepo user.Repository) *RegisterUseCase {
+func NewRegisterUseCase(userRepo user.Repository, exclusionRepo exclusion.Repository) *RegisterUseCase {
 	return &RegisterUseCase{
-		userRepo: userRepo,
+		userRepo:      userRepo,
+		exclusionRepo: exclusionRepo,
 	}
 }
 
@@ -33,7 +37,7 @@ type RegisterResult struct {
 	ID       uint
 	Username string
 	Email    string
-	Balance  float64
+	Balance  money.Money
 }
 
 // Execute выполняет регистрацию нового пользователя
@@ -48,6 +52,15 @@ func (uc *RegisterUseCase) Execute(cmd RegisterCommand) (*RegisterResult, error)
 		return nil, user.ErrUserAlreadyExists
 	}
 
+	// Игрок с бессрочным самоисключением не может завести новый аккаунт
+	blocked, err := uc.exclusionRepo.IsEmailBlocked(cmd.Email)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, exclusion.ErrEmailBlocked
+	}
+
 	// Хешируем пароль
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(cmd.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -69,4 +82,3 @@ func (uc *RegisterUseCase) Execute(cmd RegisterCommand) (*RegisterResult, error)
 		Balance:  newUser.Balance,
 	}, nil
 }
-
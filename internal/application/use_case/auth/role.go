@@ -0,0 +1,37 @@
+package auth
+
+import "gambling/internal/domain/user"
+
+// SetRoleUseCase представляет use case для назначения роли пользователю
+// Новая роль попадает в access токен при следующем входе или обновлении токена
+type SetRoleUseCase struct {
+	userRepo user.Repository
+}
+
+// NewSetRoleUseCase создает новый use case для назначения роли
+func NewSetRoleUseCase(userRepo user.Repository) *SetRoleUseCase {
+	return &SetRoleUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// SetRoleCommand представляет команду для назначения роли
+type SetRoleCommand struct {
+	Username string
+	Role     string
+}
+
+// Execute назначает роль пользователю
+func (uc *SetRoleUseCase) Execute(cmd SetRoleCommand) error {
+	role, err := user.ParseRole(cmd.Role)
+	if err != nil {
+		return err
+	}
+
+	u, err := uc.userRepo.GetByUsername(cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	return uc.userRepo.UpdateRole(u.ID, role)
+}
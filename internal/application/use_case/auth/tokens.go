@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// TokenTTL задает сроки действия выпускаемых токенов
+type TokenTTL struct {
+	Access  time.Duration
+	Refresh time.Duration
+}
+
+// Tokens представляет пару токенов, выданную клиенту
+type Tokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// signAccessToken выпускает access токен и собирает пару с уже созданным refresh токеном
+func signAccessToken(signer session.TokenSigner, ttl TokenTTL, u *user.User, refresh *session.RefreshToken, refreshToken string) (*Tokens, error) {
+	now := time.Now()
+	claims := session.Claims{
+		UserID:    u.ID,
+		Role:      u.Role,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl.Access),
+	}
+	accessToken, err := signer.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tokens{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  claims.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
@@ -1,72 +1,101 @@
 package balance
 
 import (
+	"gambling/internal/application/use_case/limits"
+	"gambling/internal/domain/money"
 	"gambling/internal/domain/transaction"
-	"gambling/internal/domain/user"
+	"gambling/internal/domain/uow"
+	"time"
 )
 
 // DepositUseCase представляет use case для пополнения баланса
 type DepositUseCase struct {
-	userRepo        user.Repository
-	transactionRepo transaction.Repository
+	uow uow.UnitOfWork
 }
 
 // NewDepositUseCase создает новый use case для пополнения баланса
-func NewDepositUseCase(userRepo user.Repository, transactionRepo transaction.Repository) *DepositUseCase {
+func NewDepositUseCase(unitOfWork uow.UnitOfWork) *DepositUseCase {
 	return &DepositUseCase{
-		userRepo:        userRepo,
-		transactionRepo: transactionRepo,
+		uow: unitOfWork,
 	}
 }
 
 // DepositCommand представляет команду для пополнения баланса
 type DepositCommand struct {
 	UserID uint
-	Amount float64
+	Amount money.Money
+	// IdempotencyKey - ключ идемпотентности запроса (пусто - без защиты от повтора)
+	IdempotencyKey string
 }
 
 // DepositResult представляет результат пополнения баланса
 type DepositResult struct {
-	Balance float64
+	Balance money.Money
 }
 
 // Execute выполняет пополнение баланса пользователя
+// Изменение баланса и запись транзакции выполняются атомарно
 func (uc *DepositUseCase) Execute(cmd DepositCommand) (*DepositResult, error) {
-	// Получаем пользователя
-	u, err := uc.userRepo.GetByID(cmd.UserID)
-	if err != nil {
-		return nil, err
-	}
+	var result *DepositResult
 
-	// Выполняем доменную операцию пополнения
-	balanceBefore := u.Balance
-	if err := u.Deposit(cmd.Amount); err != nil {
-		return nil, err
-	}
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		// Ключ отмечается в той же транзакции, что и пополнение:
+		// повтор с тем же ключом не зачислит средства второй раз
+		if cmd.IdempotencyKey != "" {
+			if err := repos.Idempotency().MarkApplied(cmd.UserID, cmd.IdempotencyKey); err != nil {
+				return err
+			}
+		}
 
-	// Сохраняем обновленный баланс
-	if err := uc.userRepo.UpdateBalance(cmd.UserID, u.Balance); err != nil {
-		return nil, err
-	}
+		// Получаем пользователя и блокируем его строку до конца транзакции,
+		// чтобы параллельные запросы не перезаписали баланс друг друга
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
 
-	// Создаем транзакцию
-	tx := transaction.NewTransaction(
-		cmd.UserID,
-		transaction.TypeDeposit,
-		cmd.Amount,
-		balanceBefore,
-		u.Balance,
-		"Пополнение баланса",
-	)
+		// Проверяем самоограничение и лимиты ответственной игры под блокировкой строки пользователя
+		now := time.Now()
+		if err := u.EnsureCanPlay(now); err != nil {
+			return err
+		}
+		if err := limits.CheckDeposit(repos, u, cmd.Amount, now); err != nil {
+			return err
+		}
 
-	if err := uc.transactionRepo.Create(tx); err != nil {
-		// Откатываем баланс в случае ошибки
-		_ = uc.userRepo.UpdateBalance(cmd.UserID, balanceBefore)
+		// Выполняем доменную операцию пополнения
+		balanceBefore := u.Balance
+		if err := u.Deposit(cmd.Amount); err != nil {
+			return err
+		}
+
+		// Сохраняем обновленный баланс
+		if err := repos.Users().UpdateBalance(cmd.UserID, u.Balance); err != nil {
+			return err
+		}
+
+		// Создаем транзакцию
+		tx := transaction.NewTransaction(
+			cmd.UserID,
+			transaction.TypeDeposit,
+			cmd.Amount,
+			balanceBefore,
+			u.Balance,
+			"Пополнение баланса",
+		)
+
+		if err := repos.Transactions().Create(tx); err != nil {
+			return err
+		}
+
+		result = &DepositResult{
+			Balance: u.Balance,
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &DepositResult{
-		Balance: u.Balance,
-	}, nil
+	return result, nil
 }
-
@@ -0,0 +1,277 @@
+package balance
+
+import (
+	"fmt"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"gambling/internal/domain/withdrawal"
+	"time"
+)
+
+// WithdrawUseCase представляет use case для создания заявки на вывод средств
+// Средства резервируются сразу: списываются с баланса, пока оператор рассматривает заявку
+type WithdrawUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewWithdrawUseCase создает новый use case для вывода средств
+func NewWithdrawUseCase(unitOfWork uow.UnitOfWork) *WithdrawUseCase {
+	return &WithdrawUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// WithdrawCommand представляет команду для вывода средств
+type WithdrawCommand struct {
+	UserID      uint
+	Amount      money.Money
+	Destination string
+}
+
+// WithdrawalResult представляет заявку на вывод
+type WithdrawalResult struct {
+	ID          uint
+	UserID      uint
+	Amount      money.Money
+	Destination string
+	Status      withdrawal.Status
+	Reason      string
+	CreatedAt   time.Time
+	ReviewedAt  *time.Time
+}
+
+// WithdrawResult представляет результат создания заявки
+type WithdrawResult struct {
+	Withdrawal *WithdrawalResult
+	Balance    money.Money
+}
+
+// Execute создает заявку и резервирует средства
+// Списание, заявка и запись транзакции выполняются атомарно
+func (uc *WithdrawUseCase) Execute(cmd WithdrawCommand) (*WithdrawResult, error) {
+	var result *WithdrawResult
+
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		w, err := withdrawal.NewWithdrawal(cmd.UserID, cmd.Amount, cmd.Destination)
+		if err != nil {
+			return err
+		}
+
+		// Резервируем средства: доменная операция проверит достаточность баланса
+		balanceBefore := u.Balance
+		if err := u.Withdraw(cmd.Amount); err != nil {
+			return err
+		}
+		if err := repos.Users().UpdateBalance(cmd.UserID, u.Balance); err != nil {
+			return err
+		}
+
+		if err := repos.Withdrawals().Create(w); err != nil {
+			return err
+		}
+
+		tx := transaction.NewTransaction(
+			cmd.UserID,
+			transaction.TypeWithdrawal,
+			cmd.Amount,
+			balanceBefore,
+			u.Balance,
+			fmt.Sprintf("Заявка на вывод #%d", w.ID),
+		)
+		if err := repos.Transactions().Create(tx); err != nil {
+			return err
+		}
+
+		result = &WithdrawResult{
+			Withdrawal: toWithdrawalResult(w),
+			Balance:    u.Balance,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReviewWithdrawalCommand представляет решение оператора по заявке
+type ReviewWithdrawalCommand struct {
+	WithdrawalID uint
+	OperatorID   uint
+	Reason       string // Только для отказа
+}
+
+// ApproveWithdrawalUseCase представляет use case для одобрения заявки на вывод
+type ApproveWithdrawalUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewApproveWithdrawalUseCase создает новый use case для одобрения заявки
+func NewApproveWithdrawalUseCase(unitOfWork uow.UnitOfWork) *ApproveWithdrawalUseCase {
+	return &ApproveWithdrawalUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// Execute одобряет заявку: зарезервированные средства считаются выплаченными
+// Баланс игрока не меняется, но выплата фиксируется отдельной транзакцией
+func (uc *ApproveWithdrawalUseCase) Execute(cmd ReviewWithdrawalCommand) (*WithdrawalResult, error) {
+	return review(uc.uow, cmd, func(repos uow.Repositories, w *withdrawal.Withdrawal, u *user.User) error {
+		if err := w.Approve(cmd.OperatorID); err != nil {
+			return err
+		}
+
+		tx := transaction.NewTransaction(
+			w.UserID,
+			transaction.TypeWithdrawalPayout,
+			w.Amount,
+			u.Balance,
+			u.Balance,
+			fmt.Sprintf("Выплата по заявке #%d", w.ID),
+		)
+		return repos.Transactions().Create(tx)
+	})
+}
+
+// RejectWithdrawalUseCase представляет use case для отклонения заявки на вывод
+type RejectWithdrawalUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewRejectWithdrawalUseCase создает новый use case для отклонения заявки
+func NewRejectWithdrawalUseCase(unitOfWork uow.UnitOfWork) *RejectWithdrawalUseCase {
+	return &RejectWithdrawalUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// Execute отклоняет заявку и возвращает зарезервированные средства на баланс
+func (uc *RejectWithdrawalUseCase) Execute(cmd ReviewWithdrawalCommand) (*WithdrawalResult, error) {
+	return review(uc.uow, cmd, func(repos uow.Repositories, w *withdrawal.Withdrawal, u *user.User) error {
+		if err := w.Reject(cmd.OperatorID, cmd.Reason); err != nil {
+			return err
+		}
+
+		balanceBefore := u.Balance
+		if err := u.Deposit(w.Amount); err != nil {
+			return err
+		}
+		if err := repos.Users().UpdateBalance(u.ID, u.Balance); err != nil {
+			return err
+		}
+
+		tx := transaction.NewTransaction(
+			w.UserID,
+			transaction.TypeWithdrawalRefund,
+			w.Amount,
+			balanceBefore,
+			u.Balance,
+			fmt.Sprintf("Возврат по отклоненной заявке #%d", w.ID),
+		)
+		return repos.Transactions().Create(tx)
+	})
+}
+
+// review выполняет решение по заявке в одной единице работы
+// Заявка и пользователь блокируются, чтобы решение не приняли дважды
+// и возврат не разошелся с параллельными операциями по балансу
+func review(
+	unitOfWork uow.UnitOfWork,
+	cmd ReviewWithdrawalCommand,
+	decide func(repos uow.Repositories, w *withdrawal.Withdrawal, u *user.User) error,
+) (*WithdrawalResult, error) {
+	var result *WithdrawalResult
+
+	err := unitOfWork.Do(func(repos uow.Repositories) error {
+		w, err := repos.Withdrawals().GetByIDForUpdate(cmd.WithdrawalID)
+		if err != nil {
+			return err
+		}
+		if !w.IsPending() {
+			return withdrawal.ErrNotPending
+		}
+
+		u, err := repos.Users().GetByIDForUpdate(w.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := decide(repos, w, u); err != nil {
+			return err
+		}
+		if err := repos.Withdrawals().Update(w); err != nil {
+			return err
+		}
+
+		result = toWithdrawalResult(w)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListWithdrawalsUseCase представляет use case для просмотра заявок на вывод
+type ListWithdrawalsUseCase struct {
+	withdrawalRepo withdrawal.Repository
+}
+
+// NewListWithdrawalsUseCase создает новый use case для просмотра заявок
+func NewListWithdrawalsUseCase(withdrawalRepo withdrawal.Repository) *ListWithdrawalsUseCase {
+	return &ListWithdrawalsUseCase{
+		withdrawalRepo: withdrawalRepo,
+	}
+}
+
+// ListWithdrawalsCommand представляет команду для просмотра заявок
+// Если задан UserID, возвращаются заявки игрока, иначе - заявки в состоянии Status
+type ListWithdrawalsCommand struct {
+	UserID uint
+	Status withdrawal.Status
+	Limit  int
+}
+
+// Execute возвращает заявки на вывод
+func (uc *ListWithdrawalsUseCase) Execute(cmd ListWithdrawalsCommand) ([]*WithdrawalResult, error) {
+	var (
+		withdrawals []*withdrawal.Withdrawal
+		err         error
+	)
+	if cmd.UserID != 0 {
+		withdrawals, err = uc.withdrawalRepo.GetByUserID(cmd.UserID, cmd.Limit)
+	} else {
+		withdrawals, err = uc.withdrawalRepo.GetByStatus(cmd.Status, cmd.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*WithdrawalResult, len(withdrawals))
+	for i, w := range withdrawals {
+		result[i] = toWithdrawalResult(w)
+	}
+	return result, nil
+}
+
+func toWithdrawalResult(w *withdrawal.Withdrawal) *WithdrawalResult {
+	return &WithdrawalResult{
+		ID:          w.ID,
+		UserID:      w.UserID,
+		Amount:      w.Amount,
+		Destination: w.Destination,
+		Status:      w.Status,
+		Reason:      w.Reason,
+		CreatedAt:   w.CreatedAt,
+		ReviewedAt:  w.ReviewedAt,
+	}
+}
@@ -0,0 +1,182 @@
+package exclusions
+
+import (
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// eventsListLimit - сколько записей журнала возвращать
+const eventsListLimit = 100
+
+// ExcludeUseCase представляет use case для установки самоограничения
+// Игрок устанавливает ограничение себе сам, оператор - по обращению игрока
+type ExcludeUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewExcludeUseCase создает новый use case для установки самоограничения
+func NewExcludeUseCase(unitOfWork uow.UnitOfWork) *ExcludeUseCase {
+	return &ExcludeUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// ExcludeCommand представляет команду для установки самоограничения
+// Kind - cool_off (Period: 24h, 7d, 30d) или self_exclusion (Period: 6m, 1y, 5y, permanent)
+type ExcludeCommand struct {
+	UserID  uint
+	ActorID uint
+	Kind    string
+	Period  string
+	Reason  string
+}
+
+// ExclusionResult представляет самоограничение игрока
+// Until равен nil у бессрочного самоисключения
+type ExclusionResult struct {
+	UserID    uint
+	Kind      user.ExclusionKind
+	StartedAt time.Time
+	Until     *time.Time
+}
+
+// Execute устанавливает самоограничение
+// Все сессии игрока завершаются, бессрочное самоисключение дополнительно
+// блокирует email для новых регистраций. Действие записывается в журнал аудита
+func (uc *ExcludeUseCase) Execute(cmd ExcludeCommand) (*ExclusionResult, error) {
+	now := time.Now()
+	e, err := user.NewExclusion(cmd.Kind, cmd.Period, now)
+	if err != nil {
+		return nil, err
+	}
+	event, err := exclusion.NewSetEvent(cmd.UserID, cmd.ActorID, e, cmd.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+		if err := u.Exclude(e, now); err != nil {
+			return err
+		}
+		if err := repos.Users().UpdateExclusion(u.ID, u.Exclusion); err != nil {
+			return err
+		}
+		if err := repos.Exclusions().CreateEvent(event); err != nil {
+			return err
+		}
+		if e.IsPermanent() {
+			if err := repos.Exclusions().BlockEmail(u.Email, u.ID); err != nil {
+				return err
+			}
+		}
+		// Сессии завершаются, чтобы игрок вошел заново и увидел ограничение. Войти и
+		// вывести средства он может, а пополнения и спины проверяют самоограничение сами
+		return repos.RefreshTokens().RevokeAllByUserID(u.ID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toExclusionResult(cmd.UserID, e), nil
+}
+
+// LiftExclusionUseCase представляет use case для досрочного снятия самоограничения
+// Доступно только операторам: игрок не может снять ограничение сам
+type LiftExclusionUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewLiftExclusionUseCase создает новый use case для снятия самоограничения
+func NewLiftExclusionUseCase(unitOfWork uow.UnitOfWork) *LiftExclusionUseCase {
+	return &LiftExclusionUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// LiftExclusionCommand представляет команду для снятия самоограничения
+type LiftExclusionCommand struct {
+	UserID     uint
+	OperatorID uint
+	Reason     string
+}
+
+// Execute снимает действующее самоограничение и разблокирует email игрока
+func (uc *LiftExclusionUseCase) Execute(cmd LiftExclusionCommand) error {
+	now := time.Now()
+	return uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+		lifted := u.ActiveExclusion(now)
+		if err := u.LiftExclusion(now); err != nil {
+			return err
+		}
+
+		event, err := exclusion.NewLiftEvent(u.ID, cmd.OperatorID, lifted, cmd.Reason)
+		if err != nil {
+			return err
+		}
+		if err := repos.Users().UpdateExclusion(u.ID, nil); err != nil {
+			return err
+		}
+		if err := repos.Exclusions().CreateEvent(event); err != nil {
+			return err
+		}
+		return repos.Exclusions().UnblockEmail(u.ID)
+	})
+}
+
+// GetExclusionUseCase представляет use case для просмотра самоограничения и журнала аудита
+type GetExclusionUseCase struct {
+	userRepo      user.Repository
+	exclusionRepo exclusion.Repository
+}
+
+// NewGetExclusionUseCase создает новый use case для просмотра самоограничения
+func NewGetExclusionUseCase(userRepo user.Repository, exclusionRepo exclusion.Repository) *GetExclusionUseCase {
+	return &GetExclusionUseCase{
+		userRepo:      userRepo,
+		exclusionRepo: exclusionRepo,
+	}
+}
+
+// ExclusionStatus представляет действующее самоограничение (nil, если его нет)
+// вместе с журналом аудита, новые записи первыми
+type ExclusionStatus struct {
+	Active *ExclusionResult
+	Events []*exclusion.Event
+}
+
+// Execute возвращает самоограничение игрока и журнал аудита
+func (uc *GetExclusionUseCase) Execute(userID uint) (*ExclusionStatus, error) {
+	u, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := uc.exclusionRepo.GetEventsByUserID(userID, eventsListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ExclusionStatus{Events: events}
+	if e := u.ActiveExclusion(time.Now()); e != nil {
+		status.Active = toExclusionResult(u.ID, e)
+	}
+	return status, nil
+}
+
+func toExclusionResult(userID uint, e *user.Exclusion) *ExclusionResult {
+	return &ExclusionResult{
+		UserID:    userID,
+		Kind:      e.Kind,
+		StartedAt: e.StartedAt,
+		Until:     e.Until,
+	}
+}
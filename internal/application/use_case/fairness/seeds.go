@@ -0,0 +1,194 @@
+package fairness
+
+import (
+	"errors"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/uow"
+	"time"
+)
+
+// SeedsResult описывает активную пару сидов так, как ее видит игрок
+// Серверный сид не раскрывается, публикуется только его хеш
+type SeedsResult struct {
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          uint64
+}
+
+// RevealedSeedResult описывает раскрытую пару сидов
+type RevealedSeedResult struct {
+	ServerSeed     string
+	ServerSeedHash string
+	ClientSeed     string
+	Rounds         uint64 // Сколько раундов сыграно на паре (nonce от 0 до Rounds-1)
+	RevealedAt     time.Time
+}
+
+// GetSeedsUseCase представляет use case для получения активной пары сидов
+type GetSeedsUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewGetSeedsUseCase создает новый use case для получения сидов
+func NewGetSeedsUseCase(unitOfWork uow.UnitOfWork) *GetSeedsUseCase {
+	return &GetSeedsUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// GetSeedsCommand представляет команду для получения сидов
+type GetSeedsCommand struct {
+	UserID uint
+}
+
+// Execute возвращает активную пару сидов, создавая ее при первом обращении
+// Так игрок получает хеш серверного сида еще до первого спина
+func (uc *GetSeedsUseCase) Execute(cmd GetSeedsCommand) (*SeedsResult, error) {
+	var result *SeedsResult
+
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		if _, err := repos.Users().GetByIDForUpdate(cmd.UserID); err != nil {
+			return err
+		}
+
+		pair, err := repos.Seeds().GetActiveByUserID(cmd.UserID)
+		if errors.Is(err, fairness.ErrSeedNotFound) {
+			pair, err = fairness.NewSeedPair(cmd.UserID, "")
+			if err != nil {
+				return err
+			}
+			err = repos.Seeds().Create(pair)
+		}
+		if err != nil {
+			return err
+		}
+
+		result = toSeedsResult(pair)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RotateSeedsUseCase представляет use case для ротации сидов
+type RotateSeedsUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewRotateSeedsUseCase создает новый use case для ротации сидов
+func NewRotateSeedsUseCase(unitOfWork uow.UnitOfWork) *RotateSeedsUseCase {
+	return &RotateSeedsUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// RotateSeedsCommand представляет команду для ротации сидов
+// Если ClientSeed пустой, для новой пары генерируется случайный клиентский сид
+type RotateSeedsCommand struct {
+	UserID     uint
+	ClientSeed string
+}
+
+// RotateSeedsResult представляет результат ротации
+type RotateSeedsResult struct {
+	Previous *RevealedSeedResult // nil, если активной пары еще не было
+	Current  *SeedsResult
+}
+
+// Execute раскрывает текущий серверный сид и выдает новую пару
+// Смена клиентского сида всегда сопровождается ротацией: иначе сервер,
+// зная новый клиентский сид, мог бы подобрать его под уже опубликованный хеш
+func (uc *RotateSeedsUseCase) Execute(cmd RotateSeedsCommand) (*RotateSeedsResult, error) {
+	result := &RotateSeedsResult{}
+
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		// Блокируем пользователя, чтобы ротация не пересеклась с его спином
+		if _, err := repos.Users().GetByIDForUpdate(cmd.UserID); err != nil {
+			return err
+		}
+
+		current, err := repos.Seeds().GetActiveByUserIDForUpdate(cmd.UserID)
+		switch {
+		case err == nil:
+			current.Reveal()
+			if err := repos.Seeds().Update(current); err != nil {
+				return err
+			}
+			result.Previous = toRevealedSeedResult(current)
+		case !errors.Is(err, fairness.ErrSeedNotFound):
+			return err
+		}
+
+		next, err := fairness.NewSeedPair(cmd.UserID, cmd.ClientSeed)
+		if err != nil {
+			return err
+		}
+		if err := repos.Seeds().Create(next); err != nil {
+			return err
+		}
+
+		result.Current = toSeedsResult(next)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListRevealedSeedsUseCase представляет use case для получения раскрытых сидов
+type ListRevealedSeedsUseCase struct {
+	seedRepo fairness.Repository
+}
+
+// NewListRevealedSeedsUseCase создает новый use case для списка раскрытых сидов
+func NewListRevealedSeedsUseCase(seedRepo fairness.Repository) *ListRevealedSeedsUseCase {
+	return &ListRevealedSeedsUseCase{
+		seedRepo: seedRepo,
+	}
+}
+
+// ListRevealedSeedsCommand представляет команду для получения раскрытых сидов
+type ListRevealedSeedsCommand struct {
+	UserID uint
+	Limit  int
+}
+
+// Execute возвращает раскрытые пары сидов пользователя, начиная с последних
+func (uc *ListRevealedSeedsUseCase) Execute(cmd ListRevealedSeedsCommand) ([]*RevealedSeedResult, error) {
+	pairs, err := uc.seedRepo.ListRevealedByUserID(cmd.UserID, cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*RevealedSeedResult, len(pairs))
+	for i, pair := range pairs {
+		result[i] = toRevealedSeedResult(pair)
+	}
+	return result, nil
+}
+
+func toSeedsResult(pair *fairness.SeedPair) *SeedsResult {
+	return &SeedsResult{
+		ServerSeedHash: pair.ServerSeedHash,
+		ClientSeed:     pair.ClientSeed,
+		Nonce:          pair.Nonce,
+	}
+}
+
+func toRevealedSeedResult(pair *fairness.SeedPair) *RevealedSeedResult {
+	result := &RevealedSeedResult{
+		ServerSeed:     pair.ServerSeed,
+		ServerSeedHash: pair.ServerSeedHash,
+		ClientSeed:     pair.ClientSeed,
+		Rounds:         pair.Nonce,
+	}
+	if pair.RevealedAt != nil {
+		result.RevealedAt = *pair.RevealedAt
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package fairness
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+)
+
+// VerifySpinUseCase представляет use case для проверки доказуемо честного спина
+// Пересчитывает исход и выигрыш из раскрытого серверного сида и сравнивает с записью в истории
+type VerifySpinUseCase struct {
+	spinRepo spin.Repository
+	seedRepo fairness.Repository
+	games    *game.Registry
+}
+
+// NewVerifySpinUseCase создает новый use case для проверки спина
+func NewVerifySpinUseCase(spinRepo spin.Repository, seedRepo fairness.Repository, games *game.Registry) *VerifySpinUseCase {
+	return &VerifySpinUseCase{
+		spinRepo: spinRepo,
+		seedRepo: seedRepo,
+		games:    games,
+	}
+}
+
+// VerifySpinCommand представляет команду для проверки спина
+type VerifySpinCommand struct {
+	UserID uint
+	SpinID uint
+}
+
+// VerifySpinResult представляет результат проверки
+type VerifySpinResult struct {
+	SpinID          uint
+	GameID          string
+	ServerSeed      string
+	ServerSeedHash  string
+	ClientSeed      string
+	Nonce           uint64
+	RecordedOutcome json.RawMessage
+	ComputedOutcome json.RawMessage
+	RecordedWin     money.Money
+	ComputedWin     money.Money
+	// WinMultiplier - коэффициент бонуса, если проверяется бесплатный спин (иначе пуст)
+	WinMultiplier spin.Multiplier
+	Valid         bool
+}
+
+// Execute проверяет спин пользователя
+func (uc *VerifySpinUseCase) Execute(cmd VerifySpinCommand) (*VerifySpinResult, error) {
+	result, err := uc.spinRepo.GetByID(cmd.SpinID)
+	if err != nil {
+		return nil, err
+	}
+	// Чужие спины не раскрываем: для игрока их просто не существует
+	if result.UserID != cmd.UserID {
+		return nil, spin.ErrResultNotFound
+	}
+	if result.SeedPairID == 0 {
+		return nil, fairness.ErrNotProvablyFair
+	}
+	// Раунд пересчитывается по той версии таблицы, по которой он сыгран
+	g, err := uc.games.GetVersion(result.GameID, result.PaytableVersion)
+	if errors.Is(err, game.ErrVersionNotFound) {
+		return nil, fairness.ErrPaytableMismatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := uc.seedRepo.GetByID(result.SeedPairID)
+	if err != nil {
+		return nil, err
+	}
+	if !pair.IsRevealed() {
+		return nil, fairness.ErrSeedNotRevealed
+	}
+
+	computed, err := g.Play(fairness.NewStream(pair.ServerSeed, pair.ClientSeed, result.Nonce), result.BetAmount)
+	if err != nil {
+		return nil, err
+	}
+	// Выплата из пула джекпота зависит от накопленной суммы, а не от сидов,
+	// поэтому сверяется только выигрыш по таблице выплат
+	recordedWin, err := result.WinAmount.Sub(result.JackpotAmount)
+	if err != nil {
+		return nil, err
+	}
+	// Выигрыш бесплатного спина - выплата раунда, умноженная на коэффициент бонуса
+	computedWin := computed.Payout
+	if result.IsFreeSpin() {
+		if computedWin, err = spin.FreeSpinWin(computed.Payout, result.WinMultiplier); err != nil {
+			return nil, err
+		}
+	}
+
+	return &VerifySpinResult{
+		SpinID:          result.ID,
+		GameID:          result.GameID,
+		ServerSeed:      pair.ServerSeed,
+		ServerSeedHash:  pair.ServerSeedHash,
+		ClientSeed:      pair.ClientSeed,
+		Nonce:           result.Nonce,
+		RecordedOutcome: result.Outcome,
+		ComputedOutcome: computed.Data,
+		RecordedWin:     recordedWin,
+		ComputedWin:     computedWin,
+		WinMultiplier:   result.WinMultiplier,
+		Valid: game.SameData(computed.Data, result.Outcome) &&
+			computedWin == recordedWin &&
+			fairness.HashServerSeed(pair.ServerSeed) == pair.ServerSeedHash,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package history
+
+import (
+	"errors"
+	"time"
+)
+
+// dateLayout - формат даты без времени
+const dateLayout = "2006-01-02"
+
+// ErrInvalidDate возвращается, если граница периода не разобрана
+var ErrInvalidDate = errors.New("неверный формат даты")
+
+// ParseDate разбирает границу периода в формате RFC 3339 или YYYY-MM-DD
+// Дата без времени для конца периода (end) означает конец этого дня, поэтому
+// возвращается начало следующего: верхняя граница не включается в период
+func ParseDate(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
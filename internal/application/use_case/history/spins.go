@@ -0,0 +1,241 @@
+package history
+
+import (
+	"encoding/json"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/pagination"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// ListSpinsUseCase представляет use case для просмотра истории спинов
+type ListSpinsUseCase struct {
+	spinRepo spin.Repository
+	userRepo user.Repository
+}
+
+// NewListSpinsUseCase создает новый use case для просмотра истории спинов
+func NewListSpinsUseCase(spinRepo spin.Repository, userRepo user.Repository) *ListSpinsUseCase {
+	return &ListSpinsUseCase{
+		spinRepo: spinRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListSpinsCommand представляет команду для просмотра истории спинов
+type ListSpinsCommand struct {
+	UserID uint
+	// Outcome - исход: win, loss; пусто - все спины
+	Outcome string
+	// MinBet и MaxBet - границы ставки включительно в валюте игрока; пусто - без границы
+	MinBet string
+	MaxBet string
+	// From и To - период: From включительно, To не включительно; нулевое значение - без границы
+	From time.Time
+	To   time.Time
+	// Cursor - курсор из NextCursor предыдущей страницы; пусто - первая страница
+	Cursor string
+	// Limit - размер страницы; 0 - pagination.DefaultLimit
+	Limit int
+}
+
+// SpinSummary представляет спин в истории
+type SpinSummary struct {
+	ID      uint
+	RoundID string
+	GameID  string
+	// Outcome - исход раунда в формате игры
+	Outcome json.RawMessage
+	// Reels - символы на барабанах, только у раундов классического автомата
+	Reels     *[spin.ReelCount]int
+	BetAmount money.Money
+	WinAmount money.Money
+	IsWin     bool
+	// JackpotAmount - часть выигрыша, выплаченная из пула джекпота
+	JackpotAmount money.Money
+	// TriggerSpinID - спин, запустивший бонус, у бесплатного спина (0 у платного раунда)
+	TriggerSpinID uint
+	CreatedAt     time.Time
+}
+
+// SpinsPage представляет страницу истории спинов
+// NextCursor пуст на последней странице
+type SpinsPage struct {
+	Spins      []SpinSummary
+	NextCursor string
+}
+
+// Execute возвращает страницу истории спинов
+func (uc *ListSpinsUseCase) Execute(cmd ListSpinsCommand) (*SpinsPage, error) {
+	outcome, err := spin.ParseOutcome(cmd.Outcome)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := pagination.Limit(cmd.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if !cmd.From.IsZero() && !cmd.To.IsZero() && !cmd.From.Before(cmd.To) {
+		return nil, transaction.ErrInvalidPeriod
+	}
+
+	query := spin.Query{
+		UserID:  cmd.UserID,
+		Outcome: outcome,
+		From:    cmd.From,
+		To:      cmd.To,
+		Limit:   limit,
+	}
+	if cmd.Cursor != "" {
+		if query.After, err = spin.DecodeCursor(cmd.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Границы ставки разбираются в валюте игрока
+	if cmd.MinBet != "" || cmd.MaxBet != "" {
+		u, err := uc.userRepo.GetByID(cmd.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if query.MinBet, err = parseBet(cmd.MinBet, u.Balance.Currency()); err != nil {
+			return nil, err
+		}
+		if query.MaxBet, err = parseBet(cmd.MaxBet, u.Balance.Currency()); err != nil {
+			return nil, err
+		}
+		if !query.MinBet.IsZero() && !query.MaxBet.IsZero() && query.MaxBet.LessThan(query.MinBet) {
+			return nil, spin.ErrInvalidBetRange
+		}
+	}
+
+	page, err := uc.spinRepo.Find(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SpinsPage{Spins: make([]SpinSummary, len(page.Results))}
+	for i, r := range page.Results {
+		result.Spins[i] = toSpinSummary(r)
+	}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	return result, nil
+}
+
+// parseBet разбирает границу ставки; пустая строка - без границы
+func parseBet(s string, currency money.Currency) (money.Money, error) {
+	if s == "" {
+		return money.Money{}, nil
+	}
+	bet, err := money.Parse(s, currency)
+	if err != nil || !bet.IsPositive() {
+		return money.Money{}, user.ErrInvalidAmount
+	}
+	return bet, nil
+}
+
+// GetSpinUseCase представляет use case для просмотра одного раунда
+type GetSpinUseCase struct {
+	spinRepo        spin.Repository
+	transactionRepo transaction.Repository
+}
+
+// NewGetSpinUseCase создает новый use case для просмотра раунда
+func NewGetSpinUseCase(spinRepo spin.Repository, transactionRepo transaction.Repository) *GetSpinUseCase {
+	return &GetSpinUseCase{
+		spinRepo:        spinRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// GetSpinCommand представляет команду для просмотра раунда
+type GetSpinCommand struct {
+	UserID uint
+	SpinID uint
+}
+
+// SpinDetails представляет раунд вместе с его транзакциями
+// Поля транзакций и балансов пусты у раундов, сыгранных до появления идентификатора раунда,
+// и у бесплатных спинов без выигрыша: у них нет транзакций
+type SpinDetails struct {
+	SpinSummary
+	PaytableVersion  string
+	BetTransactionID uint
+	WinTransactionID uint
+	// JackpotTransactionID - транзакция выплаты джекпота (0, если джекпот не выпал)
+	JackpotTransactionID uint
+	BalanceBefore        *money.Money
+	BalanceAfter         *money.Money
+	// SeedPairID и Nonce указывают сиды доказуемо честного раунда (0 вне этого режима)
+	SeedPairID uint
+	Nonce      uint64
+}
+
+// Execute возвращает раунд игрока
+// Чужой спин не отличается от несуществующего: оба дают spin.ErrResultNotFound
+func (uc *GetSpinUseCase) Execute(cmd GetSpinCommand) (*SpinDetails, error) {
+	r, err := uc.spinRepo.GetByID(cmd.SpinID)
+	if err != nil {
+		return nil, err
+	}
+	if r.UserID != cmd.UserID {
+		return nil, spin.ErrResultNotFound
+	}
+
+	details := &SpinDetails{
+		SpinSummary:     toSpinSummary(r),
+		PaytableVersion: r.PaytableVersion,
+		SeedPairID:      r.SeedPairID,
+		Nonce:           r.Nonce,
+	}
+	if r.RoundID == "" {
+		return details, nil
+	}
+
+	txs, err := uc.transactionRepo.GetByRoundID(r.RoundID)
+	if err != nil {
+		return nil, err
+	}
+	// Баланс до раунда - до первой транзакции раунда (у платного раунда это списание ставки),
+	// после - после последней транзакции раунда
+	for _, tx := range txs {
+		if details.BalanceBefore == nil {
+			before := tx.BalanceBefore
+			details.BalanceBefore = &before
+		}
+		switch tx.Type {
+		case transaction.TypeSpin:
+			details.BetTransactionID = tx.ID
+		case transaction.TypeWin, transaction.TypeFreeSpinWin:
+			details.WinTransactionID = tx.ID
+		case transaction.TypeJackpotWin:
+			details.JackpotTransactionID = tx.ID
+		}
+		after := tx.BalanceAfter
+		details.BalanceAfter = &after
+	}
+	return details, nil
+}
+
+func toSpinSummary(r *spin.Result) SpinSummary {
+	summary := SpinSummary{
+		ID:            r.ID,
+		RoundID:       r.RoundID,
+		GameID:        r.GameID,
+		Outcome:       r.Outcome,
+		BetAmount:     r.BetAmount,
+		WinAmount:     r.WinAmount,
+		IsWin:         r.IsWin,
+		JackpotAmount: r.JackpotAmount,
+		TriggerSpinID: r.TriggerSpinID,
+		CreatedAt:     r.CreatedAt,
+	}
+	if reels, ok := r.Reels(); ok {
+		summary.Reels = &reels
+	}
+	return summary
+}
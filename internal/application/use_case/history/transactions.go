@@ -0,0 +1,139 @@
+package history
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/pagination"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// ListTransactionsUseCase представляет use case для просмотра истории транзакций
+type ListTransactionsUseCase struct {
+	transactionRepo transaction.Repository
+	userRepo        user.Repository
+}
+
+// NewListTransactionsUseCase создает новый use case для просмотра истории транзакций
+func NewListTransactionsUseCase(transactionRepo transaction.Repository, userRepo user.Repository) *ListTransactionsUseCase {
+	return &ListTransactionsUseCase{
+		transactionRepo: transactionRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// ListTransactionsCommand представляет команду для просмотра истории транзакций
+// Игрок задается UserID или, для службы поддержки, именем Username
+type ListTransactionsCommand struct {
+	UserID   uint
+	Username string
+	// Types - типы транзакций; пусто - все типы
+	Types []string
+	// From и To - период: From включительно, To не включительно; нулевое значение - без границы
+	From time.Time
+	To   time.Time
+	// Sort - порядок: newest (по умолчанию), oldest, amount_desc, amount_asc
+	Sort string
+	// Cursor - курсор из NextCursor предыдущей страницы; пусто - первая страница
+	Cursor string
+	// Limit - размер страницы; 0 - pagination.DefaultLimit
+	Limit int
+}
+
+// TransactionResult представляет транзакцию в истории
+type TransactionResult struct {
+	ID            uint
+	RoundID       string
+	Type          transaction.Type
+	Amount        money.Money
+	BalanceBefore money.Money
+	BalanceAfter  money.Money
+	Description   string
+	CreatedAt     time.Time
+}
+
+// TransactionsPage представляет страницу истории транзакций
+// NextCursor пуст на последней странице
+type TransactionsPage struct {
+	UserID       uint
+	Transactions []TransactionResult
+	NextCursor   string
+}
+
+// Execute возвращает страницу истории транзакций
+func (uc *ListTransactionsUseCase) Execute(cmd ListTransactionsCommand) (*TransactionsPage, error) {
+	query, err := buildQuery(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Username != "" {
+		u, err := uc.userRepo.GetByUsername(cmd.Username)
+		if err != nil {
+			return nil, err
+		}
+		query.UserID = u.ID
+	}
+
+	page, err := uc.transactionRepo.Find(query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransactionsPage{
+		UserID:       query.UserID,
+		Transactions: make([]TransactionResult, len(page.Transactions)),
+	}
+	for i, tx := range page.Transactions {
+		result.Transactions[i] = TransactionResult{
+			ID:            tx.ID,
+			RoundID:       tx.RoundID,
+			Type:          tx.Type,
+			Amount:        tx.Amount,
+			BalanceBefore: tx.BalanceBefore,
+			BalanceAfter:  tx.BalanceAfter,
+			Description:   tx.Description,
+			CreatedAt:     tx.CreatedAt,
+		}
+	}
+	if page.Next != nil {
+		result.NextCursor = page.Next.Encode()
+	}
+	return result, nil
+}
+
+// buildQuery проверяет фильтры клиента и собирает из них объект запроса
+func buildQuery(cmd ListTransactionsCommand) (transaction.Query, error) {
+	sort, err := transaction.ParseSort(cmd.Sort)
+	if err != nil {
+		return transaction.Query{}, err
+	}
+	limit, err := pagination.Limit(cmd.Limit)
+	if err != nil {
+		return transaction.Query{}, err
+	}
+	if !cmd.From.IsZero() && !cmd.To.IsZero() && !cmd.From.Before(cmd.To) {
+		return transaction.Query{}, transaction.ErrInvalidPeriod
+	}
+
+	query := transaction.Query{
+		UserID: cmd.UserID,
+		From:   cmd.From,
+		To:     cmd.To,
+		Sort:   sort,
+		Limit:  limit,
+	}
+	for _, s := range cmd.Types {
+		t, err := transaction.ParseType(s)
+		if err != nil {
+			return transaction.Query{}, err
+		}
+		query.Types = append(query.Types, t)
+	}
+	if cmd.Cursor != "" {
+		if query.After, err = pagination.Decode(cmd.Cursor, string(sort)); err != nil {
+			return transaction.Query{}, err
+		}
+	}
+	return query, nil
+}
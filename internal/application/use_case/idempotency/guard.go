@@ -0,0 +1,137 @@
+package idempotency
+
+import (
+	"errors"
+	"gambling/internal/domain/idempotency"
+	"time"
+)
+
+// lockTimeout - через сколько незавершенный запрос считается зависшим
+// и его ключ может перехватить повтор. Операция при этом не выполнится дважды:
+// use case отмечает ключ в своей единице работы (MarkApplied)
+const lockTimeout = time.Minute
+
+// Guard представляет use case защиты операций от повторного выполнения
+// Первый запрос с ключом резервирует его и выполняется, ответ сохраняется;
+// повтор с тем же ключом и телом получает сохраненный ответ
+type Guard struct {
+	repo idempotency.Repository
+	ttl  time.Duration
+}
+
+// NewGuard создает новый use case защиты от повторов
+// ttl - сколько хранится ответ и действует ключ
+func NewGuard(repo idempotency.Repository, ttl time.Duration) *Guard {
+	return &Guard{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+// BeginCommand представляет команду начала запроса с ключом
+type BeginCommand struct {
+	UserID      uint
+	Key         string
+	Fingerprint string
+}
+
+// StoredResponse представляет сохраненный ответ на первый запрос
+type StoredResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Begin резервирует ключ для выполнения запроса
+// Возвращает сохраненный ответ, если запрос с этим ключом уже выполнен, или nil,
+// если запрос нужно выполнить. ErrKeyReused - ключ использован с другим запросом,
+// ErrInProgress - запрос с ключом сейчас выполняется
+func (g *Guard) Begin(cmd BeginCommand) (*StoredResponse, error) {
+	record, err := idempotency.NewRecord(cmd.UserID, cmd.Key, cmd.Fingerprint, g.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.repo.Create(record)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, idempotency.ErrKeyExists) {
+		return nil, err
+	}
+
+	existing, err := g.repo.Get(cmd.UserID, cmd.Key)
+	if errors.Is(err, idempotency.ErrRecordNotFound) {
+		// Запись удалили между вставкой и чтением (отказ первого запроса или
+		// очистка): ключ снова свободен
+		return nil, g.retryCreate(record)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if existing.IsExpired(now) {
+		if err := g.repo.Delete(cmd.UserID, cmd.Key); err != nil {
+			return nil, err
+		}
+		return nil, g.retryCreate(record)
+	}
+	if !existing.Matches(cmd.Fingerprint) {
+		return nil, idempotency.ErrKeyReused
+	}
+	if existing.IsCompleted() {
+		return &StoredResponse{
+			StatusCode:  existing.StatusCode,
+			ContentType: existing.ContentType,
+			Body:        existing.Body,
+		}, nil
+	}
+	if !existing.IsStale(now, lockTimeout) {
+		return nil, idempotency.ErrInProgress
+	}
+
+	taken, err := g.repo.TakeOver(existing, now)
+	if err != nil {
+		return nil, err
+	}
+	if !taken {
+		return nil, idempotency.ErrInProgress
+	}
+	return nil, nil
+}
+
+// retryCreate повторяет резервирование освободившегося ключа
+// Если ключ успел занять параллельный запрос, текущий получает ErrInProgress
+func (g *Guard) retryCreate(record *idempotency.Record) error {
+	err := g.repo.Create(record)
+	if errors.Is(err, idempotency.ErrKeyExists) {
+		return idempotency.ErrInProgress
+	}
+	return err
+}
+
+// CompleteCommand представляет ответ на первый запрос с ключом
+type CompleteCommand struct {
+	UserID   uint
+	Key      string
+	Response StoredResponse
+}
+
+// Complete сохраняет ответ, который получат повторы с тем же ключом
+func (g *Guard) Complete(cmd CompleteCommand) error {
+	record := &idempotency.Record{UserID: cmd.UserID, Key: cmd.Key}
+	record.Complete(cmd.Response.StatusCode, cmd.Response.ContentType, cmd.Response.Body)
+	return g.repo.Complete(record)
+}
+
+// Release освобождает ключ, если запрос завершился ошибкой сервера и операция
+// не зафиксирована: клиент может повторить ее с тем же ключом
+func (g *Guard) Release(userID uint, key string) error {
+	return g.repo.Release(userID, key)
+}
+
+// DeleteExpired удаляет ключи с истекшим сроком хранения
+func (g *Guard) DeleteExpired() (int64, error) {
+	return g.repo.DeleteExpired(time.Now())
+}
@@ -0,0 +1,56 @@
+package jackpots
+
+import (
+	"errors"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/money"
+)
+
+// winnersListLimit - сколько последних выигрышей показывается вместе с пулом
+const winnersListLimit = 10
+
+// GetJackpotUseCase представляет use case для просмотра пула джекпота
+type GetJackpotUseCase struct {
+	jackpotRepo jackpot.Repository
+	seed        money.Money
+	enabled     bool
+}
+
+// NewGetJackpotUseCase создает новый use case для просмотра пула джекпота
+// enabled - разыгрывается ли джекпот по текущей таблице выплат
+func NewGetJackpotUseCase(jackpotRepo jackpot.Repository, seed money.Money, enabled bool) *GetJackpotUseCase {
+	return &GetJackpotUseCase{
+		jackpotRepo: jackpotRepo,
+		seed:        seed,
+		enabled:     enabled,
+	}
+}
+
+// JackpotStatus представляет текущую сумму пула и последние выигрыши, новые первыми
+type JackpotStatus struct {
+	Amount  money.Money
+	Winners []*jackpot.Winner
+}
+
+// Execute возвращает текущую сумму пула джекпота
+// Пул создается первым спином, до этого он равен затравке
+func (uc *GetJackpotUseCase) Execute() (*JackpotStatus, error) {
+	if !uc.enabled {
+		return nil, jackpot.ErrDisabled
+	}
+
+	currency := uc.seed.Currency()
+	status := &JackpotStatus{Amount: uc.seed}
+	pool, err := uc.jackpotRepo.Get(currency)
+	switch {
+	case err == nil:
+		status.Amount = pool.Amount
+	case !errors.Is(err, jackpot.ErrPoolNotFound):
+		return nil, err
+	}
+
+	if status.Winners, err = uc.jackpotRepo.ListWinners(currency, winnersListLimit); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
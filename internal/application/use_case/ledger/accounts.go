@@ -0,0 +1,20 @@
+package ledger
+
+import "gambling/internal/domain/ledger"
+
+// SystemAccountsUseCase представляет use case для просмотра системных счетов казино
+type SystemAccountsUseCase struct {
+	ledgerRepo ledger.Repository
+}
+
+// NewSystemAccountsUseCase создает новый use case для просмотра системных счетов
+func NewSystemAccountsUseCase(ledgerRepo ledger.Repository) *SystemAccountsUseCase {
+	return &SystemAccountsUseCase{
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+// Execute возвращает остатки системных счетов: банкролл, пулы и внешний счет
+func (uc *SystemAccountsUseCase) Execute() ([]ledger.AccountBalance, error) {
+	return uc.ledgerRepo.SystemBalances()
+}
@@ -0,0 +1,87 @@
+package ledger
+
+import (
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/uow"
+)
+
+// RebuildBalancesUseCase представляет use case для пересчета балансов из главной книги
+// Баланс пользователя - это кэш остатка его кошелька, и при расхождении
+// верным считается остаток по проводкам
+type RebuildBalancesUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewRebuildBalancesUseCase создает новый use case для пересчета балансов
+func NewRebuildBalancesUseCase(unitOfWork uow.UnitOfWork) *RebuildBalancesUseCase {
+	return &RebuildBalancesUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// RebuildBalancesCommand представляет команду для пересчета балансов
+type RebuildBalancesCommand struct {
+	DryRun bool // Только найти расхождения, не исправляя их
+}
+
+// BalanceDrift представляет расхождение кэша баланса с главной книгой
+type BalanceDrift struct {
+	UserID uint
+	Cached money.Money
+	Ledger money.Money
+}
+
+// RebuildBalancesResult представляет результат пересчета
+type RebuildBalancesResult struct {
+	Checked int
+	Drifts  []BalanceDrift
+}
+
+// Execute сверяет баланс каждого пользователя с остатком его кошелька и исправляет расхождения
+// Пользователь блокируется перед подсчетом остатка, чтобы параллельный спин
+// не изменил кошелек между подсчетом и записью
+func (uc *RebuildBalancesUseCase) Execute(cmd RebuildBalancesCommand) (*RebuildBalancesResult, error) {
+	result := &RebuildBalancesResult{}
+
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		wallets, err := repos.Ledger().WalletBalances()
+		if err != nil {
+			return err
+		}
+
+		for _, wallet := range wallets {
+			u, err := repos.Users().GetByIDForUpdate(wallet.Account.UserID)
+			if err != nil {
+				return err
+			}
+
+			balance, err := repos.Ledger().Balance(ledger.Wallet(u.ID), u.Balance.Currency())
+			if err != nil {
+				return err
+			}
+			result.Checked++
+
+			if balance == u.Balance {
+				continue
+			}
+			result.Drifts = append(result.Drifts, BalanceDrift{
+				UserID: u.ID,
+				Cached: u.Balance,
+				Ledger: balance,
+			})
+			if cmd.DryRun {
+				continue
+			}
+			if err := repos.Users().UpdateBalance(u.ID, balance); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
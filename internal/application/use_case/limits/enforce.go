@@ -0,0 +1,126 @@
+package limits
+
+import (
+	"errors"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// CheckDeposit проверяет лимиты пополнений и лимит сессии перед пополнением
+// Вызывается внутри единицы работы после блокировки строки пользователя:
+// блокировка не дает параллельным пополнениям вместе обойти лимит
+func CheckDeposit(repos uow.Repositories, u *user.User, amount money.Money, now time.Time) error {
+	active, err := activeLimits(repos, u.ID, now)
+	if err != nil || len(active) == 0 {
+		return err
+	}
+
+	if err := limit.CheckDeposit(active, usageFunc(repos, u), amount, now); err != nil {
+		return err
+	}
+
+	// Пополнение не продлевает сессию, но при исчерпанной сессии не выполняется
+	session, err := currentSession(repos, u.ID, now)
+	if err != nil {
+		return err
+	}
+	return limit.CheckSession(active, session, now)
+}
+
+// CheckBet проверяет лимиты ставок, проигрыша и сессии перед спином и отмечает
+// ставку в игровой сессии. Вызывается внутри единицы работы после блокировки
+// строки пользователя
+func CheckBet(repos uow.Repositories, u *user.User, bet money.Money, now time.Time) error {
+	active, err := activeLimits(repos, u.ID, now)
+	if err != nil {
+		return err
+	}
+
+	session, err := currentSession(repos, u.ID, now)
+	if err != nil {
+		return err
+	}
+	if err := limit.CheckSession(active, session, now); err != nil {
+		return err
+	}
+	if err := limit.CheckBet(active, usageFunc(repos, u), bet, now); err != nil {
+		return err
+	}
+
+	session.Touch(now)
+	return repos.Limits().SaveSession(session)
+}
+
+// CheckFreeSpin проверяет лимит сессии перед бесплатным спином и продлевает сессию
+// Бесплатный спин не списывает ставку, поэтому лимиты ставок и проигрыша к нему не применяются
+func CheckFreeSpin(repos uow.Repositories, u *user.User, now time.Time) error {
+	active, err := activeLimits(repos, u.ID, now)
+	if err != nil {
+		return err
+	}
+
+	session, err := currentSession(repos, u.ID, now)
+	if err != nil {
+		return err
+	}
+	if err := limit.CheckSession(active, session, now); err != nil {
+		return err
+	}
+
+	session.Touch(now)
+	return repos.Limits().SaveSession(session)
+}
+
+// activeLimits возвращает действующие лимиты пользователя, применяя ослабления,
+// у которых истек период охлаждения
+func activeLimits(repos uow.Repositories, userID uint, now time.Time) ([]*limit.Limit, error) {
+	all, err := repos.Limits().GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*limit.Limit, 0, len(all))
+	for _, l := range all {
+		if l.Apply(now) {
+			if l.IsRemoved() {
+				if err := repos.Limits().Delete(l.ID); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := repos.Limits().Save(l); err != nil {
+				return nil, err
+			}
+		}
+		active = append(active, l)
+	}
+	return active, nil
+}
+
+// currentSession возвращает игровую сессию к моменту now
+// После перерыва в игре сессия начинается заново
+func currentSession(repos uow.Repositories, userID uint, now time.Time) (*limit.PlaySession, error) {
+	session, err := repos.Limits().GetSession(userID)
+	if errors.Is(err, limit.ErrSessionMissing) {
+		return limit.NewPlaySession(userID, now), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	session.Resume(now)
+	return session, nil
+}
+
+// usageFunc возвращает суммы игрока за окно по его транзакциям
+func usageFunc(repos uow.Repositories, u *user.User) limit.UsageFunc {
+	return func(since time.Time) (limit.Usage, error) {
+		totals, err := repos.Transactions().TotalsSince(u.ID, since)
+		if err != nil {
+			return limit.Usage{}, err
+		}
+		return limit.UsageFromTotals(totals, u.Balance.Currency()), nil
+	}
+}
@@ -0,0 +1,208 @@
+package limits
+
+import (
+	"errors"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/uow"
+	"time"
+)
+
+// SetLimitUseCase представляет use case для установки, изменения и снятия лимита
+type SetLimitUseCase struct {
+	uow     uow.UnitOfWork
+	cooling time.Duration
+}
+
+// NewSetLimitUseCase создает новый use case для управления лимитами
+// cooling - период охлаждения, после которого вступает в силу ослабление лимита
+func NewSetLimitUseCase(unitOfWork uow.UnitOfWork, cooling time.Duration) *SetLimitUseCase {
+	return &SetLimitUseCase{
+		uow:     unitOfWork,
+		cooling: cooling,
+	}
+}
+
+// SetLimitCommand представляет команду для установки лимита
+// Для денежных лимитов задается Amount, для лимита сессии - Duration;
+// нулевое значение снимает лимит
+type SetLimitCommand struct {
+	UserID   uint
+	Kind     string
+	Period   string
+	Amount   money.Money
+	Duration time.Duration
+}
+
+// LimitResult представляет лимит игрока
+// Для денежных лимитов заполнены Amount и Used, для лимита сессии - Duration
+// и Elapsed. Pending* описывают ожидающее ослабление
+type LimitResult struct {
+	Kind     limit.Kind
+	Period   limit.Period
+	Amount   money.Money
+	Used     money.Money
+	Duration time.Duration
+	Elapsed  time.Duration
+	// PendingAmount и PendingDuration - новое значение; нулевое означает снятие лимита
+	Pending            bool
+	PendingAmount      money.Money
+	PendingDuration    time.Duration
+	PendingEffectiveAt time.Time
+}
+
+// Execute устанавливает лимит
+// Ужесточение и новый лимит действуют сразу, ослабление - после периода охлаждения.
+// Возвращает nil, если снят лимит, который еще не был установлен
+func (uc *SetLimitUseCase) Execute(cmd SetLimitCommand) (*LimitResult, error) {
+	kind, err := limit.ParseKind(cmd.Kind)
+	if err != nil {
+		return nil, err
+	}
+	period, err := limit.ParsePeriod(kind, cmd.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *LimitResult
+	err = uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+		if kind != limit.KindSession && !cmd.Amount.IsZero() && !cmd.Amount.SameCurrency(u.Balance) {
+			return money.ErrCurrencyMismatch
+		}
+
+		now := time.Now()
+		active, err := activeLimits(repos, u.ID, now)
+		if err != nil {
+			return err
+		}
+
+		var value int64
+		if kind == limit.KindSession {
+			value = int64(cmd.Duration / time.Second)
+		} else {
+			value = cmd.Amount.Amount()
+		}
+
+		l := findLimit(active, kind, period)
+		if l == nil {
+			if value == 0 {
+				return nil
+			}
+			if kind == limit.KindSession {
+				l, err = limit.NewSessionLimit(u.ID, cmd.Duration)
+			} else {
+				l, err = limit.NewMoneyLimit(u.ID, kind, period, cmd.Amount)
+			}
+			if err != nil {
+				return err
+			}
+		} else if err := l.Set(value, now, uc.cooling); err != nil {
+			return err
+		}
+		if kind == limit.KindSession && value != 0 && value < int64(time.Minute/time.Second) {
+			return limit.ErrInvalidValue
+		}
+		if err := repos.Limits().Save(l); err != nil {
+			return err
+		}
+
+		result, err = toLimitResult(repos, u.ID, l, now, u.Balance.Currency())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListLimitsUseCase представляет use case для просмотра лимитов игрока
+type ListLimitsUseCase struct {
+	uow uow.UnitOfWork
+}
+
+// NewListLimitsUseCase создает новый use case для просмотра лимитов
+func NewListLimitsUseCase(unitOfWork uow.UnitOfWork) *ListLimitsUseCase {
+	return &ListLimitsUseCase{
+		uow: unitOfWork,
+	}
+}
+
+// Execute возвращает лимиты игрока вместе с использованной частью
+// Ослабления с истекшим периодом охлаждения применяются при чтении
+func (uc *ListLimitsUseCase) Execute(userID uint) ([]*LimitResult, error) {
+	var results []*LimitResult
+	err := uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(userID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		active, err := activeLimits(repos, u.ID, now)
+		if err != nil {
+			return err
+		}
+		results = make([]*LimitResult, 0, len(active))
+		for _, l := range active {
+			result, err := toLimitResult(repos, u.ID, l, now, u.Balance.Currency())
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func findLimit(limits []*limit.Limit, kind limit.Kind, period limit.Period) *limit.Limit {
+	for _, l := range limits {
+		if l.Kind == kind && l.Period == period {
+			return l
+		}
+	}
+	return nil
+}
+
+// toLimitResult собирает результат с использованной частью лимита к моменту now
+func toLimitResult(repos uow.Repositories, userID uint, l *limit.Limit, now time.Time, currency money.Currency) (*LimitResult, error) {
+	result := &LimitResult{Kind: l.Kind, Period: l.Period}
+	if l.Pending != nil {
+		result.Pending = true
+		result.PendingEffectiveAt = l.Pending.EffectiveAt
+	}
+
+	if l.Kind == limit.KindSession {
+		result.Duration = l.SessionDuration()
+		if l.Pending != nil {
+			result.PendingDuration = time.Duration(l.Pending.Value) * time.Second
+		}
+		session, err := repos.Limits().GetSession(userID)
+		if err != nil && !errors.Is(err, limit.ErrSessionMissing) {
+			return nil, err
+		}
+		// Сессия после перерыва уже закончилась: следующая ставка начнет новую
+		if session != nil && now.Sub(session.LastActivityAt) < limit.SessionBreak {
+			result.Elapsed = session.Elapsed(now)
+		}
+		return result, nil
+	}
+
+	result.Amount = l.Amount()
+	if l.Pending != nil {
+		result.PendingAmount = money.New(l.Pending.Value, l.Currency)
+	}
+	totals, err := repos.Transactions().TotalsSince(userID, now.Add(-l.Period.Duration()))
+	if err != nil {
+		return nil, err
+	}
+	result.Used = limit.UsageFromTotals(totals, currency).Used(l.Kind)
+	return result, nil
+}
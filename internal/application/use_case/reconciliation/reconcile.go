@@ -0,0 +1,161 @@
+package reconciliation
+
+import (
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/reconciliation"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// usersPageSize - сколько ID пользователей читается за один запрос
+const usersPageSize = 500
+
+// ReconcileUseCase представляет use case для сверки балансов
+type ReconcileUseCase struct {
+	uow        uow.UnitOfWork
+	userRepo   user.Repository
+	reportRepo reconciliation.Repository
+}
+
+// NewReconcileUseCase создает новый use case для сверки балансов
+func NewReconcileUseCase(unitOfWork uow.UnitOfWork, userRepo user.Repository, reportRepo reconciliation.Repository) *ReconcileUseCase {
+	return &ReconcileUseCase{
+		uow:        unitOfWork,
+		userRepo:   userRepo,
+		reportRepo: reportRepo,
+	}
+}
+
+// ReconcileCommand представляет команду для сверки
+type ReconcileCommand struct {
+	UserID uint // 0 - сверить всех пользователей
+}
+
+// DiscrepancyResult представляет найденное расхождение
+type DiscrepancyResult struct {
+	UserID        uint
+	Kind          reconciliation.Kind
+	TransactionID uint
+	SpinID        uint
+	Expected      money.Money
+	Actual        money.Money
+}
+
+// ReportResult представляет отчет о сверке
+type ReportResult struct {
+	RunID         uint
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	UsersChecked  int
+	Discrepancies []DiscrepancyResult
+}
+
+// Execute сверяет пользователей и сохраняет отчет
+// Каждый пользователь проверяется в своей единице работы под блокировкой,
+// поэтому идущие параллельно спины не создают ложных расхождений,
+// а сверка не держит блокировки всех пользователей сразу
+func (uc *ReconcileUseCase) Execute(cmd ReconcileCommand) (*ReportResult, error) {
+	run := reconciliation.NewRun()
+
+	if cmd.UserID != 0 {
+		if err := uc.checkUser(run, cmd.UserID); err != nil {
+			return nil, err
+		}
+	} else {
+		var afterID uint
+		for {
+			ids, err := uc.userRepo.ListIDs(afterID, usersPageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				if err := uc.checkUser(run, id); err != nil {
+					return nil, err
+				}
+			}
+			if len(ids) < usersPageSize {
+				break
+			}
+			afterID = ids[len(ids)-1]
+		}
+	}
+
+	run.Finish()
+	if err := uc.reportRepo.Save(run); err != nil {
+		return nil, err
+	}
+	return toReportResult(run), nil
+}
+
+func (uc *ReconcileUseCase) checkUser(run *reconciliation.Run, userID uint) error {
+	return uc.uow.Do(func(repos uow.Repositories) error {
+		u, err := repos.Users().GetByIDForUpdate(userID)
+		if err != nil {
+			return err
+		}
+		txs, err := repos.Transactions().GetChainByUserID(userID)
+		if err != nil {
+			return err
+		}
+		spins, err := repos.Spins().GetByUserID(userID, 0)
+		if err != nil {
+			return err
+		}
+		ledgerBalance, err := repos.Ledger().Balance(ledger.Wallet(userID), u.Balance.Currency())
+		if err != nil {
+			return err
+		}
+
+		run.Record(reconciliation.Check(reconciliation.Account{
+			User:          u,
+			Transactions:  txs,
+			Spins:         spins,
+			LedgerBalance: ledgerBalance,
+		}))
+		return nil
+	})
+}
+
+// LatestReportUseCase представляет use case для получения последнего отчета сверки
+type LatestReportUseCase struct {
+	reportRepo reconciliation.Repository
+}
+
+// NewLatestReportUseCase создает новый use case для получения последнего отчета
+func NewLatestReportUseCase(reportRepo reconciliation.Repository) *LatestReportUseCase {
+	return &LatestReportUseCase{
+		reportRepo: reportRepo,
+	}
+}
+
+// Execute возвращает последний отчет сверки
+func (uc *LatestReportUseCase) Execute() (*ReportResult, error) {
+	run, err := uc.reportRepo.GetLatest()
+	if err != nil {
+		return nil, err
+	}
+	return toReportResult(run), nil
+}
+
+func toReportResult(run *reconciliation.Run) *ReportResult {
+	result := &ReportResult{
+		RunID:         run.ID,
+		StartedAt:     run.StartedAt,
+		FinishedAt:    run.FinishedAt,
+		UsersChecked:  run.UsersChecked,
+		Discrepancies: make([]DiscrepancyResult, len(run.Discrepancies)),
+	}
+	for i, d := range run.Discrepancies {
+		result.Discrepancies[i] = DiscrepancyResult{
+			UserID:        d.UserID,
+			Kind:          d.Kind,
+			TransactionID: d.TransactionID,
+			SpinID:        d.SpinID,
+			Expected:      d.Expected,
+			Actual:        d.Actual,
+		}
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+package spin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/user"
+)
+
+// maxAutoplaySpins ограничивает число платных спинов одной автоигры
+const maxAutoplaySpins = 1000
+
+// ErrInvalidAutoplay возвращается при неверных параметрах автоигры
+var ErrInvalidAutoplay = errors.New("неверные параметры автоигры")
+
+// StopReason - причина остановки автоигры
+type StopReason string
+
+const (
+	// StopCompleted - сыграны все заказанные спины
+	StopCompleted StopReason = "completed"
+	// StopBalanceBelow - баланс опустился ниже заданного
+	StopBalanceBelow StopReason = "balance_below"
+	// StopWinAbove - выигрыш одного спина превысил заданный
+	StopWinAbove StopReason = "win_above"
+	// StopLossAbove - общий проигрыш превысил заданный
+	StopLossAbove StopReason = "loss_above"
+	// StopJackpot - выигран джекпот
+	StopJackpot StopReason = "jackpot"
+	// StopInsufficientFunds - на балансе не хватает на следующую ставку
+	StopInsufficientFunds StopReason = "insufficient_funds"
+	// StopLimit - сработал лимит ответственной игры или самоограничение
+	StopLimit StopReason = "limit"
+	// StopCancelled - автоигра отменена игроком
+	StopCancelled StopReason = "cancelled"
+	// StopError - спин завершился ошибкой
+	StopError StopReason = "error"
+)
+
+// AutoplayUseCase представляет use case для автоигры: серии спинов с одной ставкой
+// Каждый спин выполняется через SpinUseCase в своей транзакции, поэтому остановка
+// в любой момент оставляет баланс и историю согласованными
+type AutoplayUseCase struct {
+	spinUseCase *SpinUseCase
+}
+
+// NewAutoplayUseCase создает новый use case для автоигры
+func NewAutoplayUseCase(spinUseCase *SpinUseCase) *AutoplayUseCase {
+	return &AutoplayUseCase{
+		spinUseCase: spinUseCase,
+	}
+}
+
+// AutoplayCommand представляет команду для автоигры
+// Условия остановки с нулевой суммой не проверяются
+type AutoplayCommand struct {
+	UserID    uint
+	GameID    string
+	BetAmount money.Money
+	LineBet   money.Money
+	// Spins - число платных спинов; бесплатные спины бонуса в него не входят
+	Spins int
+	// StopBalanceBelow - остановиться, когда баланс станет меньше этой суммы
+	StopBalanceBelow money.Money
+	// StopWinAbove - остановиться, когда выигрыш одного спина превысит эту сумму
+	StopWinAbove money.Money
+	// StopLossAbove - остановиться, когда общий проигрыш (ставки минус выигрыши) превысит эту сумму
+	StopLossAbove money.Money
+	// StopOnJackpot - остановиться после выигрыша джекпота
+	StopOnJackpot bool
+}
+
+// AutoplayResult представляет итоги автоигры и список сыгранных спинов
+type AutoplayResult struct {
+	Spins     []*SpinResult
+	PaidSpins int
+	FreeSpins int
+	// TotalBet - сумма списанных ставок; ставки бесплатных спинов не списываются
+	TotalBet money.Money
+	TotalWin money.Money
+	// Net - выигрыши минус ставки (отрицательное значение - проигрыш)
+	Net           money.Money
+	BiggestWin    money.Money
+	JackpotAmount money.Money
+	// Balance - баланс после последнего спина
+	Balance    money.Money
+	StopReason StopReason
+	// Err - ошибка, остановившая автоигру (при StopLimit и StopError)
+	Err error
+}
+
+// AutoplayProgress вызывается после каждого спина: spin - сыгранный спин,
+// total - итоги автоигры на этот момент
+type AutoplayProgress func(spin *SpinResult, total *AutoplayResult)
+
+// Execute играет серию спинов, пока не сыграны все или не сработало условие остановки
+// Бонус бесплатных спинов, запущенный в серии или оставшийся с прошлой игры, доигрывается
+// перед следующим платным спином. Отмена ctx останавливает автоигру перед очередным спином
+// Ошибка возвращается, только если не сыгран ни один спин; остановка на ошибке
+// в середине серии отражается в StopReason и Err
+func (uc *AutoplayUseCase) Execute(ctx context.Context, cmd AutoplayCommand, progress AutoplayProgress) (*AutoplayResult, error) {
+	if cmd.Spins < 1 || cmd.Spins > maxAutoplaySpins {
+		return nil, fmt.Errorf("%w: число спинов должно быть от 1 до %d", ErrInvalidAutoplay, maxAutoplaySpins)
+	}
+
+	currency := cmd.BetAmount.Currency()
+	if !cmd.LineBet.IsZero() {
+		currency = cmd.LineBet.Currency()
+	}
+	for _, stop := range []money.Money{cmd.StopBalanceBelow, cmd.StopWinAbove, cmd.StopLossAbove} {
+		if stop.IsNegative() {
+			return nil, fmt.Errorf("%w: условие остановки не может быть отрицательным", ErrInvalidAutoplay)
+		}
+		// Суммы в разных валютах не сравниваются, и такое условие никогда бы не сработало
+		if stop.IsPositive() && stop.Currency() != currency {
+			return nil, fmt.Errorf("%w: условие остановки %s не в валюте ставки", ErrInvalidAutoplay, stop.Format())
+		}
+	}
+
+	result := &AutoplayResult{
+		TotalBet:      money.Zero(currency),
+		TotalWin:      money.Zero(currency),
+		Net:           money.Zero(currency),
+		BiggestWin:    money.Zero(currency),
+		JackpotAmount: money.Zero(currency),
+		StopReason:    StopCompleted,
+	}
+
+	freeSpin := false
+	for result.PaidSpins < cmd.Spins || freeSpin {
+		if ctx.Err() != nil {
+			result.StopReason = StopCancelled
+			break
+		}
+
+		spinCmd := SpinCommand{
+			UserID:   cmd.UserID,
+			GameID:   cmd.GameID,
+			FreeSpin: freeSpin,
+		}
+		if !freeSpin {
+			spinCmd.BetAmount = cmd.BetAmount
+			spinCmd.LineBet = cmd.LineBet
+		}
+
+		spin, err := uc.spinUseCase.Execute(spinCmd)
+		if !freeSpin && errors.Is(err, user.ErrFreeSpinsPending) {
+			// Сначала доигрываем бонус; если он в другой игре, следующий спин вернет ErrNoFreeSpins
+			freeSpin = true
+			continue
+		}
+		if err != nil {
+			if len(result.Spins) == 0 {
+				return nil, err
+			}
+			result.StopReason, result.Err = stopReasonFor(err), err
+			break
+		}
+
+		if err := result.add(spin); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(spin, result)
+		}
+
+		freeSpin = spin.FreeSpinsRemaining > 0
+		if reason, stop := checkStop(cmd, spin, result); stop {
+			result.StopReason = reason
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// add учитывает спин в итогах автоигры
+func (r *AutoplayResult) add(spin *SpinResult) error {
+	var err error
+	r.Spins = append(r.Spins, spin)
+	if spin.FreeSpin {
+		r.FreeSpins++
+	} else {
+		r.PaidSpins++
+		if r.TotalBet, err = r.TotalBet.Add(spin.BetAmount); err != nil {
+			return err
+		}
+	}
+	if r.TotalWin, err = r.TotalWin.Add(spin.WinAmount); err != nil {
+		return err
+	}
+	if r.Net, err = r.TotalWin.Sub(r.TotalBet); err != nil {
+		return err
+	}
+	if r.JackpotAmount, err = r.JackpotAmount.Add(spin.JackpotAmount); err != nil {
+		return err
+	}
+	if r.BiggestWin.LessThan(spin.WinAmount) {
+		r.BiggestWin = spin.WinAmount
+	}
+	r.Balance = spin.Balance
+	return nil
+}
+
+// checkStop проверяет условия остановки после спина
+func checkStop(cmd AutoplayCommand, spin *SpinResult, total *AutoplayResult) (StopReason, bool) {
+	switch {
+	case cmd.StopOnJackpot && spin.JackpotAmount.IsPositive():
+		return StopJackpot, true
+	case cmd.StopWinAbove.IsPositive() && cmd.StopWinAbove.LessThan(spin.WinAmount):
+		return StopWinAbove, true
+	case cmd.StopLossAbove.IsPositive() && cmd.StopLossAbove.LessThan(total.Net.Neg()):
+		return StopLossAbove, true
+	case cmd.StopBalanceBelow.IsPositive() && spin.Balance.LessThan(cmd.StopBalanceBelow):
+		return StopBalanceBelow, true
+	}
+	return "", false
+}
+
+// stopReasonFor возвращает причину остановки для ошибки спина в середине серии
+func stopReasonFor(err error) StopReason {
+	var exceeded *limit.ExceededError
+	var excluded *user.ExcludedError
+	switch {
+	case errors.Is(err, user.ErrInsufficientFunds):
+		return StopInsufficientFunds
+	case errors.As(err, &exceeded), errors.As(err, &excluded):
+		return StopLimit
+	default:
+		return StopError
+	}
+}
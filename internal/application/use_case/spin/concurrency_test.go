@@ -0,0 +1,245 @@
+package spin_test
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/balance"
+	"gambling/internal/application/use_case/spin"
+	"gambling/internal/config"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/reconciliation"
+	"gambling/internal/domain/rng"
+	spinDomain "gambling/internal/domain/spin"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"gambling/internal/infrastructure/database/pgsql"
+	"gambling/internal/infrastructure/repository"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Параметры нагрузки: ставок больше, чем хватает на начальный баланс, поэтому часть
+// спинов упирается в нехватку средств, пока их не догонят параллельные пополнения
+const (
+	concurrentSpins    = 200
+	concurrentDeposits = 50
+	workers            = 16
+)
+
+// TestConcurrentSpinsAndDeposits проверяет, что параллельные спины и пополнения одного
+// игрока не теряют обновления баланса: итоговый баланс равен сумме, посчитанной по
+// результатам операций, сумме транзакций и остатку кошелька в главной книге, а сверка
+// не находит расхождений
+//
+// Тест работает с настоящей базой PostgreSQL и пропускается, если не задан TEST_DB_NAME,
+// см. scripts/test-integration.sh
+func TestConcurrentSpinsAndDeposits(t *testing.T) {
+	storage := testStorage(t)
+	unitOfWork := repository.NewUnitOfWork(storage.DB)
+	games := game.NewRegistry(spinDomain.NewService(spinDomain.DefaultPaytable(), rng.NewCryptoSource()))
+	spinUC := spin.NewSpinUseCase(unitOfWork, games, false, jackpot.Rules{
+		ContributionBPS: 100,
+		Seed:            money.New(100000, money.DefaultCurrency),
+	})
+	depositUC := balance.NewDepositUseCase(unitOfWork)
+
+	name := "stress_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	u := user.NewUser(name, name+"@example.com", "-")
+	if err := repository.NewUserRepository(storage.DB).Create(u); err != nil {
+		t.Fatalf("создание пользователя: %v", err)
+	}
+
+	initial := money.New(5000, money.DefaultCurrency)
+	bet := money.New(100, money.DefaultCurrency)
+	deposit := money.New(250, money.DefaultCurrency)
+	if _, err := depositUC.Execute(balance.DepositCommand{UserID: u.ID, Amount: initial}); err != nil {
+		t.Fatalf("начальное пополнение: %v", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		expected = initial
+		played   int
+		errs     []error
+	)
+	apply := func(delta money.Money, spin bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		sum, err := expected.Add(delta)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		expected = sum
+		if spin {
+			played++
+		}
+	}
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+
+	jobs := make(chan bool, concurrentSpins+concurrentDeposits)
+	for i := 0; i < concurrentSpins+concurrentDeposits; i++ {
+		// Пополнения перемешаны со спинами, чтобы они шли одновременно
+		jobs <- i%((concurrentSpins+concurrentDeposits)/concurrentDeposits) == 0
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for isDeposit := range jobs {
+				if isDeposit {
+					if _, err := depositUC.Execute(balance.DepositCommand{UserID: u.ID, Amount: deposit}); err != nil {
+						fail(fmt.Errorf("пополнение: %w", err))
+						continue
+					}
+					apply(deposit, false)
+					continue
+				}
+
+				result, err := spinUC.Execute(spin.SpinCommand{UserID: u.ID, GameID: spinDomain.ClassicGameID, BetAmount: bet})
+				if errors.Is(err, user.ErrFreeSpinsPending) {
+					// Scatter принес бесплатные спины: пока они не сыграны, платные
+					// спины запрещены. Бесплатный спин не списывает ставку
+					result, err = spinUC.Execute(spin.SpinCommand{UserID: u.ID, GameID: spinDomain.ClassicGameID, FreeSpin: true})
+					if errors.Is(err, user.ErrNoFreeSpins) {
+						// Последний бесплатный спин уже сыграл другой воркер
+						continue
+					}
+					if err != nil {
+						fail(fmt.Errorf("бесплатный спин: %w", err))
+						continue
+					}
+					apply(result.WinAmount, true)
+					continue
+				}
+				if errors.Is(err, user.ErrInsufficientFunds) {
+					continue
+				}
+				if err != nil {
+					fail(fmt.Errorf("спин: %w", err))
+					continue
+				}
+				net, err := result.WinAmount.Sub(bet)
+				if err != nil {
+					fail(err)
+					continue
+				}
+				apply(net, true)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+	if played == 0 {
+		t.Fatal("ни один спин не сыгран")
+	}
+
+	err := unitOfWork.Do(func(repos uow.Repositories) error {
+		current, err := repos.Users().GetByIDForUpdate(u.ID)
+		if err != nil {
+			return err
+		}
+		txs, err := repos.Transactions().GetChainByUserID(u.ID)
+		if err != nil {
+			return err
+		}
+		spins, err := repos.Spins().GetByUserID(u.ID, 0)
+		if err != nil {
+			return err
+		}
+		ledgerBalance, err := repos.Ledger().Balance(ledger.Wallet(u.ID), current.Balance.Currency())
+		if err != nil {
+			return err
+		}
+
+		if current.Balance != expected {
+			t.Errorf("баланс %s, по результатам операций ожидается %s", current.Balance.Format(), expected.Format())
+		}
+		if len(spins) != played {
+			t.Errorf("записано спинов %d, сыграно %d", len(spins), played)
+		}
+
+		total := money.Zero(current.Balance.Currency())
+		for _, tx := range txs {
+			if total, err = total.Add(tx.BalanceDelta()); err != nil {
+				return err
+			}
+		}
+		if current.Balance != total {
+			t.Errorf("баланс %s, сумма транзакций %s", current.Balance.Format(), total.Format())
+		}
+		if current.Balance != ledgerBalance {
+			t.Errorf("баланс %s, остаток кошелька в главной книге %s", current.Balance.Format(), ledgerBalance.Format())
+		}
+
+		// Сверка проверяет еще и цепочку BalanceBefore/BalanceAfter: при потерянном
+		// обновлении две транзакции начались бы с одного и того же баланса
+		for _, d := range reconciliation.Check(reconciliation.Account{
+			User:          current,
+			Transactions:  txs,
+			Spins:         spins,
+			LedgerBalance: ledgerBalance,
+		}) {
+			t.Errorf("расхождение %s: транзакция %d, спин %d, ожидалось %s, фактически %s",
+				d.Kind, d.TransactionID, d.SpinID, d.Expected.Format(), d.Actual.Format())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("чтение итогов: %v", err)
+	}
+}
+
+// testStorage подключается к тестовой базе, заданной переменными TEST_DB_*, и применяет миграции
+func testStorage(t *testing.T) *pgsql.Storage {
+	t.Helper()
+	name := os.Getenv("TEST_DB_NAME")
+	if name == "" {
+		t.Skip("TEST_DB_NAME не задан: интеграционный тест с PostgreSQL пропущен")
+	}
+	port, err := strconv.Atoi(envOr("TEST_DB_PORT", "5432"))
+	if err != nil {
+		t.Fatalf("TEST_DB_PORT: %v", err)
+	}
+
+	storage := pgsql.New(&config.Config{
+		DBHost:     envOr("TEST_DB_HOST", "localhost"),
+		DBPort:     port,
+		DBUser:     envOr("TEST_DB_USER", "postgres"),
+		DBPassword: os.Getenv("TEST_DB_PASSWORD"),
+		DBName:     name,
+		DBSSLMode:  envOr("TEST_DB_SSLMODE", "disable"),
+	})
+	t.Cleanup(func() {
+		if err := storage.Close(); err != nil {
+			t.Errorf("закрытие соединения: %v", err)
+		}
+	})
+	if _, err := storage.MigrateUp(); err != nil {
+		t.Fatalf("миграции: %v", err)
+	}
+	return storage
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
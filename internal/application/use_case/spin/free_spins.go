@@ -0,0 +1,59 @@
+package spin
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// GetFreeSpinsUseCase представляет use case для просмотра бонуса бесплатных спинов игрока
+type GetFreeSpinsUseCase struct {
+	userRepo user.Repository
+}
+
+// NewGetFreeSpinsUseCase создает новый use case для просмотра бонуса
+func NewGetFreeSpinsUseCase(userRepo user.Repository) *GetFreeSpinsUseCase {
+	return &GetFreeSpinsUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// FreeSpinsStatus представляет несыгранный бонус бесплатных спинов
+type FreeSpinsStatus struct {
+	GameID string
+	// Bet - ставка, на которую играются бесплатные спины
+	Bet       money.Money
+	Remaining int
+	// Multiplier - коэффициент, на который умножаются выигрыши бесплатных спинов
+	Multiplier    spin.Multiplier
+	TriggerSpinID uint
+	// Won - сумма выигрышей уже сыгранных бесплатных спинов бонуса
+	Won       money.Money
+	AwardedAt time.Time
+}
+
+// Execute возвращает бонус бесплатных спинов игрока или nil, если бонуса нет
+func (uc *GetFreeSpinsUseCase) Execute(userID uint) (*FreeSpinsStatus, error) {
+	u, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.FreeSpins == nil {
+		return nil, nil
+	}
+
+	multiplier, err := spin.NewMultiplier(u.FreeSpins.MultiplierNum, u.FreeSpins.MultiplierDen)
+	if err != nil {
+		return nil, err
+	}
+	return &FreeSpinsStatus{
+		GameID:        u.FreeSpins.GameID,
+		Bet:           u.FreeSpins.Bet,
+		Remaining:     u.FreeSpins.Remaining,
+		Multiplier:    multiplier,
+		TriggerSpinID: u.FreeSpins.TriggerSpinID,
+		Won:           u.FreeSpins.Won,
+		AwardedAt:     u.FreeSpins.AwardedAt,
+	}, nil
+}
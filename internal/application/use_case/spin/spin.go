@@ -1,145 +1,562 @@
 package spin
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/limits"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
 	"gambling/internal/domain/spin"
 	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/uow"
 	"gambling/internal/domain/user"
+	"time"
 )
 
-// SpinUseCase представляет use case для выполнения спина
+// SpinUseCase представляет use case для выполнения раунда любой игры из реестра
+// Игра разыгрывает исход и выплату, а use case одинаково для всех игр списывает
+// ставку, начисляет выигрыш, ведет джекпот и записывает раунд
 type SpinUseCase struct {
-	userRepo        user.Repository
-	transactionRepo transaction.Repository
-	spinRepo        spin.Repository
-	spinService     *spin.Service
+	uow          uow.UnitOfWork
+	games        *game.Registry
+	src          rng.Source
+	provablyFair bool
+	jackpotRules jackpot.Rules
 }
 
 // NewSpinUseCase создает новый use case для спинов
-func NewSpinUseCase(
-	userRepo user.Repository,
-	transactionRepo transaction.Repository,
-	spinRepo spin.Repository,
-	spinService *spin.Service,
-) *SpinUseCase {
+// В provably fair режиме исход выводится из сидов игрока, иначе из crypto/rand.
+// Если игра участвует в джекпоте, доля каждой ставки по jackpotRules идет в пул
+func NewSpinUseCase(unitOfWork uow.UnitOfWork, games *game.Registry, provablyFair bool, jackpotRules jackpot.Rules) *SpinUseCase {
 	return &SpinUseCase{
-		userRepo:        userRepo,
-		transactionRepo: transactionRepo,
-		spinRepo:        spinRepo,
-		spinService:     spinService,
+		uow:          unitOfWork,
+		games:        games,
+		src:          rng.NewCryptoSource(),
+		provablyFair: provablyFair,
+		jackpotRules: jackpotRules,
 	}
 }
 
 // SpinCommand представляет команду для выполнения спина
 type SpinCommand struct {
-	UserID    uint
-	BetAmount float64
+	UserID uint
+	// GameID - игра из реестра (пусто - классический автомат)
+	GameID    string
+	BetAmount money.Money
+	// LineBet - ставка на линию в игре с линиями выплат (game.LineGame)
+	// Если задана, общая ставка равна LineBet * число линий, а BetAmount можно не указывать
+	LineBet money.Money
+	// FreeSpin - сыграть бесплатный спин из бонуса игрока в игре GameID
+	// Ставка берется из бонуса, поэтому BetAmount и LineBet не указываются
+	FreeSpin bool
+	// IdempotencyKey - ключ идемпотентности запроса (пусто - без защиты от повтора)
+	IdempotencyKey string
 }
 
 // SpinResult представляет результат спина
 type SpinResult struct {
-	Reel1     int
-	Reel2     int
-	Reel3     int
+	SpinID  uint
+	RoundID string
+	GameID  string
+	// Outcome - исход раунда в формате игры
+	Outcome json.RawMessage
+	// BetAmount - общая ставка на раунд
+	BetAmount money.Money
 	IsWin     bool
-	WinAmount float64
-	Balance   float64
+	WinAmount money.Money
+	// JackpotAmount - выплата из пула джекпота, входит в WinAmount
+	JackpotAmount money.Money
+	Balance       money.Money
+	// FreeSpin - раунд сыгран как бесплатный спин, без списания ставки;
+	// TriggerSpinID - спин, запустивший бонус
+	FreeSpin      bool
+	TriggerSpinID uint
+	// FreeSpinsAwarded - бесплатные спины, выигранные в раунде
+	FreeSpinsAwarded int
+	// FreeSpinsRemaining - сколько бесплатных спинов осталось у игрока после раунда
+	FreeSpinsRemaining int
+	// Данные для проверки доказуемо честного раунда (пустые вне provably fair режима)
+	ServerSeedHash string
+	ClientSeed     string
+	Nonce          uint64
 }
 
 // Execute выполняет спин игры
+// Весь раунд (списание ставки, розыгрыш исхода, начисление выигрыша и запись
+// результата) выполняется в одной транзакции: либо применяется целиком, либо не применяется
 func (uc *SpinUseCase) Execute(cmd SpinCommand) (*SpinResult, error) {
-	if cmd.BetAmount <= 0 {
+	if cmd.GameID == "" {
+		cmd.GameID = spin.ClassicGameID
+	}
+	g, err := uc.games.Get(cmd.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.FreeSpin {
+		return uc.executeFreeSpin(cmd, g)
+	}
+
+	if !cmd.LineBet.IsZero() {
+		bet, err := totalBet(g, cmd.LineBet)
+		if err != nil {
+			return nil, err
+		}
+		if !cmd.BetAmount.IsZero() && cmd.BetAmount != bet {
+			return nil, fmt.Errorf("%w: общая ставка %s не равна ставке на линию, умноженной на число линий",
+				game.ErrInvalidBet, cmd.BetAmount.Format())
+		}
+		cmd.BetAmount = bet
+	}
+	if !cmd.BetAmount.IsPositive() {
 		return nil, user.ErrInvalidAmount
 	}
+	if err := g.ValidateBet(cmd.BetAmount); err != nil {
+		return nil, err
+	}
 
-	// Получаем пользователя
-	u, err := uc.userRepo.GetByID(cmd.UserID)
+	// Идентификатор раунда связывает результат спина с его транзакциями
+	roundID, err := spin.NewRoundID()
 	if err != nil {
 		return nil, err
 	}
 
-	// Списываем ставку через доменную логику
-	balanceBefore := u.Balance
-	if err := u.Withdraw(cmd.BetAmount); err != nil {
+	var result *SpinResult
+
+	err = uc.uow.Do(func(repos uow.Repositories) error {
+		if err := markApplied(repos, cmd); err != nil {
+			return err
+		}
+
+		// Получаем пользователя и блокируем его строку до конца транзакции,
+		// чтобы параллельные запросы не перезаписали баланс друг друга
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		// Проверяем самоограничение и лимиты ответственной игры, отмечаем ставку в игровой сессии
+		now := time.Now()
+		if err := u.EnsureCanPlay(now); err != nil {
+			return err
+		}
+		if err := u.EnsureNoFreeSpins(); err != nil {
+			return err
+		}
+		if err := limits.CheckBet(repos, u, cmd.BetAmount, now); err != nil {
+			return err
+		}
+
+		// Списываем ставку через доменную логику
+		balanceBefore := u.Balance
+		if err := u.Withdraw(cmd.BetAmount); err != nil {
+			return err
+		}
+
+		// Сохраняем обновленный баланс
+		if err := repos.Users().UpdateBalance(cmd.UserID, u.Balance); err != nil {
+			return err
+		}
+
+		// Создаем транзакцию на списание
+		betTx := transaction.NewTransaction(
+			cmd.UserID,
+			transaction.TypeSpin,
+			cmd.BetAmount,
+			balanceBefore,
+			u.Balance,
+			"Ставка в игре",
+		)
+		betTx.RoundID = roundID
+
+		if err := repos.Transactions().Create(betTx); err != nil {
+			return err
+		}
+
+		result = &SpinResult{}
+		src, seedPair, nonce, err := uc.roundSource(repos, cmd.UserID, result)
+		if err != nil {
+			return err
+		}
+
+		// Разыгрываем исход и выигрыш по математике игры
+		outcome, err := g.Play(src, cmd.BetAmount)
+		if err != nil {
+			return err
+		}
+		winAmount := outcome.Payout
+		isWin := winAmount.IsPositive()
+		jackpotAmount := money.Zero(cmd.BetAmount.Currency())
+
+		// Если есть выигрыш, добавляем его на баланс
+		if isWin {
+			if err := creditWin(repos, u, roundID, transaction.TypeWin, winAmount, "Выигрыш в игре"); err != nil {
+				return err
+			}
+		}
+
+		// Взнос в пул джекпота и выплата джекпота, если он выпал
+		if g.HasJackpot() {
+			jackpotAmount, err = uc.playJackpot(repos, u, betTx, outcome.Jackpot)
+			if err != nil {
+				return err
+			}
+		}
+		totalWin, err := winAmount.Add(jackpotAmount)
+		if err != nil {
+			return err
+		}
+
+		// Сохраняем результат спина
+		spinResult := spin.NewResult(
+			cmd.UserID,
+			g.ID(),
+			roundID,
+			cmd.BetAmount,
+			totalWin,
+			outcome.Data,
+			g.Version(),
+		)
+		spinResult.JackpotAmount = jackpotAmount
+		if seedPair != nil {
+			spinResult.SeedPairID = seedPair.ID
+			spinResult.Nonce = nonce
+		}
+		if err := repos.Spins().Create(spinResult); err != nil {
+			return err
+		}
+
+		// Бонус бесплатных спинов привязывается к запустившему его спину
+		if award := outcome.FreeSpins; award != nil {
+			if err := u.AwardFreeSpins(g.ID(), cmd.BetAmount, award.Spins, award.Num, award.Den, spinResult.ID, now); err != nil {
+				return err
+			}
+			if err := repos.Users().UpdateFreeSpins(cmd.UserID, u.FreeSpins); err != nil {
+				return err
+			}
+			result.FreeSpinsAwarded = award.Spins
+			result.FreeSpinsRemaining = award.Spins
+		}
+
+		result.SpinID = spinResult.ID
+		result.RoundID = roundID
+		result.GameID = g.ID()
+		result.Outcome = outcome.Data
+		result.BetAmount = cmd.BetAmount
+		result.IsWin = spinResult.IsWin
+		result.WinAmount = totalWin
+		result.JackpotAmount = jackpotAmount
+		result.Balance = u.Balance
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Сохраняем обновленный баланс
-	if err := uc.userRepo.UpdateBalance(cmd.UserID, u.Balance); err != nil {
+	return result, nil
+}
+
+// executeFreeSpin играет бесплатный спин из бонуса игрока
+// Ставка бонуса не списывается и не идет в пул джекпота, поэтому джекпот
+// в бесплатных спинах не разыгрывается. Выигрыш раунда умножается на коэффициент
+// бонуса, а результат спина ссылается на спин, запустивший бонус
+func (uc *SpinUseCase) executeFreeSpin(cmd SpinCommand, g game.Game) (*SpinResult, error) {
+	roundID, err := spin.NewRoundID()
+	if err != nil {
 		return nil, err
 	}
 
-	// Создаем транзакцию на списание
-	tx := transaction.NewTransaction(
-		cmd.UserID,
-		transaction.TypeSpin,
-		cmd.BetAmount,
+	var result *SpinResult
+
+	err = uc.uow.Do(func(repos uow.Repositories) error {
+		if err := markApplied(repos, cmd); err != nil {
+			return err
+		}
+
+		u, err := repos.Users().GetByIDForUpdate(cmd.UserID)
+		if err != nil {
+			return err
+		}
+
+		// Бесплатный спин - тоже игра: самоограничение и лимит сессии действуют на него
+		now := time.Now()
+		if err := u.EnsureCanPlay(now); err != nil {
+			return err
+		}
+		bonus, err := u.FreeSpinsFor(g.ID())
+		if err != nil {
+			return err
+		}
+		multiplier, err := spin.NewMultiplier(bonus.MultiplierNum, bonus.MultiplierDen)
+		if err != nil {
+			return err
+		}
+		if err := limits.CheckFreeSpin(repos, u, now); err != nil {
+			return err
+		}
+
+		result = &SpinResult{FreeSpin: true, TriggerSpinID: bonus.TriggerSpinID}
+		src, seedPair, nonce, err := uc.roundSource(repos, cmd.UserID, result)
+		if err != nil {
+			return err
+		}
+
+		outcome, err := g.Play(src, bonus.Bet)
+		if err != nil {
+			return err
+		}
+		winAmount, err := spin.FreeSpinWin(outcome.Payout, multiplier)
+		if err != nil {
+			return err
+		}
+		if winAmount.IsPositive() {
+			if err := creditWin(repos, u, roundID, transaction.TypeFreeSpinWin, winAmount, "Выигрыш бесплатного спина"); err != nil {
+				return err
+			}
+		}
+
+		spinResult := spin.NewResult(
+			cmd.UserID,
+			g.ID(),
+			roundID,
+			bonus.Bet,
+			winAmount,
+			outcome.Data,
+			g.Version(),
+		)
+		spinResult.TriggerSpinID = bonus.TriggerSpinID
+		spinResult.WinMultiplier = multiplier
+		if seedPair != nil {
+			spinResult.SeedPairID = seedPair.ID
+			spinResult.Nonce = nonce
+		}
+		if err := repos.Spins().Create(spinResult); err != nil {
+			return err
+		}
+
+		// Повторный запуск бонуса добавляет спины к оставшимся
+		if outcome.FreeSpins != nil {
+			result.FreeSpinsAwarded = outcome.FreeSpins.Spins
+		}
+		if err := u.PlayFreeSpin(winAmount, result.FreeSpinsAwarded, now); err != nil {
+			return err
+		}
+		if err := repos.Users().UpdateFreeSpins(cmd.UserID, u.FreeSpins); err != nil {
+			return err
+		}
+		if u.FreeSpins != nil {
+			result.FreeSpinsRemaining = u.FreeSpins.Remaining
+		}
+
+		result.SpinID = spinResult.ID
+		result.RoundID = roundID
+		result.GameID = g.ID()
+		result.Outcome = outcome.Data
+		result.BetAmount = bonus.Bet
+		result.IsWin = spinResult.IsWin
+		result.WinAmount = winAmount
+		result.JackpotAmount = money.Zero(winAmount.Currency())
+		result.Balance = u.Balance
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// markApplied отмечает ключ идемпотентности команды в той же транзакции, что и раунд:
+// повтор с тем же ключом не сыграет второй раунд
+func markApplied(repos uow.Repositories, cmd SpinCommand) error {
+	if cmd.IdempotencyKey == "" {
+		return nil
+	}
+	return repos.Idempotency().MarkApplied(cmd.UserID, cmd.IdempotencyKey)
+}
+
+// roundSource выбирает источник случайности раунда: сиды игрока или crypto/rand
+// В provably fair режиме берет следующий nonce активной пары сидов и заполняет
+// в result данные для проверки раунда
+func (uc *SpinUseCase) roundSource(repos uow.Repositories, userID uint, result *SpinResult) (rng.Source, *fairness.SeedPair, uint64, error) {
+	if !uc.provablyFair {
+		return uc.src, nil, 0, nil
+	}
+
+	seedPair, err := activeSeedPair(repos.Seeds(), userID)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	nonce := seedPair.NextNonce()
+	if err := repos.Seeds().Update(seedPair); err != nil {
+		return nil, nil, 0, err
+	}
+
+	result.ServerSeedHash = seedPair.ServerSeedHash
+	result.ClientSeed = seedPair.ClientSeed
+	result.Nonce = nonce
+	return fairness.NewStream(seedPair.ServerSeed, seedPair.ClientSeed, nonce), seedPair, nonce, nil
+}
+
+// creditWin начисляет выигрыш раунда roundID на баланс игрока u и создает транзакцию выигрыша типа txType
+func creditWin(repos uow.Repositories, u *user.User, roundID string, txType transaction.Type, amount money.Money, description string) error {
+	balanceBefore := u.Balance
+	if err := u.AddWin(amount); err != nil {
+		return err
+	}
+	if err := repos.Users().UpdateBalance(u.ID, u.Balance); err != nil {
+		return err
+	}
+
+	winTx := transaction.NewTransaction(
+		u.ID,
+		txType,
+		amount,
 		balanceBefore,
 		u.Balance,
-		"Ставка в игре",
+		description,
 	)
+	winTx.RoundID = roundID
+	return repos.Transactions().Create(winTx)
+}
 
-	if err := uc.transactionRepo.Create(tx); err != nil {
-		// Откатываем баланс в случае ошибки
-		_ = uc.userRepo.UpdateBalance(cmd.UserID, balanceBefore)
-		return nil, err
+// playJackpot переводит в пул джекпота взнос ставки betTx и, если hit, выплачивает пул игроку u
+// Пул блокируется до конца транзакции после пользователя, поэтому параллельные спины
+// обновляют его по очереди и не теряют взносы друг друга. Деньги пула учитываются на
+// системном счете jackpot: взносы, затравка и пополнение до затравки переводятся туда
+// из банкролла казино. Возвращает выплату из пула
+func (uc *SpinUseCase) playJackpot(repos uow.Repositories, u *user.User, betTx *transaction.Transaction, hit bool) (money.Money, error) {
+	pool, err := lockJackpotPool(repos, betTx.Amount.Currency(), uc.jackpotRules.Seed, betTx.ID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	contribution, err := uc.jackpotRules.Contribution(betTx.Amount)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if contribution.IsPositive() {
+		if err := pool.Contribute(contribution); err != nil {
+			return money.Money{}, err
+		}
+		if err := repos.Ledger().Post(ledger.JackpotFunding(betTx.ID, contribution)); err != nil {
+			return money.Money{}, err
+		}
 	}
 
-	// Генерируем символы на барабанах через доменный сервис
-	reel1 := uc.spinService.GenerateSymbol()
-	reel2 := uc.spinService.GenerateSymbol()
-	reel3 := uc.spinService.GenerateSymbol()
+	if !hit {
+		return money.Zero(betTx.Amount.Currency()), repos.Jackpots().Update(pool)
+	}
 
-	// Вычисляем выигрыш через доменный сервис
-	winAmount := uc.spinService.CalculateWin(reel1, reel2, reel3, cmd.BetAmount)
-	isWin := winAmount > 0
+	payout, err := pool.Win(betTx.Amount, uc.jackpotRules)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if err := repos.Jackpots().Update(pool); err != nil {
+		return money.Money{}, err
+	}
 
-	// Если есть выигрыш, добавляем его на баланс
-	if isWin {
-		balanceBeforeWin := u.Balance
-		if err := u.AddWin(winAmount); err != nil {
-			return nil, err
+	// Пополнение пула до затравки относится к транзакции выплаты, а если выплаты нет - к ставке
+	fundingTxID := betTx.ID
+	if payout.Amount.IsPositive() {
+		balanceBefore := u.Balance
+		if err := u.AddWin(payout.Amount); err != nil {
+			return money.Money{}, err
 		}
-
-		if err := uc.userRepo.UpdateBalance(cmd.UserID, u.Balance); err != nil {
-			return nil, err
+		if err := repos.Users().UpdateBalance(u.ID, u.Balance); err != nil {
+			return money.Money{}, err
 		}
 
-		// Создаем транзакцию на выигрыш
-		winTx := transaction.NewTransaction(
-			cmd.UserID,
-			transaction.TypeWin,
-			winAmount,
-			balanceBeforeWin,
+		jackpotTx := transaction.NewTransaction(
+			u.ID,
+			transaction.TypeJackpotWin,
+			payout.Amount,
+			balanceBefore,
 			u.Balance,
-			"Выигрыш в игре",
+			"Выигрыш джекпота",
 		)
+		jackpotTx.RoundID = betTx.RoundID
+		if err := repos.Transactions().Create(jackpotTx); err != nil {
+			return money.Money{}, err
+		}
+		fundingTxID = jackpotTx.ID
+	}
+	if payout.TopUp.IsPositive() {
+		if err := repos.Ledger().Post(ledger.JackpotFunding(fundingTxID, payout.TopUp)); err != nil {
+			return money.Money{}, err
+		}
+	}
 
-		if err := uc.transactionRepo.Create(winTx); err != nil {
-			// Откатываем баланс в случае ошибки
-			_ = uc.userRepo.UpdateBalance(cmd.UserID, balanceBeforeWin)
+	winner := jackpot.NewWinner(u.ID, betTx.RoundID, betTx.Amount, payout.Amount)
+	if err := repos.Jackpots().CreateWinner(winner); err != nil {
+		return money.Money{}, err
+	}
+	return payout.Amount, nil
+}
+
+// lockJackpotPool возвращает пул джекпота в валюте ставки с блокировкой,
+// создавая его с затравкой при первом спине. Затравка переводится в пул
+// из банкролла казино проводками транзакции txID
+// Затравка задана в одной валюте, поэтому ставки в других валютах отклоняются
+// с money.ErrCurrencyMismatch
+func lockJackpotPool(repos uow.Repositories, currency money.Currency, seed money.Money, txID uint) (*jackpot.Pool, error) {
+	if currency != seed.Currency() {
+		return nil, fmt.Errorf("%w: джекпот ведется в %s, ставка в %s", money.ErrCurrencyMismatch, seed.Currency(), currency)
+	}
+	pool, err := repos.Jackpots().GetForUpdate(currency)
+	if err == nil {
+		return pool, nil
+	}
+	if !errors.Is(err, jackpot.ErrPoolNotFound) {
+		return nil, err
+	}
+
+	created, err := repos.Jackpots().Create(jackpot.NewPool(seed))
+	if err != nil {
+		return nil, err
+	}
+	if created && seed.IsPositive() {
+		if err := repos.Ledger().Post(ledger.JackpotFunding(txID, seed)); err != nil {
 			return nil, err
 		}
 	}
+	// Пул мог создать параллельный спин: в любом случае читаем его под блокировкой
+	return repos.Jackpots().GetForUpdate(currency)
+}
 
-	// Сохраняем результат спина
-	spinResult := spin.NewResult(cmd.UserID, cmd.BetAmount, winAmount, reel1, reel2, reel3)
-	if err := uc.spinRepo.Create(spinResult); err != nil {
+// activeSeedPair возвращает активную пару сидов игрока с блокировкой,
+// создавая ее при первом спине
+func activeSeedPair(seeds fairness.Repository, userID uint) (*fairness.SeedPair, error) {
+	pair, err := seeds.GetActiveByUserIDForUpdate(userID)
+	if err == nil {
+		return pair, nil
+	}
+	if !errors.Is(err, fairness.ErrSeedNotFound) {
 		return nil, err
 	}
 
-	// Получаем финальный баланс
-	finalUser, err := uc.userRepo.GetByID(cmd.UserID)
+	pair, err = fairness.NewSeedPair(userID, "")
 	if err != nil {
 		return nil, err
 	}
-
-	return &SpinResult{
-		Reel1:     reel1,
-		Reel2:     reel2,
-		Reel3:     reel3,
-		IsWin:     isWin,
-		WinAmount: winAmount,
-		Balance:   finalUser.Balance,
-	}, nil
+	if err := seeds.Create(pair); err != nil {
+		return nil, err
+	}
+	return pair, nil
 }
 
+// totalBet возвращает общую ставку на раунд для ставки на линию
+func totalBet(g game.Game, lineBet money.Money) (money.Money, error) {
+	if !lineBet.IsPositive() {
+		return money.Money{}, user.ErrInvalidAmount
+	}
+	lines, ok := g.(game.LineGame)
+	if !ok {
+		return money.Money{}, fmt.Errorf("%w: в игре %q нет линий выплат", game.ErrInvalidBet, g.ID())
+	}
+	return game.TotalBet(lineBet, lines.Lines())
+}
@@ -0,0 +1,257 @@
+package statement
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"io"
+	"time"
+)
+
+// batchSize - сколько записей читается из репозитория за один запрос
+const batchSize = 500
+
+// totalsOrder - порядок итогов по типам транзакций в выписке
+var totalsOrder = []transaction.Type{
+	transaction.TypeDeposit,
+	transaction.TypeSpin,
+	transaction.TypeWin,
+	transaction.TypeJackpotWin,
+	transaction.TypeFreeSpinWin,
+	transaction.TypeWithdrawal,
+	transaction.TypeWithdrawalPayout,
+	transaction.TypeWithdrawalRefund,
+}
+
+// GenerateUseCase представляет use case для построения выписки по счету
+type GenerateUseCase struct {
+	transactionRepo transaction.Repository
+	spinRepo        spin.Repository
+	userRepo        user.Repository
+	exporter        Exporter
+}
+
+// NewGenerateUseCase создает новый use case для построения выписки
+func NewGenerateUseCase(
+	transactionRepo transaction.Repository,
+	spinRepo spin.Repository,
+	userRepo user.Repository,
+	exporter Exporter,
+) *GenerateUseCase {
+	return &GenerateUseCase{
+		transactionRepo: transactionRepo,
+		spinRepo:        spinRepo,
+		userRepo:        userRepo,
+		exporter:        exporter,
+	}
+}
+
+// GenerateCommand представляет команду для построения выписки
+type GenerateCommand struct {
+	UserID uint
+	// From и To - период: From включительно, To не включительно
+	// Нулевой From - с открытия счета, нулевой To - по текущий момент
+	From   time.Time
+	To     time.Time
+	Format Format
+}
+
+// Header представляет заголовок выписки
+type Header struct {
+	UserID         uint
+	Username       string
+	Currency       money.Currency
+	From           time.Time // Нулевое значение - с открытия счета
+	To             time.Time
+	OpeningBalance money.Money
+	GeneratedAt    time.Time
+}
+
+// Line представляет транзакцию в выписке
+type Line struct {
+	ID            uint
+	RoundID       string
+	Type          transaction.Type
+	Amount        money.Money
+	BalanceBefore money.Money
+	BalanceAfter  money.Money
+	Description   string
+	CreatedAt     time.Time
+}
+
+// TypeTotal представляет итог по одному типу транзакций
+type TypeTotal struct {
+	Type   transaction.Type
+	Count  int
+	Amount money.Money
+}
+
+// Summary представляет итоги выписки
+type Summary struct {
+	// Totals - итоги по типам транзакций, встретившимся в периоде
+	Totals []TypeTotal
+	// Rounds и RoundsWon - число сыгранных и выигрышных раундов
+	Rounds    int
+	RoundsWon int
+	// NetGamingResult - результат игры для игрока: выигрыши минус ставки
+	// Отрицательное значение означает проигрыш игрока
+	NetGamingResult money.Money
+	ClosingBalance  money.Money
+}
+
+// Execute строит выписку и пишет ее в w в формате из команды
+// Транзакции и спины читаются пачками, а строки сразу отдаются Writer,
+// поэтому память не зависит от длины истории. Ошибки проверки команды
+// и поиска пользователя возвращаются до того, как в w записан первый байт
+func (uc *GenerateUseCase) Execute(cmd GenerateCommand, w io.Writer) error {
+	if !cmd.From.IsZero() && !cmd.To.IsZero() && !cmd.From.Before(cmd.To) {
+		return transaction.ErrInvalidPeriod
+	}
+	out, err := uc.exporter.NewWriter(cmd.Format, w)
+	if err != nil {
+		return err
+	}
+	if cmd.To.IsZero() {
+		cmd.To = time.Now()
+	}
+
+	u, err := uc.userRepo.GetByID(cmd.UserID)
+	if err != nil {
+		return err
+	}
+	currency := u.Balance.Currency()
+
+	opening, err := uc.openingBalance(cmd.UserID, cmd.From, currency)
+	if err != nil {
+		return err
+	}
+
+	err = out.WriteHeader(Header{
+		UserID:         u.ID,
+		Username:       u.Username,
+		Currency:       currency,
+		From:           cmd.From,
+		To:             cmd.To,
+		OpeningBalance: opening,
+		GeneratedAt:    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	totals := make(map[transaction.Type]*TypeTotal)
+	closing := opening
+	query := transaction.Query{
+		UserID: cmd.UserID,
+		From:   cmd.From,
+		To:     cmd.To,
+		Sort:   transaction.SortOldest,
+		Limit:  batchSize,
+	}
+	for {
+		page, err := uc.transactionRepo.Find(query)
+		if err != nil {
+			return err
+		}
+		for _, tx := range page.Transactions {
+			if err := out.WriteLine(toLine(tx)); err != nil {
+				return err
+			}
+			total, ok := totals[tx.Type]
+			if !ok {
+				total = &TypeTotal{Type: tx.Type, Amount: money.Zero(currency)}
+				totals[tx.Type] = total
+			}
+			total.Count++
+			if total.Amount, err = total.Amount.Add(tx.Amount); err != nil {
+				return err
+			}
+			closing = tx.BalanceAfter
+		}
+		if page.Next == nil {
+			break
+		}
+		query.After = page.Next
+	}
+
+	summary := Summary{ClosingBalance: closing, NetGamingResult: money.Zero(currency)}
+	for _, t := range totalsOrder {
+		if total, ok := totals[t]; ok {
+			summary.Totals = append(summary.Totals, *total)
+		}
+	}
+	for _, t := range []transaction.Type{transaction.TypeWin, transaction.TypeJackpotWin, transaction.TypeFreeSpinWin} {
+		if win, ok := totals[t]; ok {
+			if summary.NetGamingResult, err = summary.NetGamingResult.Add(win.Amount); err != nil {
+				return err
+			}
+		}
+	}
+	if bet, ok := totals[transaction.TypeSpin]; ok {
+		if summary.NetGamingResult, err = summary.NetGamingResult.Sub(bet.Amount); err != nil {
+			return err
+		}
+	}
+
+	if summary.Rounds, summary.RoundsWon, err = uc.countRounds(cmd.UserID, cmd.From, cmd.To); err != nil {
+		return err
+	}
+
+	return out.WriteSummary(summary)
+}
+
+// openingBalance возвращает баланс на начало периода - баланс после последней
+// транзакции до него; у нового счета и для периода с открытия счета он нулевой
+func (uc *GenerateUseCase) openingBalance(userID uint, from time.Time, currency money.Currency) (money.Money, error) {
+	if from.IsZero() {
+		return money.Zero(currency), nil
+	}
+	page, err := uc.transactionRepo.Find(transaction.Query{
+		UserID: userID,
+		To:     from,
+		Sort:   transaction.SortNewest,
+		Limit:  1,
+	})
+	if err != nil {
+		return money.Money{}, err
+	}
+	if len(page.Transactions) == 0 {
+		return money.Zero(currency), nil
+	}
+	return page.Transactions[0].BalanceAfter, nil
+}
+
+// countRounds считает сыгранные и выигрышные раунды за период
+func (uc *GenerateUseCase) countRounds(userID uint, from, to time.Time) (rounds, won int, err error) {
+	query := spin.Query{UserID: userID, From: from, To: to, Limit: batchSize}
+	for {
+		var page *spin.Page
+		if page, err = uc.spinRepo.Find(query); err != nil {
+			return 0, 0, err
+		}
+		for _, r := range page.Results {
+			rounds++
+			if r.IsWin {
+				won++
+			}
+		}
+		if page.Next == nil {
+			return rounds, won, nil
+		}
+		query.After = page.Next
+	}
+}
+
+func toLine(tx *transaction.Transaction) Line {
+	return Line{
+		ID:            tx.ID,
+		RoundID:       tx.RoundID,
+		Type:          tx.Type,
+		Amount:        tx.Amount,
+		BalanceBefore: tx.BalanceBefore,
+		BalanceAfter:  tx.BalanceAfter,
+		Description:   tx.Description,
+		CreatedAt:     tx.CreatedAt,
+	}
+}
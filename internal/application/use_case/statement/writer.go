@@ -0,0 +1,56 @@
+package statement
+
+import (
+	"errors"
+	"io"
+)
+
+// Format определяет формат выгрузки выписки
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatPDF  Format = "pdf"
+)
+
+// ErrUnsupportedFormat возвращается для неизвестного формата выписки
+var ErrUnsupportedFormat = errors.New("неподдерживаемый формат выписки")
+
+// ParseFormat разбирает формат выписки; пустая строка - CSV
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatJSON, FormatPDF:
+		return format, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// ContentType возвращает MIME тип выгрузки
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Writer записывает выписку по мере ее построения: заголовок, строки и итоги
+// Строки передаются по одной, поэтому выписка любого размера не держится в памяти
+type Writer interface {
+	WriteHeader(header Header) error
+	WriteLine(line Line) error
+	// WriteSummary записывает итоги и завершает документ
+	WriteSummary(summary Summary) error
+}
+
+// Exporter создает Writer выписки в нужном формате (порт, реализуется в infrastructure)
+type Exporter interface {
+	NewWriter(format Format, out io.Writer) (Writer, error)
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
+	"gambling/internal/domain/money"
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -21,9 +24,99 @@ type Config struct {
 	DBSSLMode string
 
 	LogLevel string
+
+	// PaytablePath - путь к JSON-файлу таблицы выплат (пусто - встроенная таблица)
+	PaytablePath string
+	// VideoPaytablePath - путь к JSON-файлу таблицы видеослота (пусто - встроенная таблица)
+	VideoPaytablePath string
+
+	// ProvablyFair - генерировать исход спинов из пары серверного и клиентского сидов
+	ProvablyFair bool
+
+	// ShutdownTimeout - сколько ждать завершения активных HTTP запросов при остановке
+	ShutdownTimeout time.Duration
+
+	// JWTSecret - секрет подписи access токенов (HS256), не короче 32 байт
+	JWTSecret string
+	// AccessTokenTTL - срок действия access токена
+	AccessTokenTTL time.Duration
+	// RefreshTokenTTL - срок действия refresh токена (длительность сессии без входа)
+	RefreshTokenTTL time.Duration
+
+	// ReconcileInterval - период фоновой сверки балансов в serve (0 - выключена)
+	ReconcileInterval time.Duration
+
+	// IdempotencyTTL - сколько хранятся ключи идемпотентности и ответы для повторов
+	IdempotencyTTL time.Duration
+
+	// LimitCoolingPeriod - через сколько вступает в силу ослабление лимита ответственной игры
+	LimitCoolingPeriod time.Duration
+
+	// JackpotContributionBPS - доля каждой ставки в пул джекпота, в базисных пунктах (100 = 1%)
+	JackpotContributionBPS int64
+	// JackpotSeed - затравка пула джекпота: начальная сумма и сумма после выигрыша
+	JackpotSeed money.Money
+	// JackpotFullBet - ставка, выигрывающая весь пул; меньшие ставки получают долю (0 - без пропорции)
+	JackpotFullBet money.Money
+}
+
+// ErrMissingDBConfig возвращается, если не заданы обязательные параметры базы данных
+var ErrMissingDBConfig = errors.New("необходимые параметры базы данных отсутствуют")
+
+// ErrMissingJWTSecret возвращается, если HTTP API запускается без секрета подписи токенов
+var ErrMissingJWTSecret = errors.New("не задан JWT_SECRET для подписи токенов")
+
+// ValidateAuth проверяет, что задан секрет подписи токенов
+func (c *Config) ValidateAuth() error {
+	if c.JWTSecret == "" {
+		return ErrMissingJWTSecret
+	}
+	return nil
+}
+
+// ValidateDB проверяет, что заданы параметры подключения к базе данных
+func (c *Config) ValidateDB() error {
+	if c.DBHost == "" || c.DBUser == "" || c.DBPassword == "" || c.DBName == "" {
+		return ErrMissingDBConfig
+	}
+	return nil
 }
 
+// MustLoad загружает конфигурацию и паникует, если не заданы параметры базы данных
 func MustLoad() *Config {
+	config := Load()
+
+	if err := config.ValidateDB(); err != nil {
+		PrintDBConfigHelp()
+		panic(err.Error())
+	}
+
+	return config
+}
+
+// PrintDBConfigHelp выводит подсказку по настройке подключения к базе данных
+func PrintDBConfigHelp() {
+	log.Println("═══════════════════════════════════════════════════════════")
+	log.Println("❌ ОШИБКА: Необходимые параметры базы данных отсутствуют")
+	log.Println("═══════════════════════════════════════════════════════════")
+	log.Println("Создайте файл .env в корне проекта со следующим содержимым:")
+	log.Println("")
+	log.Println("DB_HOST=localhost")
+	log.Println("DB_PORT=5432")
+	log.Println("DB_USER=your_postgres_user")
+	log.Println("DB_PASSWORD=your_postgres_password")
+	log.Println("DB_NAME=gambling")
+	log.Println("DB_SSLMODE=disable")
+	log.Println("APP_ENV=local")
+	log.Println("")
+	log.Println("Или скопируйте .env.example в .env и заполните значения:")
+	log.Println("  cp .env.example .env")
+	log.Println("═══════════════════════════════════════════════════════════")
+}
+
+// Load загружает конфигурацию из .env и переменных окружения без проверки параметров БД
+// Используется командами, которым не нужна база данных (например, analyze)
+func Load() *Config {
 	err := godotenv.Load()
 	if err != nil {
 		log.Println("Файл .env не найден, использую переменные окружения")
@@ -48,25 +141,61 @@ func MustLoad() *Config {
 	config.DBSSLMode = getEnv("DB_SSLMODE", "disable")
 
 	config.LogLevel = getEnv("LOG_LEVEL", "info")
+	config.PaytablePath = getEnv("PAYTABLE_PATH", "")
+	config.VideoPaytablePath = getEnv("VIDEO_PAYTABLE_PATH", "")
 
-	if config.DBHost == "" || config.DBUser == "" || config.DBPassword == "" || config.DBName == "" {
-		log.Println("═══════════════════════════════════════════════════════════")
-		log.Println("❌ ОШИБКА: Необходимые параметры базы данных отсутствуют")
-		log.Println("═══════════════════════════════════════════════════════════")
-		log.Println("Создайте файл .env в корне проекта со следующим содержимым:")
-		log.Println("")
-		log.Println("DB_HOST=localhost")
-		log.Println("DB_PORT=5432")
-		log.Println("DB_USER=your_postgres_user")
-		log.Println("DB_PASSWORD=your_postgres_password")
-		log.Println("DB_NAME=gambling")
-		log.Println("DB_SSLMODE=disable")
-		log.Println("APP_ENV=local")
-		log.Println("")
-		log.Println("Или скопируйте .env.example в .env и заполните значения:")
-		log.Println("  cp .env.example .env")
-		log.Println("═══════════════════════════════════════════════════════════")
-		panic("необходимые параметры базы данных отсутствуют")
+	config.ProvablyFair, err = strconv.ParseBool(getEnv("PROVABLY_FAIR", "true"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.ShutdownTimeout, err = time.ParseDuration(getEnv("SHUTDOWN_TIMEOUT", "15s"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.JWTSecret = getEnv("JWT_SECRET", "")
+	config.AccessTokenTTL, err = time.ParseDuration(getEnv("ACCESS_TOKEN_TTL", "15m"))
+	if err != nil {
+		panic(err)
+	}
+	config.RefreshTokenTTL, err = time.ParseDuration(getEnv("REFRESH_TOKEN_TTL", "720h"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.ReconcileInterval, err = time.ParseDuration(getEnv("RECONCILE_INTERVAL", "24h"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.IdempotencyTTL, err = time.ParseDuration(getEnv("IDEMPOTENCY_TTL", "24h"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.LimitCoolingPeriod, err = time.ParseDuration(getEnv("LIMIT_COOLING_PERIOD", "24h"))
+	if err != nil {
+		panic(err)
+	}
+
+	config.JackpotContributionBPS, err = strconv.ParseInt(getEnv("JACKPOT_CONTRIBUTION_BPS", "100"), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	if config.JackpotContributionBPS < 0 || config.JackpotContributionBPS > 10000 {
+		panic("JACKPOT_CONTRIBUTION_BPS должен быть от 0 до 10000")
+	}
+	config.JackpotSeed, err = money.Parse(getEnv("JACKPOT_SEED", "1000.00"), money.DefaultCurrency)
+	if err != nil {
+		panic(err)
+	}
+	config.JackpotFullBet, err = money.Parse(getEnv("JACKPOT_FULL_BET", "0"), money.DefaultCurrency)
+	if err != nil {
+		panic(err)
+	}
+	if config.JackpotSeed.IsNegative() || config.JackpotFullBet.IsNegative() {
+		panic("JACKPOT_SEED и JACKPOT_FULL_BET не могут быть отрицательными")
 	}
 
 	return config
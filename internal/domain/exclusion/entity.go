@@ -0,0 +1,68 @@
+package exclusion
+
+import (
+	"gambling/internal/domain/user"
+	"strings"
+	"time"
+)
+
+// Action определяет действие с самоограничением в журнале аудита
+type Action string
+
+const (
+	ActionSet  Action = "set"  // Самоограничение установлено или продлено
+	ActionLift Action = "lift" // Самоограничение снято оператором
+)
+
+// maxReasonLength - максимальная длина комментария к записи журнала
+const maxReasonLength = 255
+
+// Event представляет запись журнала аудита самоограничений
+// ActorID - кто выполнил действие: сам игрок или оператор
+type Event struct {
+	ID      uint
+	UserID  uint
+	ActorID uint
+	Action  Action
+	Kind    user.ExclusionKind
+	Until   *time.Time // nil у бессрочного самоисключения
+	Reason  string
+	// CreatedAt заполняет репозиторий
+	CreatedAt time.Time
+}
+
+// NewSetEvent создает запись об установке самоограничения
+func NewSetEvent(userID, actorID uint, e *user.Exclusion, reason string) (*Event, error) {
+	if len(reason) > maxReasonLength {
+		return nil, ErrInvalidReason
+	}
+	return &Event{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  ActionSet,
+		Kind:    e.Kind,
+		Until:   e.Until,
+		Reason:  reason,
+	}, nil
+}
+
+// NewLiftEvent создает запись о снятии самоограничения
+func NewLiftEvent(userID, actorID uint, e *user.Exclusion, reason string) (*Event, error) {
+	if len(reason) > maxReasonLength {
+		return nil, ErrInvalidReason
+	}
+	return &Event{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  ActionLift,
+		Kind:    e.Kind,
+		Until:   e.Until,
+		Reason:  reason,
+	}, nil
+}
+
+// NormalizeEmail приводит email к виду, в котором он хранится в списке заблокированных:
+// адреса, отличающиеся регистром или пробелами, считаются одним адресом
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,8 @@
+package exclusion
+
+import "errors"
+
+var (
+	ErrEmailBlocked  = errors.New("email заблокирован бессрочным самоисключением")
+	ErrInvalidReason = errors.New("слишком длинный комментарий")
+)
@@ -0,0 +1,14 @@
+package exclusion
+
+// Repository определяет интерфейс для журнала самоограничений и списка
+// email, заблокированных бессрочным самоисключением
+type Repository interface {
+	CreateEvent(event *Event) error
+	// GetEventsByUserID возвращает журнал пользователя, новые записи первыми
+	GetEventsByUserID(userID uint, limit int) ([]*Event, error)
+	// BlockEmail запрещает регистрацию с email; повторная блокировка не является ошибкой
+	BlockEmail(email string, userID uint) error
+	// UnblockEmail снимает запрет регистрации, установленный для пользователя userID
+	UnblockEmail(userID uint) error
+	IsEmailBlocked(email string) (bool, error)
+}
@@ -0,0 +1,95 @@
+package fairness
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// serverSeedBytes - длина серверного сида в байтах
+const serverSeedBytes = 32
+
+// clientSeedBytes - длина клиентского сида по умолчанию в байтах
+const clientSeedBytes = 16
+
+// maxClientSeedLength ограничивает длину клиентского сида, заданного игроком
+const maxClientSeedLength = 64
+
+// SeedPair представляет доменную сущность пары сидов для доказуемо честной игры
+// Хеш серверного сида публикуется игроку заранее, сам сид раскрывается только после ротации.
+// Исход каждого раунда однозначно определяется серверным сидом, клиентским сидом и nonce
+type SeedPair struct {
+	ID             uint
+	UserID         uint
+	ServerSeed     string // Секрет до раскрытия, hex
+	ServerSeedHash string // SHA-256 от ServerSeed, hex
+	ClientSeed     string
+	Nonce          uint64 // Номер следующего раунда на этой паре
+	Active         bool
+	RevealedAt     *time.Time
+	CreatedAt      time.Time
+}
+
+// NewSeedPair создает новую активную пару со свежим серверным сидом
+// Если клиентский сид пустой, он генерируется случайно
+func NewSeedPair(userID uint, clientSeed string) (*SeedPair, error) {
+	if len(clientSeed) > maxClientSeedLength {
+		return nil, ErrInvalidClientSeed
+	}
+	if clientSeed == "" {
+		generated, err := randomHex(clientSeedBytes)
+		if err != nil {
+			return nil, err
+		}
+		clientSeed = generated
+	}
+
+	serverSeed, err := randomHex(serverSeedBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeedPair{
+		UserID:         userID,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: HashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		Active:         true,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// NextNonce возвращает nonce для очередного раунда и увеличивает счетчик
+func (p *SeedPair) NextNonce() uint64 {
+	nonce := p.Nonce
+	p.Nonce++
+	return nonce
+}
+
+// Reveal деактивирует пару и раскрывает серверный сид
+// После раскрытия по паре больше нельзя играть, зато любой ее раунд можно проверить
+func (p *SeedPair) Reveal() {
+	now := time.Now()
+	p.Active = false
+	p.RevealedAt = &now
+}
+
+// IsRevealed проверяет, раскрыт ли серверный сид
+func (p *SeedPair) IsRevealed() bool {
+	return p.RevealedAt != nil
+}
+
+// HashServerSeed вычисляет публикуемый хеш серверного сида
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,11 @@
+package fairness
+
+import "errors"
+
+var (
+	ErrSeedNotFound      = errors.New("пара сидов не найдена")
+	ErrSeedNotRevealed   = errors.New("серверный сид еще не раскрыт")
+	ErrInvalidClientSeed = errors.New("неверный клиентский сид")
+	ErrNotProvablyFair   = errors.New("раунд сыгран не в режиме доказуемо честной игры")
+	ErrPaytableMismatch  = errors.New("раунд сыгран по неизвестной версии таблицы выплат")
+)
@@ -0,0 +1,13 @@
+package fairness
+
+// Repository определяет интерфейс для работы с парами сидов
+type Repository interface {
+	Create(pair *SeedPair) error
+	GetByID(id uint) (*SeedPair, error)
+	// GetActiveByUserIDForUpdate возвращает активную пару и блокирует ее до конца
+	// единицы работы, чтобы параллельные спины не получили одинаковый nonce
+	GetActiveByUserIDForUpdate(userID uint) (*SeedPair, error)
+	GetActiveByUserID(userID uint) (*SeedPair, error)
+	ListRevealedByUserID(userID uint, limit int) ([]*SeedPair, error)
+	Update(pair *SeedPair) error
+}
@@ -0,0 +1,48 @@
+package fairness
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+)
+
+// Stream представляет детерминированный поток случайных чисел раунда
+// Блоки потока вычисляются как HMAC-SHA256(serverSeed, "clientSeed:nonce:cursor"),
+// где cursor - номер блока. Каждый блок дает четыре 64-битных числа.
+// Зная раскрытый серверный сид, игрок может воспроизвести поток и проверить исход
+// Stream реализует rng.Source, но не безопасен для конкурентного использования:
+// поток создается на один раунд и не разделяется между запросами
+type Stream struct {
+	serverSeed []byte
+	clientSeed string
+	nonce      uint64
+	cursor     uint64
+	block      []byte
+}
+
+// NewStream создает поток для раунда с заданными сидами и nonce
+func NewStream(serverSeed, clientSeed string, nonce uint64) *Stream {
+	return &Stream{
+		serverSeed: []byte(serverSeed),
+		clientSeed: clientSeed,
+		nonce:      nonce,
+	}
+}
+
+// Uint64 возвращает следующее 64-битное число потока
+func (s *Stream) Uint64() uint64 {
+	if len(s.block) == 0 {
+		s.block = s.nextBlock()
+	}
+	v := binary.BigEndian.Uint64(s.block[:8])
+	s.block = s.block[8:]
+	return v
+}
+
+func (s *Stream) nextBlock() []byte {
+	mac := hmac.New(sha256.New, s.serverSeed)
+	mac.Write([]byte(s.clientSeed + ":" + strconv.FormatUint(s.nonce, 10) + ":" + strconv.FormatUint(s.cursor, 10)))
+	s.cursor++
+	return mac.Sum(nil)
+}
@@ -0,0 +1,9 @@
+package game
+
+import "errors"
+
+var (
+	ErrGameNotFound    = errors.New("игра не найдена")
+	ErrVersionNotFound = errors.New("версия игры не найдена")
+	ErrInvalidBet      = errors.New("ставка не подходит для игры")
+)
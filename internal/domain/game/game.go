@@ -0,0 +1,81 @@
+package game
+
+import (
+	"encoding/json"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
+	"reflect"
+)
+
+// Game определяет порт игры казино
+// Игра отвечает только за математику раунда: проверку ставки, исход и выплату.
+// Списание ставки, начисление выигрыша, джекпот и запись раунда выполняет
+// SpinUseCase одинаково для всех игр
+type Game interface {
+	// ID возвращает идентификатор игры в реестре и в API (например, "classic")
+	ID() string
+	// Name возвращает название игры для игрока
+	Name() string
+	// Version возвращает версию математики игры (таблицы выплат), по которой играется раунд
+	Version() string
+	// HasJackpot сообщает, участвует ли игра в прогрессивном джекпоте:
+	// доля ставок таких игр идет в общий пул
+	HasJackpot() bool
+	// ValidateBet проверяет, что ставку можно сыграть в этой игре
+	ValidateBet(bet money.Money) error
+	// Play разыгрывает раунд на ставку bet
+	// Исход полностью определяется src, что позволяет воспроизвести доказуемо честный раунд
+	Play(src rng.Source, bet money.Money) (*Outcome, error)
+}
+
+// Outcome представляет исход раунда игры
+type Outcome struct {
+	// Data - данные исхода, специфичные для игры (символы, линии и т.п.), в JSON
+	Data json.RawMessage
+	// Payout - выигрыш по таблице выплат игры, без джекпота
+	Payout money.Money
+	// Jackpot - выпала ли комбинация прогрессивного джекпота
+	Jackpot bool
+	// FreeSpins - бесплатные спины, выигранные в раунде (nil - бонус не выпал)
+	FreeSpins *FreeSpinsAward
+}
+
+// FreeSpinsAward описывает бонус бесплатных спинов, выигранный в раунде
+// Бесплатные спины играются на ставку раунда, запустившего бонус, а их выигрыши
+// умножаются на коэффициент Num/Den
+type FreeSpinsAward struct {
+	Spins int
+	Num   int64
+	Den   int64
+}
+
+// SameData сравнивает данные исходов по содержимому, а не по байтам:
+// JSON, прочитанный из БД, может отличаться форматированием и порядком ключей
+func SameData(a, b json.RawMessage) bool {
+	var av, bv interface{}
+	if err := json.Unmarshal(a, &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// LineGame - игра с линиями выплат
+// Ставка на раунд в такой игре равна ставке на линию, умноженной на число линий
+type LineGame interface {
+	Game
+	// Lines возвращает число линий выплат
+	Lines() int
+}
+
+// TotalBet возвращает ставку на раунд для ставки на линию lineBet и lines линий
+// Возвращает ErrInvalidBet для неположительной ставки и money.ErrOverflow,
+// если общая ставка не помещается в сумму
+func TotalBet(lineBet money.Money, lines int) (money.Money, error) {
+	if !lineBet.IsPositive() || lines <= 0 {
+		return money.Money{}, ErrInvalidBet
+	}
+	return lineBet.CheckedMulRatio(int64(lines), 1, money.RoundDown)
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Registry хранит игры по идентификатору
+// Игры регистрируются при старте приложения, после этого реестр только читается
+// и безопасен для конкурентного использования
+type Registry struct {
+	games map[string]Game
+	// versions - все версии игр, включая прежние, по идентификатору и версии
+	versions map[versionKey]Game
+}
+
+type versionKey struct {
+	id      string
+	version string
+}
+
+// NewRegistry создает реестр из игр
+// Паникует, если два раза указан один идентификатор: это ошибка конфигурации
+func NewRegistry(games ...Game) *Registry {
+	r := &Registry{
+		games:    make(map[string]Game, len(games)),
+		versions: make(map[versionKey]Game, len(games)),
+	}
+	for _, g := range games {
+		if _, exists := r.games[g.ID()]; exists {
+			panic(fmt.Sprintf("game %q registered twice", g.ID()))
+		}
+		r.games[g.ID()] = g
+		r.versions[versionKey{id: g.ID(), version: g.Version()}] = g
+	}
+	return r
+}
+
+// AddVersions регистрирует прежние версии игр: в них больше не играют, но по ним
+// проверяются сыгранные раунды. Версия, уже известная реестру, не заменяется,
+// поэтому текущая игра из NewRegistry остается в силе. Вызывается при старте приложения
+func (r *Registry) AddVersions(games ...Game) *Registry {
+	for _, g := range games {
+		key := versionKey{id: g.ID(), version: g.Version()}
+		if _, exists := r.versions[key]; !exists {
+			r.versions[key] = g
+		}
+	}
+	return r
+}
+
+// Get возвращает игру по идентификатору или ErrGameNotFound
+func (r *Registry) Get(id string) (Game, error) {
+	g, ok := r.games[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrGameNotFound, id)
+	}
+	return g, nil
+}
+
+// GetVersion возвращает игру по идентификатору и версии математики
+// Возвращает ErrGameNotFound, если игры нет в реестре, и ErrVersionNotFound,
+// если игра есть, но такая версия не зарегистрирована
+func (r *Registry) GetVersion(id, version string) (Game, error) {
+	if g, ok := r.versions[versionKey{id: id, version: version}]; ok {
+		return g, nil
+	}
+	if _, err := r.Get(id); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: %q версии %q", ErrVersionNotFound, id, version)
+}
+
+// List возвращает все игры, упорядоченные по идентификатору
+func (r *Registry) List() []Game {
+	result := make([]Game, 0, len(r.games))
+	for _, g := range r.games {
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	return result
+}
@@ -0,0 +1,84 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// MaxKeyLength - максимальная длина ключа идемпотентности
+const MaxKeyLength = 255
+
+// Record представляет ключ идемпотентности и сохраненный ответ на первый запрос с ним
+// Пока ответ не сохранен, запись удерживается обработчиком (LockedAt), и повтор
+// с тем же ключом получает отказ, а не второе выполнение операции
+type Record struct {
+	UserID      uint
+	Key         string
+	Fingerprint string
+	// StatusCode равен 0, пока первый запрос не завершен
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	// AppliedAt - когда операция с этим ключом зафиксирована в базе данных
+	AppliedAt *time.Time
+	LockedAt  time.Time
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// NewRecord создает запись для первого запроса с ключом
+func NewRecord(userID uint, key, fingerprint string, ttl time.Duration) (*Record, error) {
+	if key == "" || len(key) > MaxKeyLength {
+		return nil, ErrInvalidKey
+	}
+	now := time.Now()
+	return &Record{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		LockedAt:    now,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+// Fingerprint возвращает отпечаток запроса: метод, путь и тело
+// Повтор с тем же ключом, но другим отпечатком - это другой запрос
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Matches проверяет, что повтор относится к тому же запросу
+func (r *Record) Matches(fingerprint string) bool {
+	return r.Fingerprint == fingerprint
+}
+
+// IsCompleted проверяет, сохранен ли ответ на первый запрос
+func (r *Record) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// IsExpired проверяет, истек ли срок хранения ключа
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// IsStale проверяет, что обработчик, удерживающий ключ, не завершил запрос
+// за lockTimeout (например, процесс был остановлен) и ключ можно перехватить
+func (r *Record) IsStale(now time.Time, lockTimeout time.Duration) bool {
+	return !r.IsCompleted() && now.Sub(r.LockedAt) >= lockTimeout
+}
+
+// Complete сохраняет ответ на первый запрос
+func (r *Record) Complete(statusCode int, contentType string, body []byte) {
+	r.StatusCode = statusCode
+	r.ContentType = contentType
+	r.Body = body
+}
@@ -0,0 +1,12 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInvalidKey     = errors.New("неверный ключ идемпотентности")
+	ErrKeyExists      = errors.New("ключ идемпотентности уже использован")
+	ErrKeyReused      = errors.New("ключ идемпотентности использован с другим запросом")
+	ErrInProgress     = errors.New("запрос с этим ключом идемпотентности еще выполняется")
+	ErrAlreadyApplied = errors.New("операция с этим ключом идемпотентности уже выполнена")
+	ErrRecordNotFound = errors.New("ключ идемпотентности не найден")
+)
@@ -0,0 +1,26 @@
+package idempotency
+
+import "time"
+
+// Repository определяет интерфейс для хранения ключей идемпотентности
+// Ключи уникальны в пределах пользователя
+type Repository interface {
+	// Create сохраняет новую запись; ErrKeyExists, если ключ уже есть
+	Create(record *Record) error
+	Get(userID uint, key string) (*Record, error)
+	// TakeOver перехватывает зависшую запись: обновляет LockedAt, только если
+	// она не изменилась с момента чтения. false - запись перехватил другой запрос
+	TakeOver(record *Record, lockedAt time.Time) (bool, error)
+	// MarkApplied отмечает, что операция с ключом зафиксирована
+	// Вызывается внутри единицы работы операции: ErrAlreadyApplied, если отметка уже стоит
+	MarkApplied(userID uint, key string) error
+	// Complete сохраняет ответ на первый запрос
+	Complete(record *Record) error
+	// Delete удаляет запись
+	Delete(userID uint, key string) error
+	// Release удаляет незавершенную запись, если операция с ключом не зафиксирована,
+	// чтобы запрос можно было повторить с тем же ключом
+	Release(userID uint, key string) error
+	// DeleteExpired удаляет записи с истекшим сроком хранения
+	DeleteExpired(now time.Time) (int64, error)
+}
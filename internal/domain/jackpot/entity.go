@@ -0,0 +1,112 @@
+package jackpot
+
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
+// bpsDenominator - число базисных пунктов в единице: 100 б.п. = 1%
+const bpsDenominator = 10000
+
+// Rules задает правила прогрессивного джекпота
+type Rules struct {
+	// ContributionBPS - доля каждой ставки в базисных пунктах, которая идет в пул
+	ContributionBPS int64
+	// Seed - затравка: сумма, с которой пул начинается и к которой возвращается после выигрыша
+	Seed money.Money
+	// FullBet - ставка, с которой выигрывается весь пул. Меньшие ставки получают
+	// пропорциональную часть; нулевое значение отключает пропорцию
+	FullBet money.Money
+}
+
+// Contribution возвращает взнос ставки в пул
+// Доли минорной единицы не переводятся в пул и остаются у казино
+func (r Rules) Contribution(bet money.Money) (money.Money, error) {
+	return bet.CheckedMulRatio(r.ContributionBPS, bpsDenominator, money.RoundDown)
+}
+
+// Pool представляет общий пул прогрессивного джекпота
+// Пул один на валюту: в него идет доля каждой ставки, а выигрыш джекпота
+// выплачивает накопленную сумму и возвращает пул к затравке
+type Pool struct {
+	ID        uint
+	Amount    money.Money
+	UpdatedAt time.Time
+}
+
+// NewPool создает пул, начинающийся с затравки
+func NewPool(seed money.Money) *Pool {
+	return &Pool{Amount: seed}
+}
+
+// Currency возвращает валюту пула
+func (p *Pool) Currency() money.Currency {
+	return p.Amount.Currency()
+}
+
+// Contribute добавляет взнос ставки в пул
+func (p *Pool) Contribute(amount money.Money) error {
+	total, err := p.Amount.Add(amount)
+	if err != nil {
+		return err
+	}
+	p.Amount = total
+	return nil
+}
+
+// Payout представляет выплату джекпота
+type Payout struct {
+	// Amount - сумма, которую получает игрок
+	Amount money.Money
+	// TopUp - сумма, которую казино докладывает в пул, чтобы вернуть его к затравке
+	TopUp money.Money
+}
+
+// Win выплачивает джекпот ставке bet и возвращает пул к затравке
+// Ставка меньше rules.FullBet получает долю пула, пропорциональную ставке,
+// остаток пула сохраняется; пул не опускается ниже затравки
+func (p *Pool) Win(bet money.Money, rules Rules) (Payout, error) {
+	payout := Payout{Amount: p.Amount, TopUp: money.Zero(p.Currency())}
+	if rules.FullBet.IsPositive() && bet.LessThan(rules.FullBet) {
+		share, err := p.Amount.CheckedMulRatio(bet.Amount(), rules.FullBet.Amount(), money.RoundDown)
+		if err != nil {
+			return Payout{}, err
+		}
+		payout.Amount = share
+	}
+
+	remaining, err := p.Amount.Sub(payout.Amount)
+	if err != nil {
+		return Payout{}, err
+	}
+	if remaining.LessThan(rules.Seed) {
+		if payout.TopUp, err = rules.Seed.Sub(remaining); err != nil {
+			return Payout{}, err
+		}
+		remaining = rules.Seed
+	}
+
+	p.Amount = remaining
+	return payout, nil
+}
+
+// Winner представляет запись истории выигрышей джекпота
+type Winner struct {
+	ID        uint
+	UserID    uint
+	RoundID   string
+	BetAmount money.Money
+	Amount    money.Money
+	CreatedAt time.Time
+}
+
+// NewWinner создает запись о выигрыше джекпота
+func NewWinner(userID uint, roundID string, bet money.Money, amount money.Money) *Winner {
+	return &Winner{
+		UserID:    userID,
+		RoundID:   roundID,
+		BetAmount: bet,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+}
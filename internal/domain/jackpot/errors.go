@@ -0,0 +1,8 @@
+package jackpot
+
+import "errors"
+
+var (
+	ErrPoolNotFound = errors.New("пул джекпота не найден")
+	ErrDisabled     = errors.New("джекпот не разыгрывается")
+)
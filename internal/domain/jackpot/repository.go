@@ -0,0 +1,19 @@
+package jackpot
+
+import "gambling/internal/domain/money"
+
+// Repository определяет интерфейс для работы с пулами джекпота и историей выигрышей
+type Repository interface {
+	// Get возвращает пул в валюте currency или ErrPoolNotFound
+	Get(currency money.Currency) (*Pool, error)
+	// GetForUpdate возвращает пул с блокировкой строки до конца транзакции или ErrPoolNotFound
+	// Все изменения пула выполняются под этой блокировкой
+	GetForUpdate(currency money.Currency) (*Pool, error)
+	// Create создает пул, если пула в этой валюте еще нет
+	// Возвращает false, если пул уже создан параллельной транзакцией
+	Create(pool *Pool) (bool, error)
+	Update(pool *Pool) error
+	CreateWinner(winner *Winner) error
+	// ListWinners возвращает последние выигрыши джекпота в валюте currency
+	ListWinners(currency money.Currency, limit int) ([]*Winner, error)
+}
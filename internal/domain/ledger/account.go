@@ -0,0 +1,53 @@
+package ledger
+
+import "fmt"
+
+// AccountType определяет назначение счета в главной книге
+type AccountType string
+
+const (
+	// Счета игрока
+	AccountWallet            AccountType = "wallet"             // Кошелек игрока: его баланс
+	AccountPendingWithdrawal AccountType = "pending_withdrawal" // Средства игрока, зарезервированные по заявкам на вывод
+
+	// Системные счета казино
+	AccountHouse    AccountType = "house"    // Банкролл казино: принятые ставки минус выплаченные выигрыши
+	AccountJackpot  AccountType = "jackpot"  // Пул джекпота
+	AccountBonus    AccountType = "bonus"    // Бонусный пул: через него выплачиваются выигрыши бесплатных спинов
+	AccountExternal AccountType = "external" // Внешний мир: платежные шлюзы, через которые деньги входят и выходят
+)
+
+// Account идентифицирует счет главной книги
+// Счета игрока привязаны к пользователю, у системных счетов UserID равен 0
+type Account struct {
+	Type   AccountType
+	UserID uint
+}
+
+// Wallet возвращает кошелек игрока
+func Wallet(userID uint) Account {
+	return Account{Type: AccountWallet, UserID: userID}
+}
+
+// PendingWithdrawal возвращает счет резерва по заявкам на вывод игрока
+func PendingWithdrawal(userID uint) Account {
+	return Account{Type: AccountPendingWithdrawal, UserID: userID}
+}
+
+// System возвращает системный счет казино
+func System(accountType AccountType) Account {
+	return Account{Type: accountType}
+}
+
+// IsSystem проверяет, является ли счет системным
+func (a Account) IsSystem() bool {
+	return a.UserID == 0
+}
+
+// String возвращает читаемое имя счета, например "wallet:42" или "house"
+func (a Account) String() string {
+	if a.IsSystem() {
+		return string(a.Type)
+	}
+	return fmt.Sprintf("%s:%d", a.Type, a.UserID)
+}
@@ -0,0 +1,50 @@
+package ledger
+
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
+// Entry представляет проводку главной книги
+// Положительная сумма увеличивает остаток счета, отрицательная уменьшает.
+// Проводки одной транзакции всегда в сумме дают ноль: деньги не появляются
+// и не исчезают, а только переходят между счетами
+type Entry struct {
+	ID            uint
+	TransactionID uint
+	Account       Account
+	Amount        money.Money
+	CreatedAt     time.Time
+}
+
+// Validate проверяет, что набор проводок сбалансирован:
+// проводки не пустые, в одной валюте, и их сумма равна нулю
+func Validate(entries []*Entry) error {
+	if len(entries) < 2 {
+		return ErrUnbalanced
+	}
+
+	total := money.Zero(entries[0].Amount.Currency())
+	for _, e := range entries {
+		if e.Amount.IsZero() {
+			return ErrZeroEntry
+		}
+		sum, err := total.Add(e.Amount)
+		if err != nil {
+			return err
+		}
+		total = sum
+	}
+	if !total.IsZero() {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+// transfer возвращает пару проводок, переводящую amount со счета from на счет to
+func transfer(transactionID uint, from, to Account, amount money.Money) []*Entry {
+	return []*Entry{
+		{TransactionID: transactionID, Account: from, Amount: amount.Neg()},
+		{TransactionID: transactionID, Account: to, Amount: amount},
+	}
+}
@@ -0,0 +1,9 @@
+package ledger
+
+import "errors"
+
+var (
+	ErrUnbalanced             = errors.New("проводки не сбалансированы")
+	ErrZeroEntry              = errors.New("проводка с нулевой суммой")
+	ErrUnknownTransactionType = errors.New("тип транзакции не отражается в главной книге")
+)
@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"fmt"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/transaction"
+)
+
+// Postings возвращает проводки, которыми транзакция отражается в главной книге
+// Транзакция остается записью для игрока, а проводки показывают, между какими
+// счетами переместились деньги
+func Postings(tx *transaction.Transaction) ([]*Entry, error) {
+	wallet := Wallet(tx.UserID)
+	pending := PendingWithdrawal(tx.UserID)
+	house := System(AccountHouse)
+	external := System(AccountExternal)
+	jackpot := System(AccountJackpot)
+	bonus := System(AccountBonus)
+
+	var entries []*Entry
+	switch tx.Type {
+	case transaction.TypeDeposit:
+		entries = transfer(tx.ID, external, wallet, tx.Amount)
+	case transaction.TypeSpin:
+		entries = transfer(tx.ID, wallet, house, tx.Amount)
+	case transaction.TypeWin:
+		entries = transfer(tx.ID, house, wallet, tx.Amount)
+	case transaction.TypeJackpotWin:
+		entries = transfer(tx.ID, jackpot, wallet, tx.Amount)
+	case transaction.TypeFreeSpinWin:
+		// Бонусный пул пополняется из банкролла на сумму выплаты, поэтому его остаток
+		// остается нулевым, а оборот показывает, во сколько казино обходятся бонусы
+		entries = append(transfer(tx.ID, house, bonus, tx.Amount), transfer(tx.ID, bonus, wallet, tx.Amount)...)
+	case transaction.TypeWithdrawal:
+		entries = transfer(tx.ID, wallet, pending, tx.Amount)
+	case transaction.TypeWithdrawalPayout:
+		entries = transfer(tx.ID, pending, external, tx.Amount)
+	case transaction.TypeWithdrawalRefund:
+		entries = transfer(tx.ID, pending, wallet, tx.Amount)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransactionType, tx.Type)
+	}
+
+	if err := Validate(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// JackpotFunding возвращает проводки, переводящие amount из банкролла казино в пул джекпота:
+// взнос ставки, затравку нового пула или пополнение пула до затравки после выигрыша
+// Проводки относятся к транзакции игрока, в рамках которой пополняется пул
+func JackpotFunding(transactionID uint, amount money.Money) []*Entry {
+	return transfer(transactionID, System(AccountHouse), System(AccountJackpot), amount)
+}
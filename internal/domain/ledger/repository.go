@@ -0,0 +1,23 @@
+package ledger
+
+import "gambling/internal/domain/money"
+
+// AccountBalance представляет остаток счета, вычисленный по проводкам
+type AccountBalance struct {
+	Account Account
+	Balance money.Money
+}
+
+// Repository определяет интерфейс для работы с главной книгой
+// Проводки только добавляются: исправление ошибки - это новая транзакция, а не правка старой
+type Repository interface {
+	// Post записывает сбалансированный набор проводок
+	Post(entries []*Entry) error
+	// Balance возвращает остаток счета как сумму его проводок
+	Balance(account Account, currency money.Currency) (money.Money, error)
+	// WalletBalances возвращает остатки кошельков всех пользователей по проводкам,
+	// включая пользователей без проводок (с нулевым остатком)
+	WalletBalances() ([]AccountBalance, error)
+	// SystemBalances возвращает остатки системных счетов казино
+	SystemBalances() ([]AccountBalance, error)
+}
@@ -0,0 +1,134 @@
+package limit
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/transaction"
+	"time"
+)
+
+// Usage представляет суммы игрока за окно периода
+type Usage struct {
+	Deposits money.Money
+	Wagers   money.Money
+	Wins     money.Money
+}
+
+// UsageFromTotals собирает Usage из сумм транзакций по типам
+func UsageFromTotals(totals map[transaction.Type]money.Money, currency money.Currency) Usage {
+	usage := Usage{
+		Deposits: money.Zero(currency),
+		Wagers:   money.Zero(currency),
+		Wins:     money.Zero(currency),
+	}
+	if amount, ok := totals[transaction.TypeDeposit]; ok {
+		usage.Deposits = amount
+	}
+	if amount, ok := totals[transaction.TypeSpin]; ok {
+		usage.Wagers = amount
+	}
+	// Выигрыши джекпота и бесплатных спинов уменьшают проигрыш так же, как обычные выигрыши
+	for _, t := range []transaction.Type{transaction.TypeWin, transaction.TypeJackpotWin, transaction.TypeFreeSpinWin} {
+		if amount, ok := totals[t]; ok {
+			if wins, err := usage.Wins.Add(amount); err == nil {
+				usage.Wins = wins
+			}
+		}
+	}
+	return usage
+}
+
+// Loss возвращает чистый проигрыш: ставки минус выигрыши, но не меньше нуля
+func (u Usage) Loss() money.Money {
+	loss, err := u.Wagers.Sub(u.Wins)
+	if err != nil || loss.IsNegative() {
+		return money.Zero(u.Wagers.Currency())
+	}
+	return loss
+}
+
+// Used возвращает использованную часть денежного лимита вида kind
+func (u Usage) Used(kind Kind) money.Money {
+	switch kind {
+	case KindDeposit:
+		return u.Deposits
+	case KindWager:
+		return u.Wagers
+	default:
+		return u.Loss()
+	}
+}
+
+// UsageFunc возвращает суммы игрока за окно, начинающееся в since
+type UsageFunc func(since time.Time) (Usage, error)
+
+// CheckDeposit проверяет, что пополнение на amount не превысит лимиты пополнений
+func CheckDeposit(limits []*Limit, usage UsageFunc, amount money.Money, now time.Time) error {
+	return checkMoney(limits, usage, now, amount, KindDeposit)
+}
+
+// CheckBet проверяет, что ставка bet не превысит лимиты ставок и проигрыша
+// Для лимита проигрыша ставка считается проигранной целиком: игрок не может
+// потерять больше, чем позволяет лимит, даже если раунд окажется проигрышным
+func CheckBet(limits []*Limit, usage UsageFunc, bet money.Money, now time.Time) error {
+	return checkMoney(limits, usage, now, bet, KindWager, KindLoss)
+}
+
+// CheckSession проверяет, что сессия не превысила лимит длительности
+func CheckSession(limits []*Limit, session *PlaySession, now time.Time) error {
+	for _, l := range limits {
+		if l.Kind != KindSession || l.IsRemoved() {
+			continue
+		}
+		if session.Elapsed(now) >= l.SessionDuration() {
+			return &ExceededError{
+				Kind:     KindSession,
+				Period:   PeriodSession,
+				Session:  l.SessionDuration(),
+				ResumeAt: session.LastActivityAt.Add(SessionBreak),
+			}
+		}
+	}
+	return nil
+}
+
+func checkMoney(limits []*Limit, usage UsageFunc, now time.Time, amount money.Money, kinds ...Kind) error {
+	cache := make(map[Period]Usage)
+	for _, l := range limits {
+		if l.IsRemoved() || !containsKind(kinds, l.Kind) {
+			continue
+		}
+		u, ok := cache[l.Period]
+		if !ok {
+			var err error
+			if u, err = usage(now.Add(-l.Period.Duration())); err != nil {
+				return err
+			}
+			cache[l.Period] = u
+		}
+
+		used := u.Used(l.Kind)
+		total, err := used.Add(amount)
+		if err != nil {
+			return err
+		}
+		if l.Amount().LessThan(total) {
+			return &ExceededError{
+				Kind:      l.Kind,
+				Period:    l.Period,
+				Limit:     l.Amount(),
+				Used:      used,
+				Attempted: amount,
+			}
+		}
+	}
+	return nil
+}
+
+func containsKind(kinds []Kind, kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package limit
+
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
+// Kind определяет вид лимита ответственной игры
+type Kind string
+
+const (
+	KindDeposit Kind = "deposit" // Сумма пополнений за период
+	KindLoss    Kind = "loss"    // Чистый проигрыш (ставки минус выигрыши) за период
+	KindWager   Kind = "wager"   // Сумма ставок за период
+	KindSession Kind = "session" // Длительность игровой сессии
+)
+
+// Period определяет окно, за которое считается лимит
+// Окна скользящие: дневной лимит ограничивает суммы за последние 24 часа
+type Period string
+
+const (
+	PeriodDay     Period = "day"
+	PeriodWeek    Period = "week"
+	PeriodMonth   Period = "month"
+	PeriodSession Period = "session" // Единственный период лимита сессии
+)
+
+// Duration возвращает длину окна периода
+func (p Period) Duration() time.Duration {
+	switch p {
+	case PeriodDay:
+		return 24 * time.Hour
+	case PeriodWeek:
+		return 7 * 24 * time.Hour
+	case PeriodMonth:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Limit представляет доменную сущность лимита, установленного игроком
+// Value хранит сумму в минорных единицах для денежных лимитов и секунды для лимита
+// сессии; меньшее значение всегда строже. Ослабление лимита (повышение или
+// снятие) не действует сразу, а ждет в Pending до окончания периода охлаждения
+type Limit struct {
+	ID       uint
+	UserID   uint
+	Kind     Kind
+	Period   Period
+	Value    int64
+	Currency money.Currency // Пусто у лимита сессии
+	Pending  *Change
+	// CreatedAt и UpdatedAt заполняет репозиторий
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Change представляет отложенное ослабление лимита
+// Нулевое Value означает снятие лимита
+type Change struct {
+	Value       int64
+	EffectiveAt time.Time
+}
+
+// NewMoneyLimit создает денежный лимит; новый лимит действует сразу
+func NewMoneyLimit(userID uint, kind Kind, period Period, amount money.Money) (*Limit, error) {
+	if kind == KindSession || !validPeriod(kind, period) {
+		return nil, ErrInvalidLimit
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidValue
+	}
+	return &Limit{
+		UserID:   userID,
+		Kind:     kind,
+		Period:   period,
+		Value:    amount.Amount(),
+		Currency: amount.Currency(),
+	}, nil
+}
+
+// NewSessionLimit создает лимит длительности сессии; новый лимит действует сразу
+func NewSessionLimit(userID uint, duration time.Duration) (*Limit, error) {
+	if duration < time.Minute {
+		return nil, ErrInvalidValue
+	}
+	return &Limit{
+		UserID: userID,
+		Kind:   KindSession,
+		Period: PeriodSession,
+		Value:  int64(duration / time.Second),
+	}, nil
+}
+
+// ParseKind разбирает вид лимита
+func ParseKind(s string) (Kind, error) {
+	switch kind := Kind(s); kind {
+	case KindDeposit, KindLoss, KindWager, KindSession:
+		return kind, nil
+	default:
+		return "", ErrInvalidLimit
+	}
+}
+
+// ParsePeriod разбирает период лимита; у лимита сессии период можно не указывать
+func ParsePeriod(kind Kind, s string) (Period, error) {
+	period := Period(s)
+	if kind == KindSession && period == "" {
+		period = PeriodSession
+	}
+	if !validPeriod(kind, period) {
+		return "", ErrInvalidLimit
+	}
+	return period, nil
+}
+
+func validPeriod(kind Kind, period Period) bool {
+	if kind == KindSession {
+		return period == PeriodSession
+	}
+	return period == PeriodDay || period == PeriodWeek || period == PeriodMonth
+}
+
+// Amount возвращает денежный лимит как сумму
+func (l *Limit) Amount() money.Money {
+	return money.New(l.Value, l.Currency)
+}
+
+// SessionDuration возвращает лимит сессии как длительность
+func (l *Limit) SessionDuration() time.Duration {
+	return time.Duration(l.Value) * time.Second
+}
+
+// Set меняет значение лимита; value равное 0 снимает лимит
+// Ужесточение действует сразу и отменяет ожидающее ослабление. Ослабление
+// откладывается на cooling; повторный запрос того же ослабления не продлевает ожидание
+func (l *Limit) Set(value int64, now time.Time, cooling time.Duration) error {
+	if value < 0 {
+		return ErrInvalidValue
+	}
+	switch {
+	case value == l.Value:
+		l.Pending = nil
+	case value != 0 && value < l.Value:
+		l.Value = value
+		l.Pending = nil
+	case l.Pending != nil && l.Pending.Value == value:
+		// Такое же ослабление уже ожидает
+	default:
+		l.Pending = &Change{Value: value, EffectiveAt: now.Add(cooling)}
+	}
+	return nil
+}
+
+// Apply применяет ожидающее ослабление, если период охлаждения истек
+// Возвращает true, если лимит изменился и его нужно сохранить
+func (l *Limit) Apply(now time.Time) bool {
+	if l.Pending == nil || now.Before(l.Pending.EffectiveAt) {
+		return false
+	}
+	l.Value = l.Pending.Value
+	l.Pending = nil
+	return true
+}
+
+// IsRemoved проверяет, снят ли лимит
+func (l *Limit) IsRemoved() bool {
+	return l.Value == 0
+}
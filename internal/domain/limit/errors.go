@@ -0,0 +1,45 @@
+package limit
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/domain/money"
+	"time"
+)
+
+var (
+	ErrInvalidLimit   = errors.New("неизвестный вид или период лимита")
+	ErrInvalidValue   = errors.New("неверное значение лимита")
+	ErrLimitNotFound  = errors.New("лимит не найден")
+	ErrLimitExceeded  = errors.New("превышен лимит ответственной игры")
+	ErrSessionMissing = errors.New("игровая сессия не найдена")
+)
+
+// ExceededError сообщает, какой лимит не дает выполнить операцию
+// Сравнивается с ErrLimitExceeded через errors.Is
+type ExceededError struct {
+	Kind   Kind
+	Period Period
+	// Limit, Used и Attempted заполняются для денежных лимитов
+	Limit     money.Money
+	Used      money.Money
+	Attempted money.Money
+	// Session и ResumeAt заполняются для лимита сессии: играть можно будет
+	// после перерыва, то есть начиная с ResumeAt
+	Session  time.Duration
+	ResumeAt time.Time
+}
+
+func (e *ExceededError) Error() string {
+	if e.Kind == KindSession {
+		return fmt.Sprintf("превышен лимит сессии %s, игра возможна после %s",
+			e.Session, e.ResumeAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("превышен лимит %s/%s: %s, использовано %s, операция на %s",
+		e.Kind, e.Period, e.Limit, e.Used, e.Attempted)
+}
+
+// Is позволяет проверять ошибку через errors.Is(err, ErrLimitExceeded)
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
@@ -0,0 +1,14 @@
+package limit
+
+// Repository определяет интерфейс для работы с лимитами и игровыми сессиями
+type Repository interface {
+	// GetByUserID возвращает лимиты пользователя, включая ожидающие ослабления
+	GetByUserID(userID uint) ([]*Limit, error)
+	// Save создает лимит или обновляет существующий
+	Save(limit *Limit) error
+	Delete(id uint) error
+	// GetSession возвращает игровую сессию пользователя или ErrSessionMissing
+	GetSession(userID uint) (*PlaySession, error)
+	// SaveSession создает сессию или обновляет существующую
+	SaveSession(session *PlaySession) error
+}
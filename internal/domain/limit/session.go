@@ -0,0 +1,38 @@
+package limit
+
+import "time"
+
+// SessionBreak - перерыв в игре, после которого следующая ставка начинает новую сессию
+const SessionBreak = 30 * time.Minute
+
+// PlaySession представляет непрерывную игровую сессию игрока
+// Сессия начинается с первой ставки и продолжается, пока между ставками
+// проходит меньше SessionBreak
+type PlaySession struct {
+	UserID         uint
+	StartedAt      time.Time
+	LastActivityAt time.Time
+}
+
+// NewPlaySession создает сессию, начатую в момент now
+func NewPlaySession(userID uint, now time.Time) *PlaySession {
+	return &PlaySession{UserID: userID, StartedAt: now, LastActivityAt: now}
+}
+
+// Resume начинает новую сессию, если с последней ставки прошел перерыв
+func (s *PlaySession) Resume(now time.Time) {
+	if now.Sub(s.LastActivityAt) >= SessionBreak {
+		s.StartedAt = now
+		s.LastActivityAt = now
+	}
+}
+
+// Touch отмечает ставку в сессии
+func (s *PlaySession) Touch(now time.Time) {
+	s.LastActivityAt = now
+}
+
+// Elapsed возвращает длительность сессии к моменту now
+func (s *PlaySession) Elapsed(now time.Time) time.Duration {
+	return now.Sub(s.StartedAt)
+}
@@ -0,0 +1,361 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidFormat    = errors.New("неверный формат суммы")
+	ErrTooPrecise       = errors.New("слишком много знаков после запятой")
+	ErrCurrencyMismatch = errors.New("валюты не совпадают")
+	ErrUnknownCurrency  = errors.New("неизвестная валюта")
+	ErrOverflow         = errors.New("сумма слишком велика")
+)
+
+// Currency представляет код валюты ISO 4217
+type Currency string
+
+const (
+	RUB Currency = "RUB"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+)
+
+// DefaultCurrency - валюта, в которой ведутся счета, если не указано иное
+const DefaultCurrency = RUB
+
+// currencies описывает поддерживаемые валюты: число знаков минорной единицы и символ
+var currencies = map[Currency]struct {
+	exponent int
+	symbol   string
+}{
+	RUB: {exponent: 2, symbol: "₽"},
+	USD: {exponent: 2, symbol: "$"},
+	EUR: {exponent: 2, symbol: "€"},
+}
+
+// IsValid проверяет, поддерживается ли валюта
+func (c Currency) IsValid() bool {
+	_, ok := currencies[c]
+	return ok
+}
+
+// Exponent возвращает количество знаков минорной единицы (2 для копеек и центов)
+func (c Currency) Exponent() int {
+	return currencies[c].exponent
+}
+
+// Symbol возвращает символ валюты для отображения
+func (c Currency) Symbol() string {
+	if info, ok := currencies[c]; ok {
+		return info.symbol
+	}
+	return string(c)
+}
+
+// RoundingMode определяет правило округления при умножении на дробный коэффициент
+type RoundingMode int
+
+const (
+	// RoundDown отбрасывает дробную часть минорной единицы (округление к нулю)
+	// Используется для выплат: доли копейки остаются у казино
+	RoundDown RoundingMode = iota
+	// RoundHalfUp округляет половину минорной единицы от нуля
+	RoundHalfUp
+	// RoundHalfEven округляет половину минорной единицы к четному (банковское округление)
+	RoundHalfEven
+)
+
+// Money представляет Value Object денежной суммы
+// Сумма хранится в целых минорных единицах (копейках) вместе с валютой,
+// поэтому арифметика точна и не накапливает ошибок округления float64
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// New создает сумму из минорных единиц
+func New(minor int64, currency Currency) Money {
+	return Money{amount: minor, currency: currency}
+}
+
+// Zero возвращает нулевую сумму в указанной валюте
+func Zero(currency Currency) Money {
+	return Money{currency: currency}
+}
+
+// Parse разбирает десятичную строку вида "100", "100.5" или "-100.50" без потери точности
+// Строки с большим числом знаков после запятой, чем допускает валюта, отклоняются
+func Parse(s string, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, ErrUnknownCurrency
+	}
+
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if intPart == "" || (hasPoint && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, ErrInvalidFormat
+	}
+
+	exp := currency.Exponent()
+	if len(fracPart) > exp {
+		return Money{}, ErrTooPrecise
+	}
+	fracPart += strings.Repeat("0", exp-len(fracPart))
+
+	amount, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// MustParse работает как Parse, но паникует при ошибке
+// Предназначен для констант в коде и конфигурации
+func MustParse(s string, currency Currency) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic(fmt.Sprintf("money: %q: %v", s, err))
+	}
+	return m
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Amount возвращает сумму в минорных единицах
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency возвращает валюту суммы
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero проверяет, равна ли сумма нулю
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive проверяет, больше ли сумма нуля
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative проверяет, меньше ли сумма нуля
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// SameCurrency проверяет, что суммы в одной валюте
+func (m Money) SameCurrency(other Money) bool {
+	return m.currency == other.currency
+}
+
+// Add складывает суммы одной валюты
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.amount + other.amount
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub вычитает сумму той же валюты
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{amount: -other.amount, currency: other.currency})
+}
+
+// Cmp сравнивает суммы одной валюты: -1, если m < other, 0 при равенстве, 1, если m > other
+func (m Money) Cmp(other Money) (int, error) {
+	if !m.SameCurrency(other) {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// LessThan проверяет, что m меньше other
+// Суммы в разных валютах не сравнимы, для них метод возвращает false
+func (m Money) LessThan(other Money) bool {
+	return m.SameCurrency(other) && m.amount < other.amount
+}
+
+// Neg возвращает сумму с противоположным знаком
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// MulRatio умножает сумму на дробь num/den с явным правилом округления
+// Паникует при переполнении, поэтому подходит только там, где результат заведомо
+// не больше исходной суммы; для сумм из запроса используйте CheckedMulRatio
+func (m Money) MulRatio(num, den int64, mode RoundingMode) Money {
+	result, err := m.CheckedMulRatio(num, den, mode)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// CheckedMulRatio умножает сумму на дробь num/den с явным правилом округления
+// Используется для расчета выплат по коэффициентам вроде x1.5
+// Возвращает ErrOverflow, если результат не помещается в int64
+func (m Money) CheckedMulRatio(num, den int64, mode RoundingMode) (Money, error) {
+	if den == 0 {
+		panic("money: zero denominator")
+	}
+	if fitsInt32(m.amount) && fitsInt32(num) && fitsInt32(den) {
+		return Money{amount: mulRatioInt64(m.amount, num, den, mode), currency: m.currency}, nil
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(num))
+	quo, rem := new(big.Int).QuoRem(product, big.NewInt(den), new(big.Int))
+
+	if rem.Sign() != 0 && mode != RoundDown {
+		// Сравниваем удвоенный остаток с делителем, чтобы понять, дальше ли половины
+		twiceRem := new(big.Int).Abs(rem)
+		twiceRem.Lsh(twiceRem, 1)
+		cmp := twiceRem.Cmp(new(big.Int).Abs(big.NewInt(den)))
+
+		roundAway := cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || quo.Bit(0) == 1))
+		if roundAway {
+			if product.Sign()*big.NewInt(den).Sign() < 0 {
+				quo.Sub(quo, big.NewInt(1))
+			} else {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+
+	if !quo.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{amount: quo.Int64(), currency: m.currency}, nil
+}
+
+// mulRatioInt64 - быстрый путь MulRatio без math/big для сомножителей, помещающихся в int32
+// Произведение двух int32 всегда помещается в int64, поэтому переполнения нет
+func mulRatioInt64(amount, num, den int64, mode RoundingMode) int64 {
+	product := amount * num
+	quo, rem := product/den, product%den
+	if rem == 0 || mode == RoundDown {
+		return quo
+	}
+
+	twiceRem, absDen := 2*abs64(rem), abs64(den)
+	roundAway := twiceRem > absDen || (twiceRem == absDen && (mode == RoundHalfUp || quo%2 != 0))
+	if roundAway {
+		if (product < 0) != (den < 0) {
+			return quo - 1
+		}
+		return quo + 1
+	}
+	return quo
+}
+
+func fitsInt32(v int64) bool {
+	return v >= math.MinInt32 && v <= math.MaxInt32
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// String возвращает точное десятичное представление суммы без символа валюты, например "100.50"
+func (m Money) String() string {
+	exp := m.currency.Exponent()
+	if !m.currency.IsValid() {
+		exp = 2
+	}
+
+	sign := ""
+	abs := new(big.Int).Abs(big.NewInt(m.amount)).String()
+	if m.amount < 0 {
+		sign = "-"
+	}
+	if exp == 0 {
+		return sign + abs
+	}
+	if len(abs) <= exp {
+		abs = strings.Repeat("0", exp-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-exp] + "." + abs[len(abs)-exp:]
+}
+
+// Format возвращает сумму с символом валюты для отображения пользователю, например "100.50 ₽"
+func (m Money) Format() string {
+	return m.String() + " " + m.currency.Symbol()
+}
+
+// MarshalJSON сериализует сумму как точную десятичную строку, например "100.50"
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON принимает сумму строкой ("100.50") или числом (100.50)
+// Число разбирается по исходному тексту, без промежуточного float64
+// Валюта берется из уже заполненного значения, иначе используется DefaultCurrency
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return ErrInvalidFormat
+		}
+		raw = number.String()
+	}
+
+	currency := m.currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	parsed, err := Parse(raw, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
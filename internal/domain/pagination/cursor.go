@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLimit - размер страницы, если клиент его не указал
+	DefaultLimit = 20
+	// MaxLimit - максимальный размер страницы
+	MaxLimit = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("неверный курсор страницы")
+	ErrInvalidLimit  = errors.New("неверный размер страницы")
+)
+
+// Cursor указывает на последнюю запись страницы при постраничном обходе по ключу
+// Следующая страница начинается строго после пары (Key, ID) в порядке Sort;
+// ID разрешает совпадения ключа. В отличие от смещения курсор не сдвигается,
+// когда в начало списка добавляются новые записи
+type Cursor struct {
+	Sort string
+	Key  int64
+	ID   uint
+}
+
+// Encode возвращает курсор в виде непрозрачной строки для клиента
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%s:%d:%d", c.Sort, c.Key, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode разбирает курсор, полученный от клиента
+// Курсор действителен только для того же порядка сортировки, в котором был выдан
+func Decode(s, sort string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[0] != sort {
+		return nil, ErrInvalidCursor
+	}
+	key, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Sort: sort, Key: key, ID: uint(id)}, nil
+}
+
+// Limit проверяет размер страницы; 0 означает размер по умолчанию
+func Limit(n int) (int, error) {
+	switch {
+	case n == 0:
+		return DefaultLimit, nil
+	case n < 0 || n > MaxLimit:
+		return 0, ErrInvalidLimit
+	default:
+		return n, nil
+	}
+}
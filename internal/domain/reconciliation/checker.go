@@ -0,0 +1,158 @@
+package reconciliation
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"sort"
+)
+
+// Account содержит данные одного пользователя, которые сверяются между собой
+type Account struct {
+	User *user.User
+	// Transactions - все транзакции пользователя в порядке создания
+	Transactions []*transaction.Transaction
+	Spins        []*spin.Result
+	// LedgerBalance - остаток кошелька пользователя по проводкам главной книги
+	LedgerBalance money.Money
+}
+
+// Check сверяет баланс пользователя с его транзакциями, спинами и главной книгой
+// Каждому спину должна найтись своя транзакция ставки и, для выигрышного спина,
+// транзакция выигрыша (и транзакция джекпота, если спин выиграл джекпот). У бесплатного
+// спина ставка не списывается, а выигрыш выплачивается транзакцией free_spin_win. Спины
+// с идентификатором раунда сопоставляются с транзакциями своего раунда, а спины,
+// сыгранные до появления раундов, - с транзакциями без раунда по суммам
+func Check(acc Account) []*Discrepancy {
+	u := acc.User
+	var found []*Discrepancy
+	add := func(kind Kind, transactionID, spinID uint, expected, actual money.Money) {
+		found = append(found, &Discrepancy{
+			UserID:        u.ID,
+			Kind:          kind,
+			TransactionID: transactionID,
+			SpinID:        spinID,
+			Expected:      expected,
+			Actual:        actual,
+		})
+	}
+
+	// Цепочка балансов начинается с нуля: новый пользователь создается с пустым балансом
+	balance := money.Zero(u.Balance.Currency())
+	for _, tx := range acc.Transactions {
+		if tx.BalanceBefore != balance {
+			add(KindChainBreak, tx.ID, 0, balance, tx.BalanceBefore)
+		}
+		expectedAfter, err := tx.BalanceBefore.Add(tx.BalanceDelta())
+		if err != nil || tx.BalanceAfter != expectedAfter {
+			add(KindAmountMismatch, tx.ID, 0, expectedAfter, tx.BalanceAfter)
+		}
+		balance = tx.BalanceAfter
+	}
+	if balance != u.Balance {
+		add(KindBalanceMismatch, 0, 0, balance, u.Balance)
+	}
+	if acc.LedgerBalance != u.Balance {
+		add(KindLedgerMismatch, 0, 0, acc.LedgerBalance, u.Balance)
+	}
+
+	pools := pendingByRound(acc.Transactions)
+	spins := append([]*spin.Result(nil), acc.Spins...)
+	sort.Slice(spins, func(i, j int) bool { return spins[i].ID < spins[j].ID })
+	for _, s := range spins {
+		pool := pools.of(s.RoundID)
+		if !s.IsFreeSpin() && !pool.take(transaction.TypeSpin, s.BetAmount) {
+			add(KindMissingBet, 0, s.ID, s.BetAmount, money.Zero(s.BetAmount.Currency()))
+		}
+		// Выплата из пула джекпота начисляется отдельной транзакцией
+		win, err := s.WinAmount.Sub(s.JackpotAmount)
+		if err != nil {
+			win = s.WinAmount
+		}
+		if win.IsPositive() && !pool.take(winType(s), win) {
+			add(KindMissingWin, 0, s.ID, win, money.Zero(win.Currency()))
+		}
+		if s.JackpotAmount.IsPositive() && !pool.take(transaction.TypeJackpotWin, s.JackpotAmount) {
+			add(KindMissingWin, 0, s.ID, s.JackpotAmount, money.Zero(s.JackpotAmount.Currency()))
+		}
+	}
+	for _, tx := range pools.left() {
+		kind := KindOrphanBet
+		if tx.Type != transaction.TypeSpin {
+			kind = KindOrphanWin
+		}
+		add(kind, tx.ID, 0, money.Zero(tx.Amount.Currency()), tx.Amount)
+	}
+
+	return found
+}
+
+// winType возвращает тип транзакции, которой выплачивается выигрыш спина по таблице
+func winType(s *spin.Result) transaction.Type {
+	if s.IsFreeSpin() {
+		return transaction.TypeFreeSpinWin
+	}
+	return transaction.TypeWin
+}
+
+// amountKey - тип и сумма транзакции, по которым она сопоставляется со спином
+type amountKey struct {
+	txType transaction.Type
+	amount money.Money
+}
+
+// amountPool - транзакции ставок, выигрышей и джекпотов, еще не сопоставленные со спинами
+type amountPool map[amountKey][]*transaction.Transaction
+
+// roundPools - несопоставленные транзакции по раундам
+// Транзакции без раунда собраны под пустым идентификатором
+type roundPools map[string]amountPool
+
+func pendingByRound(txs []*transaction.Transaction) roundPools {
+	pools := roundPools{}
+	for _, tx := range txs {
+		switch tx.Type {
+		case transaction.TypeSpin, transaction.TypeWin, transaction.TypeJackpotWin, transaction.TypeFreeSpinWin:
+		default:
+			continue
+		}
+		pool := pools.of(tx.RoundID)
+		key := amountKey{txType: tx.Type, amount: tx.Amount}
+		pool[key] = append(pool[key], tx)
+	}
+	return pools
+}
+
+// of возвращает транзакции раунда, создавая пустой набор для нового раунда
+func (p roundPools) of(roundID string) amountPool {
+	pool, ok := p[roundID]
+	if !ok {
+		pool = amountPool{}
+		p[roundID] = pool
+	}
+	return pool
+}
+
+// take сопоставляет со спином самую раннюю транзакцию этого типа на эту сумму
+func (p amountPool) take(txType transaction.Type, amount money.Money) bool {
+	key := amountKey{txType: txType, amount: amount}
+	txs := p[key]
+	if len(txs) == 0 {
+		return false
+	}
+	p[key] = txs[1:]
+	return true
+}
+
+// left возвращает несопоставленные транзакции всех раундов в порядке создания
+func (p roundPools) left() []*transaction.Transaction {
+	var result []*transaction.Transaction
+	for _, pool := range p {
+		for _, txs := range pool {
+			result = append(result, txs...)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
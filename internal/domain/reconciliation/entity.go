@@ -0,0 +1,73 @@
+package reconciliation
+
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
+// Kind определяет вид расхождения
+type Kind string
+
+const (
+	// KindChainBreak - BalanceBefore транзакции не совпадает с BalanceAfter предыдущей
+	KindChainBreak Kind = "chain_break"
+	// KindAmountMismatch - BalanceAfter - BalanceBefore не соответствует сумме и типу транзакции
+	KindAmountMismatch Kind = "amount_mismatch"
+	// KindBalanceMismatch - цепочка транзакций заканчивается не на сохраненном балансе
+	KindBalanceMismatch Kind = "balance_mismatch"
+	// KindLedgerMismatch - сохраненный баланс не совпадает с остатком кошелька в главной книге
+	KindLedgerMismatch Kind = "ledger_mismatch"
+	// KindMissingBet - у спина нет транзакции ставки
+	KindMissingBet Kind = "missing_bet"
+	// KindMissingWin - у выигрышного спина нет транзакции выигрыша
+	KindMissingWin Kind = "missing_win"
+	// KindOrphanBet - транзакция ставки без спина
+	KindOrphanBet Kind = "orphan_bet"
+	// KindOrphanWin - транзакция выигрыша без спина
+	KindOrphanWin Kind = "orphan_win"
+)
+
+// Discrepancy представляет найденное расхождение
+// TransactionID и SpinID равны 0, если расхождение не относится к конкретной записи
+type Discrepancy struct {
+	ID            uint
+	RunID         uint
+	UserID        uint
+	Kind          Kind
+	TransactionID uint
+	SpinID        uint
+	Expected      money.Money
+	Actual        money.Money
+}
+
+// Run представляет запуск сверки и найденные в нем расхождения
+type Run struct {
+	ID            uint
+	StartedAt     time.Time
+	FinishedAt    time.Time
+	UsersChecked  int
+	Discrepancies []*Discrepancy
+}
+
+// NewRun начинает новый запуск сверки
+func NewRun() *Run {
+	return &Run{
+		StartedAt: time.Now(),
+	}
+}
+
+// Record добавляет результат проверки одного пользователя
+func (r *Run) Record(discrepancies []*Discrepancy) {
+	r.UsersChecked++
+	r.Discrepancies = append(r.Discrepancies, discrepancies...)
+}
+
+// Finish завершает запуск сверки
+func (r *Run) Finish() {
+	r.FinishedAt = time.Now()
+}
+
+// IsClean проверяет, что расхождений не найдено
+func (r *Run) IsClean() bool {
+	return len(r.Discrepancies) == 0
+}
@@ -0,0 +1,7 @@
+package reconciliation
+
+import "errors"
+
+var (
+	ErrRunNotFound = errors.New("отчет сверки не найден")
+)
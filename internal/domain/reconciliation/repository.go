@@ -0,0 +1,9 @@
+package reconciliation
+
+// Repository определяет интерфейс для хранения отчетов сверки
+type Repository interface {
+	// Save сохраняет запуск вместе со всеми расхождениями
+	Save(run *Run) error
+	// GetLatest возвращает последний завершенный запуск с расхождениями
+	GetLatest() (*Run, error)
+}
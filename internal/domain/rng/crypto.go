@@ -0,0 +1,25 @@
+package rng
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// CryptoSource - криптографически стойкий источник на основе crypto/rand
+// Используется в production: исход спина невозможно предсказать по предыдущим
+// Безопасен для конкурентного использования
+type CryptoSource struct{}
+
+// NewCryptoSource создает криптографически стойкий источник
+func NewCryptoSource() *CryptoSource {
+	return &CryptoSource{}
+}
+
+// Uint64 возвращает следующее случайное число
+func (CryptoSource) Uint64() uint64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic("rng: crypto/rand недоступен: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(buf[:])
+}
@@ -0,0 +1,29 @@
+package rng
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// SeededSource - детерминированный источник, инициализированный зерном (PCG)
+// Одинаковое зерно дает одинаковую последовательность, поэтому источник
+// используется симулятором и для воспроизведения игровых сессий
+// Безопасен для конкурентного использования
+type SeededSource struct {
+	mu  sync.Mutex
+	pcg *rand.PCG
+}
+
+// NewSeededSource создает детерминированный источник с заданным зерном
+func NewSeededSource(seed uint64) *SeededSource {
+	return &SeededSource{
+		pcg: rand.NewPCG(seed, seed^0x9E3779B97F4A7C15),
+	}
+}
+
+// Uint64 возвращает следующее число последовательности
+func (s *SeededSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pcg.Uint64()
+}
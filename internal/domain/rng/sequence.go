@@ -0,0 +1,72 @@
+package rng
+
+import "sync"
+
+// SequenceSource воспроизводит заранее записанную последовательность чисел
+// Используется для повтора раунда по журналу, записанному Recorder
+// Безопасен для конкурентного использования
+type SequenceSource struct {
+	mu     sync.Mutex
+	values []uint64
+	pos    int
+}
+
+// NewSequenceSource создает источник, возвращающий values по порядку
+func NewSequenceSource(values []uint64) *SequenceSource {
+	return &SequenceSource{
+		values: append([]uint64(nil), values...),
+	}
+}
+
+// Uint64 возвращает следующее записанное число
+// Паникует, если последовательность исчерпана: повтор раунда запросил больше
+// чисел, чем было записано, и его результат уже не совпадет с оригиналом
+func (s *SequenceSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pos >= len(s.values) {
+		panic("rng: записанная последовательность исчерпана")
+	}
+	v := s.values[s.pos]
+	s.pos++
+	return v
+}
+
+// Remaining возвращает количество еще не прочитанных чисел
+func (s *SequenceSource) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.values) - s.pos
+}
+
+// Recorder оборачивает источник и запоминает все выданные им числа
+// Записанную последовательность можно передать в NewSequenceSource для повтора
+// Безопасен для конкурентного использования
+type Recorder struct {
+	mu     sync.Mutex
+	src    Source
+	values []uint64
+}
+
+// NewRecorder создает записывающую обертку над src
+func NewRecorder(src Source) *Recorder {
+	return &Recorder{src: src}
+}
+
+// Uint64 возвращает следующее число источника и записывает его
+func (r *Recorder) Uint64() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v := r.src.Uint64()
+	r.values = append(r.values, v)
+	return v
+}
+
+// Values возвращает копию записанной последовательности
+func (r *Recorder) Values() []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uint64(nil), r.values...)
+}
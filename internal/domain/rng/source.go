@@ -0,0 +1,28 @@
+package rng
+
+// Source представляет источник случайных 64-битных чисел для игрового движка
+// Источник, который разделяется между запросами, обязан быть безопасным
+// для конкурентного использования
+type Source interface {
+	Uint64() uint64
+}
+
+// Intn возвращает равномерно распределенное число из [0, n)
+// В отличие от src.Uint64() % n, не дает смещения в сторону младших значений:
+// числа из неполного последнего интервала отбрасываются и запрашиваются заново
+func Intn(src Source, n int) int {
+	if n <= 0 {
+		panic("rng: Intn: n должно быть положительным")
+	}
+
+	bound := uint64(n)
+	// threshold = 2^64 mod bound: столько младших значений нужно отбросить,
+	// чтобы оставшийся диапазон делился на bound без остатка
+	threshold := -bound % bound
+	for {
+		v := src.Uint64()
+		if v >= threshold {
+			return int(v % bound)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"gambling/internal/domain/user"
+	"time"
+)
+
+// Claims представляет содержимое access токена
+type Claims struct {
+	UserID    uint
+	Role      user.Role
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// TokenSigner определяет порт для выпуска и проверки access токенов
+// Реализация (формат и алгоритм подписи) находится в инфраструктурном слое
+type TokenSigner interface {
+	Sign(claims Claims) (string, error)
+	// Parse проверяет подпись и срок действия токена
+	Parse(token string) (*Claims, error)
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// refreshTokenBytes - длина refresh токена в байтах
+const refreshTokenBytes = 32
+
+// familyIDBytes - длина идентификатора семейства токенов в байтах
+const familyIDBytes = 16
+
+// RefreshToken представляет доменную сущность refresh токена
+// В базе хранится только хеш токена: утечка таблицы не дает доступа к сессиям.
+// Токены одной сессии образуют семейство: при обновлении старый токен отзывается
+// и заменяется новым того же семейства. Повторное предъявление отозванного токена
+// означает его кражу, и тогда отзывается все семейство
+type RefreshToken struct {
+	ID        uint
+	UserID    uint
+	FamilyID  string
+	TokenHash string // SHA-256 от токена, hex
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken создает refresh токен для новой сессии пользователя
+// Возвращает сущность для сохранения и сам токен, который отдается клиенту один раз
+func NewRefreshToken(userID uint, ttl time.Duration) (*RefreshToken, string, error) {
+	familyID, err := randomHex(familyIDBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	return newRefreshToken(userID, familyID, ttl)
+}
+
+// Rotate отзывает токен и выпускает следующий токен того же семейства
+func (t *RefreshToken) Rotate(ttl time.Duration) (*RefreshToken, string, error) {
+	t.Revoke()
+	return newRefreshToken(t.UserID, t.FamilyID, ttl)
+}
+
+// Revoke отзывает токен
+func (t *RefreshToken) Revoke() {
+	if t.RevokedAt != nil {
+		return
+	}
+	now := time.Now()
+	t.RevokedAt = &now
+}
+
+// IsRevoked проверяет, отозван ли токен
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired проверяет, истек ли срок действия токена
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// HashToken вычисляет хеш refresh токена для поиска в хранилище
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newRefreshToken(userID uint, familyID string, ttl time.Duration) (*RefreshToken, string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
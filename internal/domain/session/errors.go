@@ -0,0 +1,9 @@
+package session
+
+import "errors"
+
+var (
+	ErrInvalidToken = errors.New("неверный токен")
+	ErrTokenExpired = errors.New("срок действия токена истек")
+	ErrTokenReused  = errors.New("повторное использование отозванного токена: сессия завершена")
+)
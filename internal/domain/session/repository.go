@@ -0,0 +1,14 @@
+package session
+
+import "time"
+
+// Repository определяет интерфейс для работы с refresh токенами
+type Repository interface {
+	Create(token *RefreshToken) error
+	// GetByHashForUpdate возвращает токен по хешу и блокирует его до конца единицы работы,
+	// чтобы один токен нельзя было обменять дважды параллельными запросами
+	GetByHashForUpdate(tokenHash string) (*RefreshToken, error)
+	Update(token *RefreshToken) error
+	RevokeFamily(familyID string, at time.Time) error
+	RevokeAllByUserID(userID uint, at time.Time) error
+}
@@ -1,32 +1,72 @@
 package spin
 
-import "time"
+import (
+	"encoding/json"
+	"gambling/internal/domain/money"
+	"time"
+)
 
 // Result представляет доменную сущность результата спина
-// Это запись о результате игры пользователя
+// Это запись о раунде любой игры реестра: данные исхода, специфичные для игры,
+// хранятся в Outcome в формате JSON
 type Result struct {
-	ID        uint
-	UserID    uint
-	BetAmount float64
-	WinAmount float64
-	Reel1     int // Символ на первом барабане (0-9)
-	Reel2     int // Символ на втором барабане (0-9)
-	Reel3     int // Символ на третьем барабане (0-9)
-	IsWin     bool
-	CreatedAt time.Time
+	ID     uint
+	UserID uint
+	// GameID - игра, в которой сыгран раунд
+	GameID string
+	// RoundID связывает спин с транзакциями ставки и выигрыша
+	// Пуст у спинов, сыгранных до появления идентификатора раунда
+	RoundID   string
+	BetAmount money.Money
+	WinAmount money.Money
+	// Outcome - исход раунда в формате игры; у классического автомата это ReelsOutcome
+	Outcome json.RawMessage
+	IsWin   bool
+	// JackpotAmount - часть выигрыша, выплаченная из пула джекпота (входит в WinAmount)
+	JackpotAmount money.Money
+	// PaytableVersion - версия математики игры (таблицы выплат), по которой сыгран спин
+	PaytableVersion string
+	// SeedPairID и Nonce указывают, из каких сидов выведен доказуемо честный раунд
+	// Для раундов, сыгранных без provably fair режима, SeedPairID равен 0
+	SeedPairID uint
+	Nonce      uint64
+	// TriggerSpinID - спин, запустивший бонус, у бесплатного спина (0 у платного раунда)
+	// Бесплатный спин играется на BetAmount без списания ставки
+	TriggerSpinID uint
+	// WinMultiplier - коэффициент бонуса, на который умножена выплата бесплатного спина
+	WinMultiplier Multiplier
+	CreatedAt     time.Time
 }
 
 // NewResult создает новый результат спина
-func NewResult(userID uint, betAmount, winAmount float64, reel1, reel2, reel3 int) *Result {
+func NewResult(userID uint, gameID, roundID string, betAmount, winAmount money.Money, outcome json.RawMessage, paytableVersion string) *Result {
 	return &Result{
-		UserID:    userID,
-		BetAmount: betAmount,
-		WinAmount: winAmount,
-		Reel1:     reel1,
-		Reel2:     reel2,
-		Reel3:     reel3,
-		IsWin:     winAmount > 0,
-		CreatedAt: time.Now(),
+		UserID:          userID,
+		GameID:          gameID,
+		RoundID:         roundID,
+		BetAmount:       betAmount,
+		WinAmount:       winAmount,
+		Outcome:         outcome,
+		IsWin:           winAmount.IsPositive(),
+		JackpotAmount:   money.Zero(betAmount.Currency()),
+		PaytableVersion: paytableVersion,
+		CreatedAt:       time.Now(),
 	}
 }
 
+// Reels возвращает символы на барабанах, если раунд сыгран в классическом автомате
+func (r *Result) Reels() ([ReelCount]int, bool) {
+	if r.GameID != ClassicGameID {
+		return [ReelCount]int{}, false
+	}
+	reels, err := DecodeReels(r.Outcome)
+	if err != nil {
+		return [ReelCount]int{}, false
+	}
+	return reels, true
+}
+
+// IsFreeSpin проверяет, сыгран ли раунд как бесплатный спин бонуса
+func (r *Result) IsFreeSpin() bool {
+	return r.TriggerSpinID != 0
+}
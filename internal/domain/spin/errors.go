@@ -0,0 +1,9 @@
+package spin
+
+import "errors"
+
+var (
+	ErrResultNotFound  = errors.New("результат спина не найден")
+	ErrInvalidOutcome  = errors.New("неизвестный исход спина")
+	ErrInvalidBetRange = errors.New("минимальная ставка больше максимальной")
+)
@@ -0,0 +1,123 @@
+package spin
+
+import (
+	"encoding/json"
+	"fmt"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
+)
+
+// ClassicGameID - идентификатор классического трехбарабанного автомата
+const ClassicGameID = "classic"
+
+// ReelsOutcome - данные исхода раунда классического автомата
+type ReelsOutcome struct {
+	Reels [ReelCount]int `json:"reels"`
+	// Scatter - выплата и бонус за scatter (нет в таблицах без scatter)
+	Scatter *ScatterWin `json:"scatter,omitempty"`
+}
+
+// DecodeClassicOutcome возвращает данные исхода классического автомата
+func DecodeClassicOutcome(data json.RawMessage) (*ReelsOutcome, error) {
+	var outcome ReelsOutcome
+	if err := json.Unmarshal(data, &outcome); err != nil {
+		return nil, fmt.Errorf("failed to decode reels: %w", err)
+	}
+	return &outcome, nil
+}
+
+// DecodeReels возвращает символы на барабанах из данных исхода классического автомата
+func DecodeReels(data json.RawMessage) ([ReelCount]int, error) {
+	outcome, err := DecodeClassicOutcome(data)
+	if err != nil {
+		return [ReelCount]int{}, err
+	}
+	return outcome.Reels, nil
+}
+
+// Service реализует game.Game: классический автомат - первая игра реестра
+var _ game.Game = (*Service)(nil)
+
+// ID возвращает идентификатор игры
+func (s *Service) ID() string {
+	return ClassicGameID
+}
+
+// Name возвращает название игры
+func (s *Service) Name() string {
+	return "Классический автомат"
+}
+
+// Version возвращает версию таблицы выплат
+func (s *Service) Version() string {
+	return s.paytable.Version
+}
+
+// HasJackpot сообщает, есть ли джекпот в таблице выплат
+func (s *Service) HasJackpot() bool {
+	return s.paytable.Jackpot != nil
+}
+
+// ValidateBet проверяет ставку: классический автомат принимает любую положительную ставку
+func (s *Service) ValidateBet(bet money.Money) error {
+	if !bet.IsPositive() {
+		return game.ErrInvalidBet
+	}
+	return nil
+}
+
+// Play генерирует символы из src и вычисляет выигрыш по таблице выплат
+func (s *Service) Play(src rng.Source, bet money.Money) (*game.Outcome, error) {
+	reels := s.GenerateReelsFrom(src)
+	scatter, err := s.ScatterWin(reels[0], reels[1], reels[2], bet)
+	if err != nil {
+		return nil, err
+	}
+	win, err := s.CalculateWin(reels[0], reels[1], reels[2], bet)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ReelsOutcome{Reels: reels, Scatter: scatter})
+	if err != nil {
+		return nil, err
+	}
+	return &game.Outcome{
+		Data:      data,
+		Payout:    win,
+		Jackpot:   s.IsJackpot(reels[0], reels[1], reels[2]),
+		FreeSpins: s.paytable.Scatter.Award(scatter),
+	}, nil
+}
+
+// Payout разыгрывает раунд и возвращает исход без данных (Data пуст)
+// Используется симулятором: символы генерируются из src так же, как в Play
+func (s *Service) Payout(src rng.Source, bet money.Money) (game.Outcome, error) {
+	reels := s.GenerateReelsFrom(src)
+	win, err := s.CalculateWin(reels[0], reels[1], reels[2], bet)
+	if err != nil {
+		return game.Outcome{}, err
+	}
+	scatter, err := s.ScatterWin(reels[0], reels[1], reels[2], bet)
+	if err != nil {
+		return game.Outcome{}, err
+	}
+	return game.Outcome{
+		Payout:    win,
+		Jackpot:   s.IsJackpot(reels[0], reels[1], reels[2]),
+		FreeSpins: s.paytable.Scatter.Award(scatter),
+	}, nil
+}
+
+// EmbeddedGames возвращает игры по всем встроенным версиям таблиц выплат
+// Регистрируются в реестре как прежние версии, чтобы проверять раунды, сыгранные до смены таблицы
+func EmbeddedGames() []game.Game {
+	var games []game.Game
+	for _, p := range EmbeddedPaytables() {
+		games = append(games, NewService(p, rng.NewCryptoSource()))
+	}
+	for _, p := range EmbeddedVideoPaytables() {
+		games = append(games, NewVideoService(p))
+	}
+	return games
+}
@@ -0,0 +1,120 @@
+package spin
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMultiplier = errors.New("неверный коэффициент выплаты")
+
+// maxMultiplierDecimals ограничивает точность коэффициента в таблице выплат
+const maxMultiplierDecimals = 6
+
+// Multiplier представляет Value Object коэффициента выплаты в виде точной дроби num/den
+// Дробь позволяет задавать коэффициенты вроде x1.5 без ошибок округления float64
+type Multiplier struct {
+	num int64
+	den int64
+}
+
+// NewMultiplier создает коэффициент num/den
+func NewMultiplier(num, den int64) (Multiplier, error) {
+	if num <= 0 || den <= 0 {
+		return Multiplier{}, ErrInvalidMultiplier
+	}
+	g := gcd(num, den)
+	return Multiplier{num: num / g, den: den / g}, nil
+}
+
+// ParseMultiplier разбирает десятичную запись коэффициента, например "1000" или "1.5"
+func ParseMultiplier(s string) (Multiplier, error) {
+	intPart, fracPart, hasPoint := strings.Cut(strings.TrimSpace(s), ".")
+	if intPart == "" || (hasPoint && fracPart == "") || len(fracPart) > maxMultiplierDecimals {
+		return Multiplier{}, ErrInvalidMultiplier
+	}
+
+	num, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Multiplier{}, ErrInvalidMultiplier
+	}
+	den := int64(1)
+	for range fracPart {
+		den *= 10
+	}
+
+	return NewMultiplier(num, den)
+}
+
+// Num возвращает числитель коэффициента
+func (m Multiplier) Num() int64 {
+	return m.num
+}
+
+// Den возвращает знаменатель коэффициента
+func (m Multiplier) Den() int64 {
+	return m.den
+}
+
+// IsZero проверяет, что коэффициент не задан
+func (m Multiplier) IsZero() bool {
+	return m.num == 0
+}
+
+// Less проверяет, что коэффициент меньше other; незаданный коэффициент меньше любого заданного
+func (m Multiplier) Less(other Multiplier) bool {
+	return m.Rat().Cmp(other.Rat()) < 0
+}
+
+// Rat возвращает коэффициент как точное рациональное число
+func (m Multiplier) Rat() *big.Rat {
+	if m.den == 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(m.num, m.den)
+}
+
+// String возвращает десятичную запись коэффициента, например "1.5"
+func (m Multiplier) String() string {
+	if m.den == 0 {
+		return "0"
+	}
+	return strings.TrimSuffix(strings.TrimRight(m.Rat().FloatString(maxMultiplierDecimals), "0"), ".")
+}
+
+// MarshalJSON сериализует коэффициент числом
+func (m Multiplier) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON принимает коэффициент числом (1.5) или строкой ("1.5")
+func (m *Multiplier) UnmarshalJSON(data []byte) error {
+	var raw string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	} else {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return ErrInvalidMultiplier
+		}
+		raw = number.String()
+	}
+
+	parsed, err := ParseMultiplier(raw)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,358 @@
+package spin
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"sort"
+)
+
+var ErrInvalidPaytable = errors.New("неверная таблица выплат")
+
+// Встраиваются все версии таблиц: по прежним версиям проверяются раунды, сыгранные до смены таблицы
+//
+//go:embed paytables/*.json
+var defaultPaytables embed.FS
+
+// defaultPaytableFile - таблица выплат, используемая, если файл конфигурации не задан
+const defaultPaytableFile = "paytables/classic-3.json"
+
+// embeddedPaytablesPattern - все встроенные версии таблиц классического автомата
+const embeddedPaytablesPattern = "paytables/classic-*.json"
+
+// ReelCount - количество барабанов классического автомата
+const ReelCount = 3
+
+// Paytable описывает математику автомата: символы с весами и правила выплат
+// Таблица версионируется, и каждый результат спина хранит версию, по которой он сыгран
+type Paytable struct {
+	Version   string           `json:"version"`
+	Symbols   []SymbolWeight   `json:"symbols"`
+	Triples   []SymbolPayout   `json:"triples"`
+	Pairs     []SymbolPayout   `json:"pairs"`
+	Sequences []SequencePayout `json:"sequences"`
+	// Jackpot - комбинация, выигрывающая прогрессивный джекпот (nil - без джекпота)
+	Jackpot *JackpotRule `json:"jackpot,omitempty"`
+	// Wild - символ, заменяющий другие в тройках и парах (nil - без wild)
+	Wild *WildRule `json:"wild,omitempty"`
+	// Scatter - символ, который платит на любом барабане и запускает бесплатные спины (nil - без scatter)
+	Scatter *ScatterRule `json:"scatter,omitempty"`
+
+	// Производные данные, вычисляемые при валидации
+	cumulative  []int
+	totalWeight int
+	triples     map[int]Multiplier
+	pairs       map[int]Multiplier
+}
+
+// SymbolWeight задает вес символа на барабане
+// Вероятность выпадения символа равна его весу, деленному на сумму всех весов
+type SymbolWeight struct {
+	Symbol int `json:"symbol"`
+	Weight int `json:"weight"`
+}
+
+// SymbolPayout задает коэффициент выплаты для комбинации из одинаковых символов
+type SymbolPayout struct {
+	Symbol     int        `json:"symbol"`
+	Multiplier Multiplier `json:"multiplier"`
+}
+
+// SequencePayout задает коэффициент выплаты за точную последовательность символов
+type SequencePayout struct {
+	Reels      [ReelCount]int `json:"reels"`
+	Multiplier Multiplier     `json:"multiplier"`
+}
+
+// JackpotRule задает комбинацию прогрессивного джекпота: три символа Symbol
+// Сумму выигрыша определяет пул, а не таблица, поэтому у символа не должно
+// быть фиксированной выплаты за три одинаковых
+type JackpotRule struct {
+	Symbol int `json:"symbol"`
+}
+
+// ParsePaytable разбирает таблицу выплат из JSON и проверяет ее корректность
+func ParsePaytable(data []byte) (*Paytable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var p Paytable
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPaytable, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DefaultPaytable возвращает встроенную таблицу выплат классического автомата
+func DefaultPaytable() *Paytable {
+	data, err := defaultPaytables.ReadFile(defaultPaytableFile)
+	if err != nil {
+		panic("failed to read default paytable: " + err.Error())
+	}
+	p, err := ParsePaytable(data)
+	if err != nil {
+		panic("failed to parse default paytable: " + err.Error())
+	}
+	return p
+}
+
+// EmbeddedPaytables возвращает все встроенные версии таблиц классического автомата,
+// включая прежние, упорядоченные по имени файла
+func EmbeddedPaytables() []*Paytable {
+	files, err := fs.Glob(defaultPaytables, embeddedPaytablesPattern)
+	if err != nil {
+		panic("failed to list embedded paytables: " + err.Error())
+	}
+	result := make([]*Paytable, 0, len(files))
+	for _, file := range files {
+		data, err := defaultPaytables.ReadFile(file)
+		if err != nil {
+			panic("failed to read embedded paytable: " + err.Error())
+		}
+		p, err := ParsePaytable(data)
+		if err != nil {
+			panic("failed to parse embedded paytable " + file + ": " + err.Error())
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// Validate проверяет таблицу выплат и подготавливает производные данные
+func (p *Paytable) Validate() error {
+	if p.Version == "" {
+		return fmt.Errorf("%w: не указана версия", ErrInvalidPaytable)
+	}
+	if len(p.Symbols) == 0 {
+		return fmt.Errorf("%w: не указаны символы", ErrInvalidPaytable)
+	}
+
+	known := make(map[int]bool, len(p.Symbols))
+	p.cumulative = make([]int, len(p.Symbols))
+	p.totalWeight = 0
+	for i, sw := range p.Symbols {
+		if known[sw.Symbol] {
+			return fmt.Errorf("%w: символ %d указан дважды", ErrInvalidPaytable, sw.Symbol)
+		}
+		if sw.Weight <= 0 {
+			return fmt.Errorf("%w: вес символа %d должен быть положительным", ErrInvalidPaytable, sw.Symbol)
+		}
+		if p.totalWeight > math.MaxInt32-sw.Weight {
+			return fmt.Errorf("%w: сумма весов слишком велика", ErrInvalidPaytable)
+		}
+		known[sw.Symbol] = true
+		p.totalWeight += sw.Weight
+		p.cumulative[i] = p.totalWeight
+	}
+
+	var err error
+	if p.triples, err = buildPayouts("triples", p.Triples, known); err != nil {
+		return err
+	}
+	if p.pairs, err = buildPayouts("pairs", p.Pairs, known); err != nil {
+		return err
+	}
+
+	seen := make(map[[ReelCount]int]bool, len(p.Sequences))
+	for _, seq := range p.Sequences {
+		for _, symbol := range seq.Reels {
+			if !known[symbol] {
+				return fmt.Errorf("%w: sequences: неизвестный символ %d", ErrInvalidPaytable, symbol)
+			}
+		}
+		if seq.Reels[0] == seq.Reels[1] || seq.Reels[1] == seq.Reels[2] || seq.Reels[0] == seq.Reels[2] {
+			// Такая последовательность никогда не сработает: комбинацию перехватят тройки или пары
+			return fmt.Errorf("%w: sequences: последовательность %v содержит повторяющиеся символы", ErrInvalidPaytable, seq.Reels)
+		}
+		if seen[seq.Reels] {
+			return fmt.Errorf("%w: sequences: последовательность %v указана дважды", ErrInvalidPaytable, seq.Reels)
+		}
+		if seq.Multiplier.IsZero() {
+			return fmt.Errorf("%w: sequences: не указан коэффициент для %v", ErrInvalidPaytable, seq.Reels)
+		}
+		seen[seq.Reels] = true
+	}
+
+	if p.Jackpot != nil {
+		if !known[p.Jackpot.Symbol] {
+			return fmt.Errorf("%w: jackpot: неизвестный символ %d", ErrInvalidPaytable, p.Jackpot.Symbol)
+		}
+		if _, exists := p.triples[p.Jackpot.Symbol]; exists {
+			return fmt.Errorf("%w: jackpot: у символа %d есть фиксированная выплата за три одинаковых", ErrInvalidPaytable, p.Jackpot.Symbol)
+		}
+	}
+
+	return p.validateRoles()
+}
+
+// validateRoles проверяет, что wild и scatter не участвуют в правилах,
+// где их роль не определена: scatter платит только сам по себе, а wild не заменяет
+// символы последовательностей и джекпота
+func (p *Paytable) validateRoles() error {
+	known := make(map[int]bool, len(p.Symbols))
+	for _, sw := range p.Symbols {
+		known[sw.Symbol] = true
+	}
+	if err := validateSymbolRoles(p.Wild, p.Scatter, known, ReelCount); err != nil {
+		return err
+	}
+
+	special := make(map[int]string, 2)
+	if p.Wild != nil {
+		special[p.Wild.Symbol] = "wild"
+	}
+	if p.Scatter != nil {
+		special[p.Scatter.Symbol] = "scatter"
+		if _, exists := p.triples[p.Scatter.Symbol]; exists {
+			return fmt.Errorf("%w: triples: символ scatter %d платит только как scatter", ErrInvalidPaytable, p.Scatter.Symbol)
+		}
+		if _, exists := p.pairs[p.Scatter.Symbol]; exists {
+			return fmt.Errorf("%w: pairs: символ scatter %d платит только как scatter", ErrInvalidPaytable, p.Scatter.Symbol)
+		}
+	}
+	for _, seq := range p.Sequences {
+		for _, symbol := range seq.Reels {
+			if role, ok := special[symbol]; ok {
+				return fmt.Errorf("%w: sequences: символ %s %d не может входить в последовательность", ErrInvalidPaytable, role, symbol)
+			}
+		}
+	}
+	if p.Jackpot != nil {
+		if role, ok := special[p.Jackpot.Symbol]; ok {
+			return fmt.Errorf("%w: jackpot: символ %s %d не может быть символом джекпота", ErrInvalidPaytable, role, p.Jackpot.Symbol)
+		}
+	}
+	return nil
+}
+
+func buildPayouts(section string, payouts []SymbolPayout, known map[int]bool) (map[int]Multiplier, error) {
+	result := make(map[int]Multiplier, len(payouts))
+	for _, sp := range payouts {
+		if !known[sp.Symbol] {
+			return nil, fmt.Errorf("%w: %s: неизвестный символ %d", ErrInvalidPaytable, section, sp.Symbol)
+		}
+		if _, exists := result[sp.Symbol]; exists {
+			return nil, fmt.Errorf("%w: %s: символ %d указан дважды", ErrInvalidPaytable, section, sp.Symbol)
+		}
+		if sp.Multiplier.IsZero() {
+			return nil, fmt.Errorf("%w: %s: не указан коэффициент для символа %d", ErrInvalidPaytable, section, sp.Symbol)
+		}
+		result[sp.Symbol] = sp.Multiplier
+	}
+	return result, nil
+}
+
+// TotalWeight возвращает сумму весов всех символов
+func (p *Paytable) TotalWeight() int {
+	return p.totalWeight
+}
+
+// SymbolAt возвращает символ, соответствующий числу roll из диапазона [0, TotalWeight)
+func (p *Paytable) SymbolAt(roll int) int {
+	i := sort.Search(len(p.cumulative), func(i int) bool {
+		return roll < p.cumulative[i]
+	})
+	return p.Symbols[i].Symbol
+}
+
+// Evaluate возвращает коэффициент выплаты для комбинации символов
+// Правила проверяются по порядку: три одинаковых, два одинаковых, последовательность.
+// Комбинация с wild оценивается отдельно, см. evaluateWild. Выплата scatter сюда не входит.
+// Второе значение равно false, если комбинация не выигрышная
+func (p *Paytable) Evaluate(reel1, reel2, reel3 int) (Multiplier, bool) {
+	if p.Wild != nil && (reel1 == p.Wild.Symbol || reel2 == p.Wild.Symbol || reel3 == p.Wild.Symbol) {
+		return p.evaluateWild([ReelCount]int{reel1, reel2, reel3})
+	}
+
+	// Три одинаковых символа
+	if reel1 == reel2 && reel2 == reel3 {
+		m, ok := p.triples[reel1]
+		return m, ok
+	}
+
+	// Два одинаковых символа
+	if reel1 == reel2 || reel2 == reel3 || reel1 == reel3 {
+		symbol := reel3
+		if reel1 == reel2 || reel1 == reel3 {
+			symbol = reel1
+		}
+		m, ok := p.pairs[symbol]
+		return m, ok
+	}
+
+	// Последовательность
+	for _, seq := range p.Sequences {
+		if seq.Reels == [ReelCount]int{reel1, reel2, reel3} {
+			return seq.Multiplier, true
+		}
+	}
+
+	return Multiplier{}, false
+}
+
+// evaluateWild оценивает комбинацию, в которой есть wild
+// Wild дополняет до тройки или пары любой символ, кроме scatter, и из всех
+// возможных троек и пар засчитывается самая дорогая. Три wild платят как тройка
+// wild, если она есть в таблице. В последовательностях wild не участвует
+func (p *Paytable) evaluateWild(reels [ReelCount]int) (Multiplier, bool) {
+	wild := p.Wild.Symbol
+	wilds := 0
+	for _, symbol := range reels {
+		if symbol == wild {
+			wilds++
+		}
+	}
+
+	var best Multiplier
+	consider := func(symbol, count int) {
+		if m, ok := p.triples[symbol]; ok && count == ReelCount && best.Less(m) {
+			best = m
+		}
+		if m, ok := p.pairs[symbol]; ok && count >= 2 && best.Less(m) {
+			best = m
+		}
+	}
+
+	consider(wild, wilds)
+	for _, symbol := range reels {
+		if symbol == wild || (p.Scatter != nil && symbol == p.Scatter.Symbol) {
+			continue
+		}
+		count := wilds
+		for _, other := range reels {
+			if other == symbol {
+				count++
+			}
+		}
+		consider(symbol, count)
+	}
+	return best, !best.IsZero()
+}
+
+// ScatterCount возвращает число символов scatter на барабанах (0 - scatter в таблице нет)
+func (p *Paytable) ScatterCount(reel1, reel2, reel3 int) int {
+	if p.Scatter == nil {
+		return 0
+	}
+	count := 0
+	for _, symbol := range [ReelCount]int{reel1, reel2, reel3} {
+		if symbol == p.Scatter.Symbol {
+			count++
+		}
+	}
+	return count
+}
+
+// IsJackpot проверяет, выигрывает ли комбинация прогрессивный джекпот
+// Wild символ джекпота не заменяет
+func (p *Paytable) IsJackpot(reel1, reel2, reel3 int) bool {
+	return p.Jackpot != nil &&
+		reel1 == p.Jackpot.Symbol && reel2 == p.Jackpot.Symbol && reel3 == p.Jackpot.Symbol
+}
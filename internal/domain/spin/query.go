@@ -0,0 +1,61 @@
+package spin
+
+import (
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/pagination"
+	"time"
+)
+
+// Outcome определяет фильтр истории спинов по исходу
+type Outcome string
+
+const (
+	OutcomeAll  Outcome = ""     // Все спины
+	OutcomeWin  Outcome = "win"  // Только выигрышные
+	OutcomeLoss Outcome = "loss" // Только проигрышные
+)
+
+// historySort - единственный порядок истории спинов: сначала новые
+const historySort = "newest"
+
+// ParseOutcome разбирает фильтр по исходу; пустая строка - все спины
+func ParseOutcome(s string) (Outcome, error) {
+	switch outcome := Outcome(s); outcome {
+	case OutcomeAll, OutcomeWin, OutcomeLoss:
+		return outcome, nil
+	default:
+		return "", ErrInvalidOutcome
+	}
+}
+
+// Query описывает выборку страницы истории спинов пользователя, начиная с последних
+type Query struct {
+	UserID  uint
+	Outcome Outcome
+	// MinBet и MaxBet ограничивают ставку включительно; нулевая сумма - без границы
+	MinBet money.Money
+	MaxBet money.Money
+	// From и To ограничивают время спина: From включительно, To не включительно
+	From time.Time
+	To   time.Time
+	// After - курсор последнего спина предыдущей страницы; nil - первая страница
+	After *pagination.Cursor
+	Limit int
+}
+
+// Page представляет страницу истории спинов
+// Next равен nil на последней странице
+type Page struct {
+	Results []*Result
+	Next    *pagination.Cursor
+}
+
+// Cursor возвращает курсор, указывающий на результат спина в истории
+func (r *Result) Cursor() pagination.Cursor {
+	return pagination.Cursor{Sort: historySort, Key: r.CreatedAt.UnixMicro(), ID: r.ID}
+}
+
+// DecodeCursor разбирает курсор истории спинов, полученный от клиента
+func DecodeCursor(s string) (*pagination.Cursor, error) {
+	return pagination.Decode(s, historySort)
+}
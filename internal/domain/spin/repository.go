@@ -3,6 +3,8 @@ package spin
 // Repository определяет интерфейс для работы с результатами спинов
 type Repository interface {
 	Create(result *Result) error
+	GetByID(id uint) (*Result, error)
 	GetByUserID(userID uint, limit int) ([]*Result, error)
+	// Find возвращает страницу истории спинов по объекту запроса
+	Find(query Query) (*Page, error)
 }
-
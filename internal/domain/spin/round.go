@@ -0,0 +1,18 @@
+package spin
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// NewRoundID создает идентификатор раунда - случайный UUID версии 4
+// Идентификатор раунда связывает результат спина с транзакциями ставки и выигрыша
+func NewRoundID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate round id: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40 // Версия 4
+	b[8] = b[8]&0x3f | 0x80 // Вариант RFC 4122
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
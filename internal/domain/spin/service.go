@@ -1,124 +1,102 @@
 package spin
 
 import (
-	"math/rand"
-	"time"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
 )
 
 // Service представляет доменный сервис для логики игры
 // Доменные сервисы содержат бизнес-логику, которая не принадлежит конкретной сущности
-// В данном случае - генерация символов и расчет выигрыша
+// В данном случае - генерация символов и расчет выигрыша по таблице выплат
 type Service struct {
-	rng *rand.Rand
+	src      rng.Source
+	paytable *Paytable
 }
 
 // NewService создает новый доменный сервис для спинов
-func NewService() *Service {
+// src должен быть безопасен для конкурентного использования: сервис один на все запросы.
+// В production используется rng.NewCryptoSource, в симуляции и при повторе -
+// детерминированные источники
+func NewService(paytable *Paytable, src rng.Source) *Service {
 	return &Service{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		src:      src,
+		paytable: paytable,
 	}
 }
 
-// GenerateSymbol генерирует символ с правильным распределением вероятностей
-// Вероятности настроены так, чтобы обеспечить RTP (Return to Player) ~95%
-// Символы: 0 (самый редкий), 1-9 (обычные)
+// Paytable возвращает таблицу выплат, по которой работает сервис
+func (s *Service) Paytable() *Paytable {
+	return s.paytable
+}
+
+// GenerateSymbol генерирует символ с распределением вероятностей из таблицы выплат
+// Вероятность символа равна его весу, деленному на сумму всех весов
 func (s *Service) GenerateSymbol() int {
-	// Генерируем случайное число от 0 до 9999
-	roll := s.rng.Intn(10000)
+	return s.GenerateSymbolFrom(s.src)
+}
 
-	// Распределение вероятностей:
-	// 0: 0.5% (50 из 10000) - диапазон 0-49
-	// 1: 5% (500 из 10000) - диапазон 50-549
-	// 2: 5% (500 из 10000) - диапазон 550-1049
-	// 3: 5% (500 из 10000) - диапазон 1050-1549
-	// 4: 10% (1000 из 10000) - диапазон 1550-2549
-	// 5: 10% (1000 из 10000) - диапазон 2550-3549
-	// 6: 10% (1000 из 10000) - диапазон 3550-4549
-	// 7: 20% (2000 из 10000) - диапазон 4550-6549
-	// 8: 20% (2000 из 10000) - диапазон 6550-8549
-	// 9: 20% (2000 из 10000) - диапазон 8550-9999
+// GenerateReels генерирует символы всех барабанов из источника сервиса
+func (s *Service) GenerateReels() [ReelCount]int {
+	return s.GenerateReelsFrom(s.src)
+}
 
-	if roll < 50 {
-		return 0 // 0.5%
-	} else if roll < 550 {
-		return 1 // 5%
-	} else if roll < 1050 {
-		return 2 // 5%
-	} else if roll < 1550 {
-		return 3 // 5%
-	} else if roll < 2550 {
-		return 4 // 10%
-	} else if roll < 3550 {
-		return 5 // 10%
-	} else if roll < 4550 {
-		return 6 // 10%
-	} else if roll < 6550 {
-		return 7 // 20%
-	} else if roll < 8550 {
-		return 8 // 20%
-	} else {
-		return 9 // 20%
-	}
+// GenerateSymbolFrom генерирует символ по числу из внешнего источника
+// Результат полностью определяется источником, что позволяет воспроизвести раунд
+func (s *Service) GenerateSymbolFrom(src rng.Source) int {
+	roll := rng.Intn(src, s.paytable.TotalWeight())
+	return s.paytable.SymbolAt(roll)
 }
 
-// CalculateWin вычисляет выигрыш на основе комбинации символов
-// Коэффициенты выплат настроены для обеспечения RTP ~95%
-func (s *Service) CalculateWin(reel1, reel2, reel3 int, betAmount float64) float64 {
-	// Три одинаковых символа (джекпот)
-	if reel1 == reel2 && reel2 == reel3 {
-		switch reel1 {
-		case 0:
-			return betAmount * 1000 // Джекпот для тройки нулей
-		case 1:
-			return betAmount * 50
-		case 2:
-			return betAmount * 50
-		case 3:
-			return betAmount * 50
-		case 4:
-			return betAmount * 20
-		case 5:
-			return betAmount * 20
-		case 6:
-			return betAmount * 20
-		case 7:
-			return betAmount * 10
-		case 8:
-			return betAmount * 10
-		case 9:
-			return betAmount * 10
-		}
+// GenerateReelsFrom генерирует символы всех барабанов из внешнего источника
+func (s *Service) GenerateReelsFrom(src rng.Source) [ReelCount]int {
+	var reels [ReelCount]int
+	for i := range reels {
+		reels[i] = s.GenerateSymbolFrom(src)
 	}
+	return reels
+}
 
-	// Два одинаковых символа (обычный выигрыш)
-	if reel1 == reel2 || reel2 == reel3 || reel1 == reel3 {
-		var symbol int
-		if reel1 == reel2 {
-			symbol = reel1
-		} else if reel2 == reel3 {
-			symbol = reel2
-		} else {
-			symbol = reel1
-		}
+// payoutRounding определяет правило округления выплат
+// Доли минорной единицы не выплачиваются и остаются у казино
+const payoutRounding = money.RoundDown
 
-		switch symbol {
-		case 0:
-			return betAmount * 10 // Два нуля
-		case 1, 2, 3:
-			return betAmount * 3
-		case 4, 5, 6:
-			return betAmount * 2
-		case 7, 8, 9:
-			return betAmount * 1.5
+// payout умножает ставку на коэффициент с округлением по payoutRounding
+// Возвращает money.ErrOverflow, если выплата не помещается в сумму
+func payout(betAmount money.Money, multiplier Multiplier) (money.Money, error) {
+	return betAmount.CheckedMulRatio(multiplier.Num(), multiplier.Den(), payoutRounding)
+}
+
+// CalculateWin вычисляет выигрыш на основе комбинации символов и таблицы выплат:
+// выплату комбинации (с заменой wild) и выплату scatter, каждую с округлением вниз
+// Выплата джекпота сюда не входит: ее сумму определяет пул, см. IsJackpot
+func (s *Service) CalculateWin(reel1, reel2, reel3 int, betAmount money.Money) (money.Money, error) {
+	win := money.Zero(betAmount.Currency())
+	if multiplier, ok := s.paytable.Evaluate(reel1, reel2, reel3); ok {
+		var err error
+		if win, err = payout(betAmount, multiplier); err != nil {
+			return money.Money{}, err
 		}
 	}
-
-	// Последовательность (0-1-2 или 7-8-9)
-	if (reel1 == 0 && reel2 == 1 && reel3 == 2) || (reel1 == 7 && reel2 == 8 && reel3 == 9) {
-		return betAmount * 5
+	scatter, err := s.ScatterWin(reel1, reel2, reel3, betAmount)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if scatter != nil {
+		return win.Add(scatter.Win)
 	}
+	return win, nil
+}
 
-	// Нет выигрыша
-	return 0
+// ScatterWin возвращает выплату и бонус за scatter на барабанах (nil - ничего не положено)
+func (s *Service) ScatterWin(reel1, reel2, reel3 int, betAmount money.Money) (*ScatterWin, error) {
+	count := s.paytable.ScatterCount(reel1, reel2, reel3)
+	if count == 0 {
+		return nil, nil
+	}
+	return s.paytable.Scatter.Evaluate(count, betAmount)
 }
 
+// IsJackpot проверяет, выигрывает ли комбинация символов прогрессивный джекпот
+func (s *Service) IsJackpot(reel1, reel2, reel3 int) bool {
+	return s.paytable.IsJackpot(reel1, reel2, reel3)
+}
@@ -0,0 +1,138 @@
+package spin
+
+import (
+	"fmt"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+)
+
+// WildRule задает символ wild: он заменяет любой символ в выигрышных комбинациях,
+// кроме scatter. Из возможных замен засчитывается самая дорогая комбинация
+type WildRule struct {
+	Symbol int `json:"symbol"`
+}
+
+// ScatterRule задает символ scatter: он платит в любой позиции окна, а не на линии
+// Выплата зависит от числа scatter в окне и умножается на общую ставку раунда
+type ScatterRule struct {
+	Symbol int `json:"symbol"`
+	// Pays - число scatter в окне -> коэффициент; если за такое число выплата не задана,
+	// используется наибольшее заданное меньшее число
+	Pays map[int]Multiplier `json:"pays,omitempty"`
+	// FreeSpins - бонус бесплатных спинов (nil - scatter только платит)
+	FreeSpins *FreeSpinsRule `json:"free_spins,omitempty"`
+}
+
+// FreeSpinsRule задает бонус бесплатных спинов: Trigger и больше scatter в окне дают
+// Spins бесплатных спинов, выигрыши которых умножаются на Multiplier
+// Повторный запуск во время бесплатных спинов добавляет Spins к оставшимся
+type FreeSpinsRule struct {
+	Trigger    int        `json:"trigger"`
+	Spins      int        `json:"spins"`
+	Multiplier Multiplier `json:"multiplier"`
+}
+
+// ScatterWin описывает выплату и бонус за символы scatter в окне
+type ScatterWin struct {
+	Symbol int `json:"symbol"`
+	Count  int `json:"count"`
+	// Multiplier - коэффициент к общей ставке (0, если за это число scatter не платят)
+	Multiplier Multiplier  `json:"multiplier"`
+	Win        money.Money `json:"win"`
+	// FreeSpins - число выигранных бесплатных спинов (0 - бонус не запущен)
+	FreeSpins int `json:"free_spins,omitempty"`
+}
+
+// maxFreeSpins ограничивает число бесплатных спинов, выдаваемых за один запуск бонуса
+const maxFreeSpins = 100
+
+// validateSymbolRoles проверяет правила wild и scatter
+// maxScatters - сколько scatter может оказаться в окне игры
+func validateSymbolRoles(wild *WildRule, scatter *ScatterRule, known map[int]bool, maxScatters int) error {
+	if wild != nil && !known[wild.Symbol] {
+		return fmt.Errorf("%w: wild: неизвестный символ %d", ErrInvalidPaytable, wild.Symbol)
+	}
+	if scatter == nil {
+		return nil
+	}
+
+	if !known[scatter.Symbol] {
+		return fmt.Errorf("%w: scatter: неизвестный символ %d", ErrInvalidPaytable, scatter.Symbol)
+	}
+	if wild != nil && wild.Symbol == scatter.Symbol {
+		return fmt.Errorf("%w: scatter: символ %d уже назначен wild", ErrInvalidPaytable, scatter.Symbol)
+	}
+	if len(scatter.Pays) == 0 && scatter.FreeSpins == nil {
+		return fmt.Errorf("%w: scatter: не указаны ни выплаты, ни бесплатные спины", ErrInvalidPaytable)
+	}
+	for count, m := range scatter.Pays {
+		if count < 1 || count > maxScatters {
+			return fmt.Errorf("%w: scatter: число символов %d вне диапазона 1-%d", ErrInvalidPaytable, count, maxScatters)
+		}
+		if m.IsZero() {
+			return fmt.Errorf("%w: scatter: не указан коэффициент для %d символов", ErrInvalidPaytable, count)
+		}
+	}
+
+	if fs := scatter.FreeSpins; fs != nil {
+		if fs.Trigger < 1 || fs.Trigger > maxScatters {
+			return fmt.Errorf("%w: free_spins: число scatter для запуска %d вне диапазона 1-%d", ErrInvalidPaytable, fs.Trigger, maxScatters)
+		}
+		if fs.Spins < 1 || fs.Spins > maxFreeSpins {
+			return fmt.Errorf("%w: free_spins: число спинов %d вне диапазона 1-%d", ErrInvalidPaytable, fs.Spins, maxFreeSpins)
+		}
+		if fs.Multiplier.IsZero() {
+			return fmt.Errorf("%w: free_spins: не указан коэффициент", ErrInvalidPaytable)
+		}
+	}
+	return nil
+}
+
+// Evaluate возвращает выплату и бонус за count символов scatter при общей ставке bet
+// Возвращает nil, если за такое число scatter ничего не положено
+func (r *ScatterRule) Evaluate(count int, bet money.Money) (*ScatterWin, error) {
+	win := &ScatterWin{
+		Symbol: r.Symbol,
+		Count:  count,
+		Win:    money.Zero(bet.Currency()),
+	}
+	for n := count; n >= 1; n-- {
+		if m, ok := r.Pays[n]; ok {
+			var err error
+			win.Multiplier = m
+			if win.Win, err = payout(bet, m); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if r.FreeSpins != nil && count >= r.FreeSpins.Trigger {
+		win.FreeSpins = r.FreeSpins.Spins
+	}
+
+	if win.Multiplier.IsZero() && win.FreeSpins == 0 {
+		return nil, nil
+	}
+	return win, nil
+}
+
+// Award возвращает бонус, выигранный при этой выплате scatter (nil - бонус не запущен)
+func (r *ScatterRule) Award(win *ScatterWin) *game.FreeSpinsAward {
+	if win == nil || win.FreeSpins == 0 {
+		return nil
+	}
+	return &game.FreeSpinsAward{
+		Spins: win.FreeSpins,
+		Num:   r.FreeSpins.Multiplier.Num(),
+		Den:   r.FreeSpins.Multiplier.Den(),
+	}
+}
+
+// FreeSpinWin возвращает выигрыш бесплатного спина: выплату раунда, умноженную
+// на коэффициент бонуса, с тем же округлением, что и выплаты таблицы
+func FreeSpinWin(roundPayout money.Money, multiplier Multiplier) (money.Money, error) {
+	if !roundPayout.IsPositive() {
+		return money.Zero(roundPayout.Currency()), nil
+	}
+	return payout(roundPayout, multiplier)
+}
@@ -0,0 +1,249 @@
+package spin
+
+import (
+	"encoding/json"
+	"fmt"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/rng"
+)
+
+// VideoGameID - идентификатор видеослота 5x3
+const VideoGameID = "video"
+
+// VideoGrid - символы в окне видеослота по рядам: Grid[ряд][барабан], ряд 0 - верхний
+type VideoGrid [VideoRowCount][VideoReelCount]int
+
+// LineWin описывает выигрыш на одной линии выплат
+type LineWin struct {
+	// Line - номер линии в таблице, начиная с 1
+	Line   int `json:"line"`
+	Symbol int `json:"symbol"`
+	// Count - сколько символов подряд слева оплачено
+	Count int `json:"count"`
+	// Rows - ряды выигравших символов на первых Count барабанах, для подсветки
+	Rows       []int       `json:"rows"`
+	Multiplier Multiplier  `json:"multiplier"`
+	Win        money.Money `json:"win"`
+}
+
+// VideoOutcome - данные исхода раунда видеослота
+type VideoOutcome struct {
+	// Stops - позиции остановки лент; в окне видны символы Stops[i], Stops[i]+1, Stops[i]+2
+	Stops   [VideoReelCount]int `json:"stops"`
+	Grid    VideoGrid           `json:"grid"`
+	LineBet money.Money         `json:"line_bet"`
+	// Lines - только выигравшие линии
+	Lines []LineWin `json:"lines"`
+	// Scatter - выплата и бонус за scatter в окне (нет, если ничего не положено)
+	Scatter *ScatterWin `json:"scatter,omitempty"`
+}
+
+// DecodeVideoOutcome возвращает данные исхода видеослота
+func DecodeVideoOutcome(data json.RawMessage) (*VideoOutcome, error) {
+	var outcome VideoOutcome
+	if err := json.Unmarshal(data, &outcome); err != nil {
+		return nil, fmt.Errorf("failed to decode video outcome: %w", err)
+	}
+	return &outcome, nil
+}
+
+// VideoService представляет доменный сервис видеослота 5x3
+// Сервис не хранит источник случайных чисел: он передается в каждый раунд,
+// поэтому один сервис безопасно обслуживает все запросы
+type VideoService struct {
+	paytable *VideoPaytable
+}
+
+// NewVideoService создает доменный сервис видеослота
+func NewVideoService(paytable *VideoPaytable) *VideoService {
+	return &VideoService{
+		paytable: paytable,
+	}
+}
+
+// VideoService реализует game.LineGame
+var _ game.LineGame = (*VideoService)(nil)
+
+// Paytable возвращает таблицу видеослота
+func (s *VideoService) Paytable() *VideoPaytable {
+	return s.paytable
+}
+
+// ID возвращает идентификатор игры
+func (s *VideoService) ID() string {
+	return VideoGameID
+}
+
+// Name возвращает название игры
+func (s *VideoService) Name() string {
+	return "Видеослот 5x3"
+}
+
+// Version возвращает версию таблицы видеослота
+func (s *VideoService) Version() string {
+	return s.paytable.Version
+}
+
+// HasJackpot сообщает, что видеослот не участвует в прогрессивном джекпоте
+func (s *VideoService) HasJackpot() bool {
+	return false
+}
+
+// Lines возвращает число линий выплат
+func (s *VideoService) Lines() int {
+	return s.paytable.Lines()
+}
+
+// LineBet возвращает ставку на линию для общей ставки bet
+// Общая ставка должна делиться на число линий без остатка
+func (s *VideoService) LineBet(bet money.Money) (money.Money, error) {
+	lines := int64(s.Lines())
+	lineBet := bet.MulRatio(1, lines, money.RoundDown)
+	if !lineBet.IsPositive() || lineBet.MulRatio(lines, 1, money.RoundDown) != bet {
+		return money.Money{}, fmt.Errorf("%w: ставка %s должна делиться на %d линий",
+			game.ErrInvalidBet, bet.Format(), lines)
+	}
+	return lineBet, nil
+}
+
+// ValidateBet проверяет, что общая ставка делится на число линий
+func (s *VideoService) ValidateBet(bet money.Money) error {
+	_, err := s.LineBet(bet)
+	return err
+}
+
+// GenerateStopsFrom выбирает позиции остановки лент из внешнего источника
+func (s *VideoService) GenerateStopsFrom(src rng.Source) [VideoReelCount]int {
+	var stops [VideoReelCount]int
+	for i, strip := range s.paytable.Reels {
+		stops[i] = rng.Intn(src, len(strip))
+	}
+	return stops
+}
+
+// Grid возвращает символы в окне для позиций остановки лент
+func (s *VideoService) Grid(stops [VideoReelCount]int) VideoGrid {
+	var grid VideoGrid
+	for reel, strip := range s.paytable.Reels {
+		for row := 0; row < VideoRowCount; row++ {
+			grid[row][reel] = strip[(stops[reel]+row)%len(strip)]
+		}
+	}
+	return grid
+}
+
+// EvaluateLines проверяет все линии выплат слева направо и возвращает выигравшие
+// Выигрыш линии - ставка на линию, умноженная на коэффициент, с округлением вниз
+func (s *VideoService) EvaluateLines(grid VideoGrid, lineBet money.Money) ([]LineWin, error) {
+	var wins []LineWin
+	for i, line := range s.paytable.Paylines {
+		var symbols [VideoReelCount]int
+		for reel, row := range line {
+			symbols[reel] = grid[row][reel]
+		}
+
+		symbol, multiplier, paid := s.paytable.EvaluateLine(symbols)
+		if paid == 0 {
+			continue
+		}
+		win, err := payout(lineBet, multiplier)
+		if err != nil {
+			return nil, err
+		}
+		wins = append(wins, LineWin{
+			Line:       i + 1,
+			Symbol:     symbol,
+			Count:      paid,
+			Rows:       append([]int(nil), line[:paid]...),
+			Multiplier: multiplier,
+			Win:        win,
+		})
+	}
+	return wins, nil
+}
+
+// LinePayout возвращает выигрыш линии, на которой слева подряд стоят count символов symbol
+// Второе значение - оплаченная длина комбинации (0 - нет выигрыша)
+// Используется анализатором: расчет тот же, что в EvaluateLines
+func (s *VideoService) LinePayout(symbol, count int, lineBet money.Money) (money.Money, int, error) {
+	multiplier, paid := s.paytable.LinePay(symbol, count)
+	if paid == 0 {
+		return money.Zero(lineBet.Currency()), 0, nil
+	}
+	win, err := payout(lineBet, multiplier)
+	if err != nil {
+		return money.Money{}, 0, err
+	}
+	return win, paid, nil
+}
+
+// round разыгрывает раунд: останавливает ленты и считает выигрыш по всем линиям
+func (s *VideoService) round(src rng.Source, bet money.Money) (*VideoOutcome, money.Money, error) {
+	lineBet, err := s.LineBet(bet)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+
+	stops := s.GenerateStopsFrom(src)
+	outcome := &VideoOutcome{
+		Stops:   stops,
+		Grid:    s.Grid(stops),
+		LineBet: lineBet,
+		Lines:   []LineWin{},
+	}
+	wins, err := s.EvaluateLines(outcome.Grid, lineBet)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	total := money.Zero(bet.Currency())
+	for _, win := range wins {
+		outcome.Lines = append(outcome.Lines, win)
+		if total, err = total.Add(win.Win); err != nil {
+			return nil, money.Money{}, err
+		}
+	}
+
+	// Scatter платит к общей ставке, а не к ставке на линию
+	if count := s.paytable.ScatterCount(outcome.Grid); count > 0 {
+		if outcome.Scatter, err = s.paytable.Scatter.Evaluate(count, bet); err != nil {
+			return nil, money.Money{}, err
+		}
+		if outcome.Scatter != nil {
+			if total, err = total.Add(outcome.Scatter.Win); err != nil {
+				return nil, money.Money{}, err
+			}
+		}
+	}
+	return outcome, total, nil
+}
+
+// Play разыгрывает раунд видеослота на общую ставку bet
+func (s *VideoService) Play(src rng.Source, bet money.Money) (*game.Outcome, error) {
+	outcome, win, err := s.round(src, bet)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(outcome)
+	if err != nil {
+		return nil, err
+	}
+	return &game.Outcome{
+		Data:      data,
+		Payout:    win,
+		FreeSpins: s.paytable.Scatter.Award(outcome.Scatter),
+	}, nil
+}
+
+// Payout разыгрывает раунд и возвращает исход без данных (Data пуст)
+// Используется симулятором; ставка должна быть проверена через ValidateBet
+func (s *VideoService) Payout(src rng.Source, bet money.Money) (game.Outcome, error) {
+	outcome, win, err := s.round(src, bet)
+	if err != nil {
+		return game.Outcome{}, err
+	}
+	return game.Outcome{
+		Payout:    win,
+		FreeSpins: s.paytable.Scatter.Award(outcome.Scatter),
+	}, nil
+}
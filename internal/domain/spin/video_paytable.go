@@ -0,0 +1,252 @@
+package spin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+)
+
+// defaultVideoPaytableFile - таблица видеослота, используемая, если файл конфигурации не задан
+const defaultVideoPaytableFile = "paytables/video-2.json"
+
+// embeddedVideoPaytablesPattern - все встроенные версии таблиц видеослота
+const embeddedVideoPaytablesPattern = "paytables/video-*.json"
+
+const (
+	// VideoReelCount - количество барабанов видеослота
+	VideoReelCount = 5
+	// VideoRowCount - количество видимых символов на каждом барабане
+	VideoRowCount = 3
+	// maxPaylines ограничивает число линий выплат в таблице видеослота
+	maxPaylines = 50
+	// minLineCount - минимальная длина выигрышной комбинации на линии
+	minLineCount = 2
+)
+
+// Payline - линия выплат: номер ряда (0 - верхний) на каждом барабане слева направо
+type Payline [VideoReelCount]int
+
+// VideoPaytable описывает математику видеослота 5x3: ленты барабанов, линии выплат и выплаты
+// Вероятности задаются не весами символов, а составом лент: барабан останавливается
+// на случайной позиции своей ленты, и в окне видны три символа подряд
+type VideoPaytable struct {
+	Version string `json:"version"`
+	// Reels - ленты барабанов; лента замкнута, за последним символом идет первый
+	Reels    [][]int      `json:"reels"`
+	Paylines []Payline    `json:"paylines"`
+	Pays     []LinePayout `json:"pays"`
+	// Wild - символ, продолжающий комбинацию любого символа на линии (nil - без wild)
+	Wild *WildRule `json:"wild,omitempty"`
+	// Scatter - символ, который платит в любой позиции окна и запускает бесплатные спины (nil - без scatter)
+	Scatter *ScatterRule `json:"scatter,omitempty"`
+
+	// Производные данные, вычисляемые при валидации
+	pays map[int]map[int]Multiplier
+}
+
+// LinePayout задает выплаты за символ на линии: число символов подряд слева -> коэффициент
+// Коэффициент умножается на ставку на линию
+type LinePayout struct {
+	Symbol int                `json:"symbol"`
+	Pays   map[int]Multiplier `json:"pays"`
+}
+
+// ParseVideoPaytable разбирает таблицу видеослота из JSON и проверяет ее корректность
+func ParseVideoPaytable(data []byte) (*VideoPaytable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var p VideoPaytable
+	if err := decoder.Decode(&p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPaytable, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DefaultVideoPaytable возвращает встроенную таблицу видеослота
+func DefaultVideoPaytable() *VideoPaytable {
+	data, err := defaultPaytables.ReadFile(defaultVideoPaytableFile)
+	if err != nil {
+		panic("failed to read default video paytable: " + err.Error())
+	}
+	p, err := ParseVideoPaytable(data)
+	if err != nil {
+		panic("failed to parse default video paytable: " + err.Error())
+	}
+	return p
+}
+
+// EmbeddedVideoPaytables возвращает все встроенные версии таблиц видеослота,
+// включая прежние, упорядоченные по имени файла
+func EmbeddedVideoPaytables() []*VideoPaytable {
+	files, err := fs.Glob(defaultPaytables, embeddedVideoPaytablesPattern)
+	if err != nil {
+		panic("failed to list embedded video paytables: " + err.Error())
+	}
+	result := make([]*VideoPaytable, 0, len(files))
+	for _, file := range files {
+		data, err := defaultPaytables.ReadFile(file)
+		if err != nil {
+			panic("failed to read embedded video paytable: " + err.Error())
+		}
+		p, err := ParseVideoPaytable(data)
+		if err != nil {
+			panic("failed to parse embedded video paytable " + file + ": " + err.Error())
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// Validate проверяет таблицу видеослота и подготавливает производные данные
+func (p *VideoPaytable) Validate() error {
+	if p.Version == "" {
+		return fmt.Errorf("%w: не указана версия", ErrInvalidPaytable)
+	}
+	if len(p.Reels) != VideoReelCount {
+		return fmt.Errorf("%w: reels: ожидается %d лент, указано %d", ErrInvalidPaytable, VideoReelCount, len(p.Reels))
+	}
+
+	known := make(map[int]bool)
+	for i, strip := range p.Reels {
+		if len(strip) < VideoRowCount {
+			return fmt.Errorf("%w: reels: лента %d короче окна из %d символов", ErrInvalidPaytable, i+1, VideoRowCount)
+		}
+		for _, symbol := range strip {
+			known[symbol] = true
+		}
+	}
+
+	if len(p.Paylines) == 0 || len(p.Paylines) > maxPaylines {
+		return fmt.Errorf("%w: paylines: допускается от 1 до %d линий", ErrInvalidPaytable, maxPaylines)
+	}
+	seen := make(map[Payline]bool, len(p.Paylines))
+	for i, line := range p.Paylines {
+		for _, row := range line {
+			if row < 0 || row >= VideoRowCount {
+				return fmt.Errorf("%w: paylines: линия %d выходит за %d ряда", ErrInvalidPaytable, i+1, VideoRowCount)
+			}
+		}
+		if seen[line] {
+			return fmt.Errorf("%w: paylines: линия %v указана дважды", ErrInvalidPaytable, line)
+		}
+		seen[line] = true
+	}
+
+	if len(p.Pays) == 0 {
+		return fmt.Errorf("%w: не указаны выплаты", ErrInvalidPaytable)
+	}
+	p.pays = make(map[int]map[int]Multiplier, len(p.Pays))
+	for _, lp := range p.Pays {
+		if !known[lp.Symbol] {
+			return fmt.Errorf("%w: pays: символа %d нет на лентах", ErrInvalidPaytable, lp.Symbol)
+		}
+		if _, exists := p.pays[lp.Symbol]; exists {
+			return fmt.Errorf("%w: pays: символ %d указан дважды", ErrInvalidPaytable, lp.Symbol)
+		}
+		if len(lp.Pays) == 0 {
+			return fmt.Errorf("%w: pays: не указаны выплаты для символа %d", ErrInvalidPaytable, lp.Symbol)
+		}
+		for count, m := range lp.Pays {
+			if count < minLineCount || count > VideoReelCount {
+				return fmt.Errorf("%w: pays: символ %d: длина комбинации %d вне диапазона %d-%d",
+					ErrInvalidPaytable, lp.Symbol, count, minLineCount, VideoReelCount)
+			}
+			if m.IsZero() {
+				return fmt.Errorf("%w: pays: не указан коэффициент для %d x %d", ErrInvalidPaytable, count, lp.Symbol)
+			}
+		}
+		p.pays[lp.Symbol] = lp.Pays
+	}
+
+	if err := validateSymbolRoles(p.Wild, p.Scatter, known, VideoReelCount*VideoRowCount); err != nil {
+		return err
+	}
+	if p.Scatter != nil {
+		if _, exists := p.pays[p.Scatter.Symbol]; exists {
+			return fmt.Errorf("%w: pays: символ scatter %d платит только как scatter", ErrInvalidPaytable, p.Scatter.Symbol)
+		}
+	}
+
+	return nil
+}
+
+// Lines возвращает число линий выплат
+func (p *VideoPaytable) Lines() int {
+	return len(p.Paylines)
+}
+
+// LinePay возвращает коэффициент для count одинаковых символов symbol на линии слева
+// Если за такую длину выплата не задана, используется самая длинная заданная короче нее.
+// Второе значение - длина, за которую начислена выплата; 0, если комбинация не выигрышная
+func (p *VideoPaytable) LinePay(symbol, count int) (Multiplier, int) {
+	pays := p.pays[symbol]
+	for n := count; n >= minLineCount; n-- {
+		if m, ok := pays[n]; ok {
+			return m, n
+		}
+	}
+	return Multiplier{}, 0
+}
+
+// EvaluateLine оценивает символы линии слева направо
+// Wild продолжает комбинацию любого символа, кроме scatter. Если линия начинается
+// с wild, засчитывается более дорогая из комбинаций: самих wild или символа,
+// который они заменяют. Возвращает символ комбинации, коэффициент и оплаченную
+// длину; длина 0 означает, что линия не выигрышная
+func (p *VideoPaytable) EvaluateLine(symbols [VideoReelCount]int) (int, Multiplier, int) {
+	isWild := func(symbol int) bool {
+		return p.Wild != nil && symbol == p.Wild.Symbol
+	}
+
+	var (
+		best       Multiplier
+		bestSymbol int
+		bestPaid   int
+	)
+
+	wilds := 0
+	for wilds < VideoReelCount && isWild(symbols[wilds]) {
+		wilds++
+	}
+	if wilds > 0 {
+		best, bestPaid = p.LinePay(p.Wild.Symbol, wilds)
+		bestSymbol = p.Wild.Symbol
+	}
+
+	if wilds < VideoReelCount && (p.Scatter == nil || symbols[wilds] != p.Scatter.Symbol) {
+		symbol := symbols[wilds]
+		count := wilds + 1
+		for count < VideoReelCount && (symbols[count] == symbol || isWild(symbols[count])) {
+			count++
+		}
+		if m, paid := p.LinePay(symbol, count); paid > 0 && best.Less(m) {
+			best, bestSymbol, bestPaid = m, symbol, paid
+		}
+	}
+
+	if bestPaid == 0 {
+		return 0, Multiplier{}, 0
+	}
+	return bestSymbol, best, bestPaid
+}
+
+// ScatterCount возвращает число символов scatter в окне (0 - scatter в таблице нет)
+func (p *VideoPaytable) ScatterCount(grid VideoGrid) int {
+	if p.Scatter == nil {
+		return 0
+	}
+	count := 0
+	for _, row := range grid {
+		for _, symbol := range row {
+			if symbol == p.Scatter.Symbol {
+				count++
+			}
+		}
+	}
+	return count
+}
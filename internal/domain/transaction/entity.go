@@ -1,6 +1,9 @@
 package transaction
 
-import "time"
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
 
 // Type определяет тип транзакции
 type Type string
@@ -9,23 +12,33 @@ const (
 	TypeDeposit Type = "deposit" // Пополнение
 	TypeSpin    Type = "spin"    // Ставка в игре
 	TypeWin     Type = "win"     // Выигрыш
+
+	TypeJackpotWin  Type = "jackpot_win"   // Выигрыш прогрессивного джекпота
+	TypeFreeSpinWin Type = "free_spin_win" // Выигрыш бесплатного спина, выплачивается из бонусного пула
+
+	TypeWithdrawal       Type = "withdrawal"        // Резервирование средств по заявке на вывод
+	TypeWithdrawalPayout Type = "withdrawal_payout" // Выплата одобренной заявки (баланс не меняется)
+	TypeWithdrawalRefund Type = "withdrawal_refund" // Возврат резерва по отклоненной заявке
 )
 
 // Transaction представляет доменную сущность транзакции
 // Транзакция - это запись о финансовой операции пользователя
 type Transaction struct {
-	ID            uint
-	UserID        uint
+	ID     uint
+	UserID uint
+	// RoundID связывает транзакции ставки и выигрыша с результатом спина
+	// Пуст у транзакций вне игрового раунда
+	RoundID       string
 	Type          Type
-	Amount        float64
-	BalanceBefore float64
-	BalanceAfter  float64
+	Amount        money.Money
+	BalanceBefore money.Money
+	BalanceAfter  money.Money
 	Description   string
 	CreatedAt     time.Time
 }
 
 // NewTransaction создает новую транзакцию
-func NewTransaction(userID uint, txType Type, amount, balanceBefore, balanceAfter float64, description string) *Transaction {
+func NewTransaction(userID uint, txType Type, amount, balanceBefore, balanceAfter money.Money, description string) *Transaction {
 	return &Transaction{
 		UserID:        userID,
 		Type:          txType,
@@ -37,3 +50,15 @@ func NewTransaction(userID uint, txType Type, amount, balanceBefore, balanceAfte
 	}
 }
 
+// BalanceDelta возвращает изменение баланса игрока, которое вносит транзакция
+// Выплата по заявке не меняет баланс: средства списаны еще при создании заявки
+func (t *Transaction) BalanceDelta() money.Money {
+	switch t.Type {
+	case TypeDeposit, TypeWin, TypeJackpotWin, TypeFreeSpinWin, TypeWithdrawalRefund:
+		return t.Amount
+	case TypeSpin, TypeWithdrawal:
+		return t.Amount.Neg()
+	default:
+		return money.Zero(t.Amount.Currency())
+	}
+}
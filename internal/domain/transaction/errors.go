@@ -0,0 +1,9 @@
+package transaction
+
+import "errors"
+
+var (
+	ErrInvalidType   = errors.New("неизвестный тип транзакции")
+	ErrInvalidSort   = errors.New("неизвестный порядок сортировки")
+	ErrInvalidPeriod = errors.New("начало периода позже его конца")
+)
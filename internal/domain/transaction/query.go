@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"gambling/internal/domain/pagination"
+	"time"
+)
+
+// Sort определяет порядок истории транзакций
+type Sort string
+
+const (
+	SortNewest     Sort = "newest"      // Сначала новые (по умолчанию)
+	SortOldest     Sort = "oldest"      // Сначала старые
+	SortAmountDesc Sort = "amount_desc" // Сначала крупные суммы
+	SortAmountAsc  Sort = "amount_asc"  // Сначала мелкие суммы
+)
+
+// ParseSort разбирает порядок сортировки; пустая строка - SortNewest
+func ParseSort(s string) (Sort, error) {
+	switch sort := Sort(s); sort {
+	case "":
+		return SortNewest, nil
+	case SortNewest, SortOldest, SortAmountDesc, SortAmountAsc:
+		return sort, nil
+	default:
+		return "", ErrInvalidSort
+	}
+}
+
+// ParseType разбирает тип транзакции
+func ParseType(s string) (Type, error) {
+	switch t := Type(s); t {
+	case TypeDeposit, TypeSpin, TypeWin, TypeJackpotWin, TypeFreeSpinWin, TypeWithdrawal, TypeWithdrawalPayout, TypeWithdrawalRefund:
+		return t, nil
+	default:
+		return "", ErrInvalidType
+	}
+}
+
+// Query описывает выборку страницы истории транзакций пользователя
+// Это объект запроса: репозиторий переводит его в SQL, а use case собирает из фильтров клиента
+type Query struct {
+	UserID uint
+	// Types - типы транзакций; пусто - все типы
+	Types []Type
+	// From и To ограничивают время создания: From включительно, To не включительно
+	// Нулевое значение означает отсутствие границы
+	From time.Time
+	To   time.Time
+	Sort Sort
+	// After - курсор последней записи предыдущей страницы; nil - первая страница
+	After *pagination.Cursor
+	Limit int
+}
+
+// Page представляет страницу истории
+// Next равен nil на последней странице
+type Page struct {
+	Transactions []*Transaction
+	Next         *pagination.Cursor
+}
+
+// CursorFor возвращает курсор, указывающий на транзакцию в порядке sort
+// Время хранится в микросекундах: такова точность timestamptz в БД
+func (t *Transaction) CursorFor(sort Sort) pagination.Cursor {
+	key := t.CreatedAt.UnixMicro()
+	if sort == SortAmountDesc || sort == SortAmountAsc {
+		key = t.Amount.Amount()
+	}
+	return pagination.Cursor{Sort: string(sort), Key: key, ID: t.ID}
+}
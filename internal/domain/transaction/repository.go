@@ -1,8 +1,20 @@
 package transaction
 
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
 // Repository определяет интерфейс для работы с транзакциями
 type Repository interface {
 	Create(transaction *Transaction) error
 	GetByUserID(userID uint, limit int) ([]*Transaction, error)
+	// GetChainByUserID возвращает все транзакции пользователя в порядке создания
+	GetChainByUserID(userID uint) ([]*Transaction, error)
+	// GetByRoundID возвращает транзакции игрового раунда в порядке создания
+	GetByRoundID(roundID string) ([]*Transaction, error)
+	// TotalsSince возвращает суммы транзакций пользователя по типам, созданных начиная с since
+	TotalsSince(userID uint, since time.Time) (map[Type]money.Money, error)
+	// Find возвращает страницу истории по объекту запроса
+	Find(query Query) (*Page, error)
 }
-
@@ -0,0 +1,39 @@
+package uow
+
+import (
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/idempotency"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"gambling/internal/domain/withdrawal"
+)
+
+// Repositories предоставляет доступ к репозиториям внутри единицы работы
+// Все репозитории работают в рамках одной транзакции БД
+type Repositories interface {
+	Users() user.Repository
+	Transactions() transaction.Repository
+	Spins() spin.Repository
+	Seeds() fairness.Repository
+	RefreshTokens() session.Repository
+	Withdrawals() withdrawal.Repository
+	Ledger() ledger.Repository
+	Idempotency() idempotency.Repository
+	Limits() limit.Repository
+	Exclusions() exclusion.Repository
+	Jackpots() jackpot.Repository
+}
+
+// UnitOfWork определяет порт для атомарного выполнения бизнес-операции
+// Это порт (port) в архитектуре Ports & Adapters, реализация находится в infrastructure слое
+// Все изменения, сделанные внутри fn, фиксируются вместе или откатываются целиком,
+// если fn вернула ошибку
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
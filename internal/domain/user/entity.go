@@ -1,6 +1,7 @@
 package user
 
 import (
+	"gambling/internal/domain/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,19 +14,23 @@ type User struct {
 	Username     string
 	Email        string
 	PasswordHash string
-	Balance      float64
+	Balance      money.Money
+	Role         Role
+	Exclusion    *Exclusion // Самоограничение игрока; nil или истекшее не ограничивает игру
+	FreeSpins    *FreeSpins // Несыгранный бонус бесплатных спинов; nil - бонуса нет
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt
 }
 
-// NewUser создает нового пользователя с начальным балансом 0
+// NewUser создает нового пользователя с начальным балансом 0 в валюте по умолчанию
 func NewUser(username, email, passwordHash string) *User {
 	return &User{
 		Username:     username,
 		Email:        email,
 		PasswordHash: passwordHash,
-		Balance:      0,
+		Balance:      money.Zero(money.DefaultCurrency),
+		Role:         RolePlayer,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -33,35 +38,49 @@ func NewUser(username, email, passwordHash string) *User {
 
 // Deposit пополняет баланс пользователя
 // Это доменная логика, которая инкапсулирована в сущности
-func (u *User) Deposit(amount float64) error {
-	if amount <= 0 {
+func (u *User) Deposit(amount money.Money) error {
+	if !amount.IsPositive() {
 		return ErrInvalidAmount
 	}
-	u.Balance += amount
+	balance, err := u.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	u.Balance = balance
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
 // Withdraw списывает средства с баланса пользователя
-func (u *User) Withdraw(amount float64) error {
-	if amount <= 0 {
+func (u *User) Withdraw(amount money.Money) error {
+	if !amount.IsPositive() {
 		return ErrInvalidAmount
 	}
-	if u.Balance < amount {
+	if !u.Balance.SameCurrency(amount) {
+		return money.ErrCurrencyMismatch
+	}
+	if u.Balance.LessThan(amount) {
 		return ErrInsufficientFunds
 	}
-	u.Balance -= amount
+	balance, err := u.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+	u.Balance = balance
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
 // AddWin добавляет выигрыш на баланс
-func (u *User) AddWin(amount float64) error {
-	if amount <= 0 {
+func (u *User) AddWin(amount money.Money) error {
+	if !amount.IsPositive() {
 		return ErrInvalidAmount
 	}
-	u.Balance += amount
+	balance, err := u.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	u.Balance = balance
 	u.UpdatedAt = time.Now()
 	return nil
 }
-
@@ -7,5 +7,13 @@ var (
 	ErrInsufficientFunds = errors.New("недостаточно средств")
 	ErrUserNotFound      = errors.New("пользователь не найден")
 	ErrUserAlreadyExists = errors.New("пользователь уже существует")
-)
 
+	ErrExcluded           = errors.New("игрок ограничил себе игру")
+	ErrInvalidExclusion   = errors.New("неизвестный вид или срок самоограничения")
+	ErrExclusionShortened = errors.New("действующее самоограничение нельзя сократить")
+	ErrNotExcluded        = errors.New("самоограничение не действует")
+
+	ErrFreeSpinsPending = errors.New("сначала нужно сыграть бесплатные спины")
+	ErrNoFreeSpins      = errors.New("нет бесплатных спинов в этой игре")
+	ErrInvalidFreeSpins = errors.New("неверный бонус бесплатных спинов")
+)
@@ -0,0 +1,141 @@
+package user
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExclusionKind определяет вид самоограничения игрока
+type ExclusionKind string
+
+const (
+	// ExclusionCoolOff - короткий перерыв в игре (24 часа, 7 или 30 дней)
+	ExclusionCoolOff ExclusionKind = "cool_off"
+	// ExclusionSelf - самоисключение на длительный срок или бессрочно
+	ExclusionSelf ExclusionKind = "self_exclusion"
+)
+
+// coolOffPeriods - допустимые сроки перерыва в игре
+var coolOffPeriods = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// selfExclusionPeriods - допустимые сроки самоисключения; 0 означает бессрочное
+var selfExclusionPeriods = map[string]time.Duration{
+	"6m":        182 * 24 * time.Hour,
+	"1y":        365 * 24 * time.Hour,
+	"5y":        5 * 365 * 24 * time.Hour,
+	"permanent": 0,
+}
+
+// Exclusion представляет действующее самоограничение игрока
+// Until равен nil у бессрочного самоисключения
+type Exclusion struct {
+	Kind      ExclusionKind
+	StartedAt time.Time
+	Until     *time.Time
+}
+
+// NewExclusion создает самоограничение вида kind на срок period, начатое в момент now
+func NewExclusion(kind, period string, now time.Time) (*Exclusion, error) {
+	var periods map[string]time.Duration
+	switch ExclusionKind(kind) {
+	case ExclusionCoolOff:
+		periods = coolOffPeriods
+	case ExclusionSelf:
+		periods = selfExclusionPeriods
+	default:
+		return nil, ErrInvalidExclusion
+	}
+
+	duration, ok := periods[period]
+	if !ok {
+		return nil, ErrInvalidExclusion
+	}
+
+	exclusion := &Exclusion{Kind: ExclusionKind(kind), StartedAt: now}
+	if duration > 0 {
+		until := now.Add(duration)
+		exclusion.Until = &until
+	}
+	return exclusion, nil
+}
+
+// IsPermanent проверяет, бессрочное ли самоисключение
+func (e *Exclusion) IsPermanent() bool {
+	return e.Until == nil
+}
+
+// ActiveAt проверяет, действует ли самоограничение в момент now
+func (e *Exclusion) ActiveAt(now time.Time) bool {
+	return e.IsPermanent() || now.Before(*e.Until)
+}
+
+// endsBefore проверяет, заканчивается ли самоограничение раньше other
+func (e *Exclusion) endsBefore(other *Exclusion) bool {
+	if e.IsPermanent() {
+		return false
+	}
+	return other.IsPermanent() || e.Until.Before(*other.Until)
+}
+
+// ExcludedError сообщает, что игрок ограничил себе игру
+// Сравнивается с ErrExcluded через errors.Is
+type ExcludedError struct {
+	Kind  ExclusionKind
+	Until *time.Time
+}
+
+func (e *ExcludedError) Error() string {
+	if e.Until == nil {
+		return "действует бессрочное самоисключение"
+	}
+	if e.Kind == ExclusionCoolOff {
+		return fmt.Sprintf("действует перерыв в игре до %s", e.Until.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("действует самоисключение до %s", e.Until.Format(time.RFC3339))
+}
+
+// Is позволяет проверять ошибку через errors.Is(err, ErrExcluded)
+func (e *ExcludedError) Is(target error) bool {
+	return target == ErrExcluded
+}
+
+// ActiveExclusion возвращает самоограничение, действующее в момент now, или nil
+func (u *User) ActiveExclusion(now time.Time) *Exclusion {
+	if u.Exclusion == nil || !u.Exclusion.ActiveAt(now) {
+		return nil
+	}
+	return u.Exclusion
+}
+
+// EnsureCanPlay возвращает ExcludedError, если в момент now игроку запрещена игра
+func (u *User) EnsureCanPlay(now time.Time) error {
+	if e := u.ActiveExclusion(now); e != nil {
+		return &ExcludedError{Kind: e.Kind, Until: e.Until}
+	}
+	return nil
+}
+
+// Exclude устанавливает самоограничение
+// Действующее ограничение можно только продлить: новое не должно заканчиваться раньше
+func (u *User) Exclude(exclusion *Exclusion, now time.Time) error {
+	if current := u.ActiveExclusion(now); current != nil && exclusion.endsBefore(current) {
+		return ErrExclusionShortened
+	}
+	u.Exclusion = exclusion
+	u.UpdatedAt = now
+	return nil
+}
+
+// LiftExclusion снимает действующее самоограничение
+func (u *User) LiftExclusion(now time.Time) error {
+	if u.ActiveExclusion(now) == nil {
+		return ErrNotExcluded
+	}
+	u.Exclusion = nil
+	u.UpdatedAt = now
+	return nil
+}
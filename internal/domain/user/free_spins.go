@@ -0,0 +1,84 @@
+package user
+
+import (
+	"gambling/internal/domain/money"
+	"time"
+)
+
+// FreeSpins представляет бонус бесплатных спинов игрока
+// Бонус хранится на сервере: спины играются только в игре GameID на ставку Bet
+// раунда, запустившего бонус, без списания с баланса. Выигрыши бесплатных спинов
+// умножаются на коэффициент MultiplierNum/MultiplierDen
+type FreeSpins struct {
+	GameID        string
+	Bet           money.Money
+	Remaining     int
+	MultiplierNum int64
+	MultiplierDen int64
+	// TriggerSpinID - спин, запустивший бонус; с ним связаны все бесплатные спины бонуса
+	TriggerSpinID uint
+	// Won - сумма выигрышей уже сыгранных бесплатных спинов
+	Won       money.Money
+	AwardedAt time.Time
+}
+
+// EnsureNoFreeSpins возвращает ErrFreeSpinsPending, если у игрока есть несыгранные бесплатные спины
+// Платный раунд нельзя сыграть, пока не сыгран бонус: так у игрока не бывает двух бонусов сразу
+func (u *User) EnsureNoFreeSpins() error {
+	if u.FreeSpins != nil {
+		return ErrFreeSpinsPending
+	}
+	return nil
+}
+
+// AwardFreeSpins начисляет бонус из spins бесплатных спинов игры gameID на ставку bet,
+// запущенный спином triggerSpinID
+func (u *User) AwardFreeSpins(gameID string, bet money.Money, spins int, num, den int64, triggerSpinID uint, now time.Time) error {
+	if err := u.EnsureNoFreeSpins(); err != nil {
+		return err
+	}
+	if spins <= 0 || num <= 0 || den <= 0 || !bet.IsPositive() {
+		return ErrInvalidFreeSpins
+	}
+	u.FreeSpins = &FreeSpins{
+		GameID:        gameID,
+		Bet:           bet,
+		Remaining:     spins,
+		MultiplierNum: num,
+		MultiplierDen: den,
+		TriggerSpinID: triggerSpinID,
+		Won:           money.Zero(bet.Currency()),
+		AwardedAt:     now,
+	}
+	u.UpdatedAt = now
+	return nil
+}
+
+// FreeSpinsFor возвращает бонус, из которого играется бесплатный спин игры gameID,
+// или ErrNoFreeSpins, если бонуса в этой игре нет
+func (u *User) FreeSpinsFor(gameID string) (*FreeSpins, error) {
+	if u.FreeSpins == nil || u.FreeSpins.GameID != gameID || u.FreeSpins.Remaining <= 0 {
+		return nil, ErrNoFreeSpins
+	}
+	return u.FreeSpins, nil
+}
+
+// PlayFreeSpin отмечает сыгранный бесплатный спин с выигрышем win
+// retrigger - бесплатные спины, выигранные в этом спине: они добавляются к оставшимся
+// Когда спины заканчиваются, бонус снимается
+func (u *User) PlayFreeSpin(win money.Money, retrigger int, now time.Time) error {
+	if u.FreeSpins == nil || u.FreeSpins.Remaining <= 0 {
+		return ErrNoFreeSpins
+	}
+	won, err := u.FreeSpins.Won.Add(win)
+	if err != nil {
+		return err
+	}
+	u.FreeSpins.Won = won
+	u.FreeSpins.Remaining += retrigger - 1
+	if u.FreeSpins.Remaining == 0 {
+		u.FreeSpins = nil
+	}
+	u.UpdatedAt = now
+	return nil
+}
@@ -1,14 +1,27 @@
 package user
 
+import "gambling/internal/domain/money"
+
 // Repository определяет интерфейс для работы с пользователями
 // Это порт (port) в архитектуре Ports & Adapters (Hexagonal Architecture)
 // Реализация находится в infrastructure слое
 type Repository interface {
 	Create(user *User) error
 	GetByID(id uint) (*User, error)
+	// GetByIDForUpdate возвращает пользователя и блокирует его запись до конца
+	// текущей единицы работы, чтобы параллельные операции с балансом выполнялись по очереди
+	GetByIDForUpdate(id uint) (*User, error)
 	GetByUsername(username string) (*User, error)
 	GetByEmail(email string) (*User, error)
-	UpdateBalance(userID uint, newBalance float64) error
+	// ListIDs возвращает ID пользователей больше afterID по возрастанию (постраничный обход)
+	ListIDs(afterID uint, limit int) ([]uint, error)
+	// UpdateBalance сохраняет кэш баланса. Источник истины - проводки главной книги,
+	// поэтому баланс обновляется в той же единице работы, что и транзакция
+	UpdateBalance(userID uint, newBalance money.Money) error
+	UpdateRole(userID uint, role Role) error
+	// UpdateExclusion сохраняет самоограничение игрока; nil снимает его
+	UpdateExclusion(userID uint, exclusion *Exclusion) error
+	// UpdateFreeSpins сохраняет бонус бесплатных спинов игрока; nil снимает его
+	UpdateFreeSpins(userID uint, freeSpins *FreeSpins) error
 	Update(user *User) error
 }
-
@@ -4,6 +4,7 @@ import "errors"
 
 var (
 	ErrInvalidCredentials = errors.New("неверные учетные данные")
+	ErrInvalidRole        = errors.New("неизвестная роль")
 )
 
 // Credentials представляет Value Object для учетных данных
@@ -24,3 +25,20 @@ func NewCredentials(username, password string) (*Credentials, error) {
 	}, nil
 }
 
+// Role представляет Value Object роли пользователя
+type Role string
+
+const (
+	RolePlayer   Role = "player"   // Игрок
+	RoleOperator Role = "operator" // Оператор: рассматривает заявки на вывод средств
+)
+
+// ParseRole преобразует строку в роль
+func ParseRole(s string) (Role, error) {
+	switch role := Role(s); role {
+	case RolePlayer, RoleOperator:
+		return role, nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
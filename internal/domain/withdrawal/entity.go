@@ -0,0 +1,87 @@
+package withdrawal
+
+import (
+	"gambling/internal/domain/money"
+	"strings"
+	"time"
+)
+
+// Status определяет состояние заявки на вывод средств
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // Ожидает решения оператора, средства зарезервированы
+	StatusApproved Status = "approved" // Выплачена
+	StatusRejected Status = "rejected" // Отклонена, средства возвращены на баланс
+)
+
+// maxDestinationLength ограничивает длину реквизитов для выплаты
+const maxDestinationLength = 100
+
+// maxReasonLength ограничивает длину причины отказа
+const maxReasonLength = 255
+
+// Withdrawal представляет доменную сущность заявки на вывод средств
+// Средства списываются с баланса при создании заявки (резервируются) и либо
+// выплачиваются после одобрения, либо возвращаются игроку при отказе
+type Withdrawal struct {
+	ID          uint
+	UserID      uint
+	Amount      money.Money
+	Destination string // Реквизиты для выплаты (карта, счет, кошелек)
+	Status      Status
+	ReviewedBy  *uint // ID оператора, принявшего решение
+	Reason      string
+	CreatedAt   time.Time
+	ReviewedAt  *time.Time
+}
+
+// NewWithdrawal создает заявку на вывод в состоянии pending
+func NewWithdrawal(userID uint, amount money.Money, destination string) (*Withdrawal, error) {
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	destination = strings.TrimSpace(destination)
+	if destination == "" || len(destination) > maxDestinationLength {
+		return nil, ErrInvalidDestination
+	}
+
+	return &Withdrawal{
+		UserID:      userID,
+		Amount:      amount,
+		Destination: destination,
+		Status:      StatusPending,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// Approve одобряет заявку
+func (w *Withdrawal) Approve(operatorID uint) error {
+	return w.review(StatusApproved, operatorID, "")
+}
+
+// Reject отклоняет заявку; зарезервированные средства нужно вернуть игроку
+func (w *Withdrawal) Reject(operatorID uint, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxReasonLength {
+		return ErrInvalidReason
+	}
+	return w.review(StatusRejected, operatorID, reason)
+}
+
+// IsPending проверяет, ожидает ли заявка решения
+func (w *Withdrawal) IsPending() bool {
+	return w.Status == StatusPending
+}
+
+func (w *Withdrawal) review(status Status, operatorID uint, reason string) error {
+	if !w.IsPending() {
+		return ErrNotPending
+	}
+	now := time.Now()
+	w.Status = status
+	w.ReviewedBy = &operatorID
+	w.Reason = reason
+	w.ReviewedAt = &now
+	return nil
+}
@@ -0,0 +1,11 @@
+package withdrawal
+
+import "errors"
+
+var (
+	ErrWithdrawalNotFound = errors.New("заявка на вывод не найдена")
+	ErrNotPending         = errors.New("заявка на вывод уже рассмотрена")
+	ErrInvalidAmount      = errors.New("неверная сумма вывода")
+	ErrInvalidDestination = errors.New("неверные реквизиты для выплаты")
+	ErrInvalidReason      = errors.New("слишком длинная причина отказа")
+)
@@ -0,0 +1,12 @@
+package withdrawal
+
+// Repository определяет интерфейс для работы с заявками на вывод средств
+type Repository interface {
+	Create(withdrawal *Withdrawal) error
+	// GetByIDForUpdate возвращает заявку и блокирует ее до конца единицы работы,
+	// чтобы два оператора не рассмотрели одну заявку одновременно
+	GetByIDForUpdate(id uint) (*Withdrawal, error)
+	Update(withdrawal *Withdrawal) error
+	GetByUserID(userID uint, limit int) ([]*Withdrawal, error)
+	GetByStatus(status Status, limit int) ([]*Withdrawal, error)
+}
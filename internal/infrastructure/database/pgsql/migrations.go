@@ -1,15 +1,303 @@
 package pgsql
 
 import (
-	"gambling/internal/infrastructure/repository"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
-// RunMigrations выполняет автоматические миграции для всех моделей
-func (s *Storage) RunMigrations() error {
-	return s.DB.AutoMigrate(
-		&repository.DBUser{},
-		&repository.DBTransaction{},
-		&repository.DBSpinResult{},
-	)
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationsLockID - ключ advisory-блокировки, которая не дает двум процессам
+// применять миграции одновременно
+const migrationsLockID = 7283501
+
+var (
+	// ErrSchemaBehind возвращается, если в базе применены не все миграции
+	ErrSchemaBehind = errors.New("схема базы данных устарела: выполните `gambling migrate up`")
+	// ErrSchemaAhead возвращается, если в базе есть миграции, неизвестные этой версии приложения
+	ErrSchemaAhead = errors.New("схема базы данных новее приложения: обновите приложение")
+)
+
+// Migration представляет одну пронумерованную SQL миграцию
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus представляет состояние миграции в базе данных
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time // nil, если миграция не применена
+}
+
+// schemaMigration - строка таблицы schema_migrations
+type schemaMigration struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+// loadMigrations читает встроенные миграции вида NNNN_name.up.sql / NNNN_name.down.sql
+// Номера должны идти подряд с 1, у каждой миграции должны быть обе части
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(fileName, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("неверное имя файла миграции %q", fileName)
+		}
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("неверное имя файла миграции %q", fileName)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("неверный номер миграции в %q", fileName)
+		}
+
+		body, err := migrationFiles.ReadFile("migrations/" + fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("миграция %d названа по-разному: %q и %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("у миграции %04d_%s нет up или down части", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("пропущена миграция %d", i+1)
+		}
+	}
+	return migrations, nil
+}
+
+// MigrateUp применяет все неприменённые миграции по порядку
+// Каждая миграция выполняется в своей транзакции вместе с записью в schema_migrations
+func (s *Storage) MigrateUp() ([]Migration, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, m := range migrations {
+		done, err := s.applyMigration(m)
+		if err != nil {
+			return applied, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		if done {
+			applied = append(applied, m)
+		}
+	}
+	return applied, nil
+}
+
+// MigrateDown откатывает n последних применённых миграций
+func (s *Storage) MigrateDown(n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, errors.New("число откатываемых миграций должно быть положительным")
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := 0; i < n; i++ {
+		m, done, err := s.revertLatest(migrations)
+		if err != nil {
+			return reverted, err
+		}
+		if !done {
+			break
+		}
+		reverted = append(reverted, m)
+	}
+	return reverted, nil
+}
+
+// MigrationStatus возвращает состояние всех известных миграций
+func (s *Storage) MigrationStatus() ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := s.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		status[i] = MigrationStatus{Version: m.Version, Name: m.Name}
+		if row, ok := applied[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status[i].AppliedAt = &appliedAt
+		}
+	}
+	return status, nil
+}
+
+// CheckSchema проверяет, что в базе применены ровно те миграции, которые знает приложение
+func (s *Storage) CheckSchema() error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	applied, err := s.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for version := range applied {
+		if version > len(migrations) {
+			return fmt.Errorf("%w (в базе версия %d, приложение знает до %d)", ErrSchemaAhead, version, len(migrations))
+		}
+	}
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; !ok {
+			return fmt.Errorf("%w (не применена %04d_%s)", ErrSchemaBehind, m.Version, m.Name)
+		}
+	}
+	return nil
+}
+
+// MustCheckSchema проверяет схему и паникует, если она не совпадает с приложением
+func (s *Storage) MustCheckSchema() {
+	if err := s.CheckSchema(); err != nil {
+		panic("database schema check failed: " + err.Error())
+	}
 }
 
+func (s *Storage) ensureMigrationsTable() error {
+	return s.DB.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    integer PRIMARY KEY,
+		name       varchar(255) NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`).Error
+}
+
+// appliedMigrations возвращает применённые миграции; без таблицы schema_migrations - пустой набор
+func (s *Storage) appliedMigrations() (map[int]schemaMigration, error) {
+	var exists bool
+	if err := s.DB.Raw(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]schemaMigration)
+	if !exists {
+		return applied, nil
+	}
+
+	var rows []schemaMigration
+	if err := s.DB.Raw(`SELECT version, name, applied_at FROM schema_migrations`).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// applyMigration применяет миграцию, если она еще не применена другим процессом
+func (s *Storage) applyMigration(m Migration) (bool, error) {
+	applied := false
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, migrationsLockID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Raw(`SELECT count(*) FROM schema_migrations WHERE version = ?`, m.Version).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(m.Up).Error; err != nil {
+			return err
+		}
+		applied = true
+		return tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Version, m.Name).Error
+	})
+	return applied, err
+}
+
+// revertLatest откатывает последнюю применённую миграцию
+func (s *Storage) revertLatest(migrations []Migration) (Migration, bool, error) {
+	var (
+		reverted Migration
+		done     bool
+	)
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, migrationsLockID).Error; err != nil {
+			return err
+		}
+
+		var versions []int
+		if err := tx.Raw(`SELECT version FROM schema_migrations ORDER BY version DESC LIMIT 1`).Scan(&versions).Error; err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			return nil
+		}
+		latest := versions[0]
+		if latest > len(migrations) {
+			return fmt.Errorf("%w (в базе версия %d)", ErrSchemaAhead, latest)
+		}
+
+		reverted = migrations[latest-1]
+		if err := tx.Exec(reverted.Down).Error; err != nil {
+			return fmt.Errorf("migration %04d_%s: %w", reverted.Version, reverted.Name, err)
+		}
+		done = true
+		return tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, latest).Error
+	})
+	return reverted, done, err
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS spin_results;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,45 @@
+-- Исходная схема: пользователи, транзакции и результаты спинов.
+-- IF NOT EXISTS позволяет применить миграцию к базе, созданной GORM AutoMigrate.
+
+CREATE TABLE IF NOT EXISTS users (
+    id            bigserial PRIMARY KEY,
+    username      varchar(50)   NOT NULL,
+    email         varchar(100)  NOT NULL,
+    password_hash varchar(255)  NOT NULL,
+    balance       decimal(15,2) NOT NULL DEFAULT 0,
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id             bigserial PRIMARY KEY,
+    user_id        bigint        NOT NULL,
+    type           varchar(20)   NOT NULL,
+    amount         decimal(15,2) NOT NULL,
+    balance_before decimal(15,2) NOT NULL,
+    balance_after  decimal(15,2) NOT NULL,
+    description    varchar(255),
+    created_at     timestamptz,
+    deleted_at     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
+
+CREATE TABLE IF NOT EXISTS spin_results (
+    id         bigserial PRIMARY KEY,
+    user_id    bigint        NOT NULL,
+    bet_amount decimal(15,2) NOT NULL,
+    win_amount decimal(15,2) NOT NULL,
+    reel1      bigint        NOT NULL,
+    reel2      bigint        NOT NULL,
+    reel3      bigint        NOT NULL,
+    is_win     boolean       NOT NULL,
+    created_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_spin_results_user_id ON spin_results (user_id);
+CREATE INDEX IF NOT EXISTS idx_spin_results_deleted_at ON spin_results (deleted_at);
@@ -0,0 +1,13 @@
+ALTER TABLE spin_results DROP COLUMN IF EXISTS currency;
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE users DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE spin_results
+    ALTER COLUMN bet_amount TYPE decimal(15,2) USING bet_amount / 100.0,
+    ALTER COLUMN win_amount TYPE decimal(15,2) USING win_amount / 100.0;
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE decimal(15,2) USING amount / 100.0,
+    ALTER COLUMN balance_before TYPE decimal(15,2) USING balance_before / 100.0,
+    ALTER COLUMN balance_after TYPE decimal(15,2) USING balance_after / 100.0;
+ALTER TABLE users
+    ALTER COLUMN balance TYPE decimal(15,2) USING balance / 100.0;
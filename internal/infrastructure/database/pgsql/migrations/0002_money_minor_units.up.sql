@@ -0,0 +1,24 @@
+-- Денежные суммы хранятся в целых минорных единицах (копейках) вместе с валютой.
+-- Колонки переводятся из decimal только если они еще не были переведены.
+
+CREATE FUNCTION pg_temp.to_minor_units(tbl text, col text) RETURNS void AS $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = tbl AND column_name = col) = 'numeric' THEN
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN %I TYPE bigint USING round(%I * 100)::bigint', tbl, col, col);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT pg_temp.to_minor_units('users', 'balance');
+SELECT pg_temp.to_minor_units('transactions', 'amount');
+SELECT pg_temp.to_minor_units('transactions', 'balance_before');
+SELECT pg_temp.to_minor_units('transactions', 'balance_after');
+SELECT pg_temp.to_minor_units('spin_results', 'bet_amount');
+SELECT pg_temp.to_minor_units('spin_results', 'win_amount');
+
+DROP FUNCTION pg_temp.to_minor_units(text, text);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE spin_results ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'RUB';
@@ -0,0 +1 @@
+ALTER TABLE spin_results DROP COLUMN IF EXISTS paytable_version;
@@ -0,0 +1,2 @@
+-- Версия таблицы выплат, по которой сыгран спин
+ALTER TABLE spin_results ADD COLUMN IF NOT EXISTS paytable_version varchar(32) NOT NULL DEFAULT 'classic-1';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_spin_results_seed_pair_id;
+ALTER TABLE spin_results DROP COLUMN IF EXISTS nonce;
+ALTER TABLE spin_results DROP COLUMN IF EXISTS seed_pair_id;
+DROP TABLE IF EXISTS seed_pairs;
@@ -0,0 +1,21 @@
+-- Пары сидов доказуемо честной игры и привязка спинов к ним
+CREATE TABLE IF NOT EXISTS seed_pairs (
+    id               bigserial PRIMARY KEY,
+    user_id          bigint      NOT NULL,
+    server_seed      varchar(64) NOT NULL,
+    server_seed_hash varchar(64) NOT NULL,
+    client_seed      varchar(64) NOT NULL,
+    nonce            bigint      NOT NULL DEFAULT 0,
+    active           boolean     NOT NULL DEFAULT true,
+    revealed_at      timestamptz,
+    created_at       timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_seed_pairs_user_id ON seed_pairs (user_id);
+-- Одна активная пара на пользователя
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seed_pairs_active_user ON seed_pairs (user_id) WHERE active;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seed_pairs_server_seed_hash ON seed_pairs (server_seed_hash);
+CREATE INDEX IF NOT EXISTS idx_seed_pairs_revealed_at ON seed_pairs (revealed_at);
+
+ALTER TABLE spin_results ADD COLUMN IF NOT EXISTS seed_pair_id bigint;
+ALTER TABLE spin_results ADD COLUMN IF NOT EXISTS nonce bigint NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_spin_results_seed_pair_id ON spin_results (seed_pair_id);
@@ -0,0 +1,11 @@
+ALTER TABLE refresh_tokens DROP CONSTRAINT IF EXISTS fk_refresh_tokens_user;
+ALTER TABLE seed_pairs DROP CONSTRAINT IF EXISTS fk_seed_pairs_user;
+ALTER TABLE spin_results
+    DROP CONSTRAINT IF EXISTS fk_spin_results_seed_pair,
+    DROP CONSTRAINT IF EXISTS fk_spin_results_user,
+    DROP CONSTRAINT IF EXISTS chk_spin_results_win_non_negative,
+    DROP CONSTRAINT IF EXISTS chk_spin_results_bet_positive;
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS fk_transactions_user,
+    DROP CONSTRAINT IF EXISTS chk_transactions_amount_positive;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_balance_non_negative;
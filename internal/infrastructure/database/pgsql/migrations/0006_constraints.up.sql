@@ -0,0 +1,21 @@
+-- Инварианты, которые AutoMigrate создать не мог: внешние ключи и проверки сумм.
+-- Ссылки на несуществующих пользователей (если они есть) не дадут применить миграцию:
+-- такие записи нужно разобрать вручную.
+ALTER TABLE users
+    ADD CONSTRAINT chk_users_balance_non_negative CHECK (balance >= 0);
+
+ALTER TABLE transactions
+    ADD CONSTRAINT chk_transactions_amount_positive CHECK (amount > 0),
+    ADD CONSTRAINT fk_transactions_user FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE spin_results
+    ADD CONSTRAINT chk_spin_results_bet_positive CHECK (bet_amount > 0),
+    ADD CONSTRAINT chk_spin_results_win_non_negative CHECK (win_amount >= 0),
+    ADD CONSTRAINT fk_spin_results_user FOREIGN KEY (user_id) REFERENCES users (id),
+    ADD CONSTRAINT fk_spin_results_seed_pair FOREIGN KEY (seed_pair_id) REFERENCES seed_pairs (id);
+
+ALTER TABLE seed_pairs
+    ADD CONSTRAINT fk_seed_pairs_user FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE refresh_tokens
+    ADD CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (id);
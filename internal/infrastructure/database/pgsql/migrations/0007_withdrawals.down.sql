@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS withdrawals;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,24 @@
+-- Роли пользователей: операторы рассматривают заявки на вывод
+ALTER TABLE users ADD COLUMN role varchar(20) NOT NULL DEFAULT 'player'
+    CONSTRAINT chk_users_role CHECK (role IN ('player', 'operator'));
+
+-- Заявки на вывод средств
+CREATE TABLE withdrawals (
+    id          bigserial PRIMARY KEY,
+    user_id     bigint       NOT NULL REFERENCES users (id),
+    amount      bigint       NOT NULL CHECK (amount > 0),
+    currency    varchar(3)   NOT NULL,
+    destination varchar(100) NOT NULL,
+    status      varchar(20)  NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by bigint REFERENCES users (id),
+    reason      varchar(255) NOT NULL DEFAULT '',
+    created_at  timestamptz  NOT NULL DEFAULT now(),
+    reviewed_at timestamptz,
+    -- Решение по заявке принимается один раз и всегда фиксирует оператора и время
+    CONSTRAINT chk_withdrawals_review CHECK (
+        (status = 'pending') = (reviewed_at IS NULL AND reviewed_by IS NULL)
+    )
+);
+CREATE INDEX idx_withdrawals_user_id ON withdrawals (user_id);
+CREATE INDEX idx_withdrawals_status ON withdrawals (status);
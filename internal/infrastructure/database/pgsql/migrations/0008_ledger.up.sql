@@ -0,0 +1,88 @@
+-- Главная книга с двойной записью: каждая транзакция отражается проводками,
+-- сумма которых равна нулю. users.balance становится кэшем остатка кошелька.
+CREATE TABLE ledger_accounts (
+    id         bigserial PRIMARY KEY,
+    type       varchar(30) NOT NULL CHECK (type IN (
+        'wallet', 'pending_withdrawal', 'house', 'jackpot', 'bonus', 'external'
+    )),
+    user_id    bigint REFERENCES users (id),
+    currency   varchar(3)  NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    -- Счета игрока принадлежат пользователю, системные счета - никому
+    CONSTRAINT chk_ledger_accounts_owner CHECK (
+        (type IN ('wallet', 'pending_withdrawal')) = (user_id IS NOT NULL)
+    )
+);
+CREATE UNIQUE INDEX idx_ledger_accounts_key ON ledger_accounts (type, COALESCE(user_id, 0), currency);
+
+CREATE TABLE ledger_entries (
+    id             bigserial PRIMARY KEY,
+    -- NULL только у проводок начальных остатков, перенесенных этой миграцией
+    transaction_id bigint REFERENCES transactions (id),
+    account_id     bigint      NOT NULL REFERENCES ledger_accounts (id),
+    amount         bigint      NOT NULL CHECK (amount <> 0),
+    currency       varchar(3)  NOT NULL,
+    created_at     timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries (transaction_id);
+CREATE INDEX idx_ledger_entries_account_id ON ledger_entries (account_id);
+
+-- Проводки транзакции проверяются при фиксации: несбалансированная транзакция не зафиксируется
+CREATE FUNCTION ledger_check_balanced() RETURNS trigger AS $$
+BEGIN
+    IF NEW.transaction_id IS NOT NULL AND (
+        SELECT SUM(amount) FROM ledger_entries WHERE transaction_id = NEW.transaction_id
+    ) <> 0 THEN
+        RAISE EXCEPTION 'ledger: transaction % is unbalanced', NEW.transaction_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_ledger_entries_balanced
+    AFTER INSERT ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_check_balanced();
+
+-- Проводки не меняются и не удаляются: ошибки исправляются новыми транзакциями
+CREATE FUNCTION ledger_entries_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger: entries are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ledger_entries_immutable
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_entries_immutable();
+
+-- Начальные остатки: текущие балансы и резервы по ожидающим заявкам переносятся
+-- в книгу как поступление с внешнего счета, история до миграции не восстанавливается
+INSERT INTO ledger_accounts (type, currency)
+SELECT 'external', currency FROM users GROUP BY currency;
+
+INSERT INTO ledger_accounts (type, user_id, currency)
+SELECT 'wallet', id, currency FROM users;
+
+INSERT INTO ledger_accounts (type, user_id, currency)
+SELECT DISTINCT 'pending_withdrawal', user_id, currency FROM withdrawals WHERE status = 'pending';
+
+INSERT INTO ledger_entries (account_id, amount, currency)
+SELECT a.id, u.balance, u.currency
+FROM users u
+JOIN ledger_accounts a ON a.type = 'wallet' AND a.user_id = u.id AND a.currency = u.currency
+WHERE u.balance <> 0;
+
+INSERT INTO ledger_entries (account_id, amount, currency)
+SELECT a.id, SUM(w.amount), w.currency
+FROM withdrawals w
+JOIN ledger_accounts a ON a.type = 'pending_withdrawal' AND a.user_id = w.user_id AND a.currency = w.currency
+WHERE w.status = 'pending'
+GROUP BY a.id, w.currency;
+
+INSERT INTO ledger_entries (account_id, amount, currency)
+SELECT ext.id, -opening.total, opening.currency
+FROM (
+    SELECT currency, SUM(amount) AS total FROM ledger_entries GROUP BY currency
+) opening
+JOIN ledger_accounts ext ON ext.type = 'external' AND ext.user_id IS NULL AND ext.currency = opening.currency
+WHERE opening.total <> 0;
@@ -0,0 +1,22 @@
+-- Отчеты сверки балансов с транзакциями, спинами и главной книгой
+CREATE TABLE reconciliation_runs (
+    id            bigserial PRIMARY KEY,
+    started_at    timestamptz NOT NULL,
+    finished_at   timestamptz NOT NULL,
+    users_checked integer     NOT NULL CHECK (users_checked >= 0),
+    discrepancies integer     NOT NULL CHECK (discrepancies >= 0)
+);
+
+CREATE TABLE reconciliation_discrepancies (
+    id             bigserial PRIMARY KEY,
+    run_id         bigint      NOT NULL REFERENCES reconciliation_runs (id) ON DELETE CASCADE,
+    user_id        bigint      NOT NULL REFERENCES users (id),
+    kind           varchar(30) NOT NULL,
+    transaction_id bigint REFERENCES transactions (id),
+    spin_id        bigint REFERENCES spin_results (id),
+    expected       bigint      NOT NULL,
+    actual         bigint      NOT NULL,
+    currency       varchar(3)  NOT NULL
+);
+CREATE INDEX idx_reconciliation_discrepancies_run_id ON reconciliation_discrepancies (run_id);
+CREATE INDEX idx_reconciliation_discrepancies_user_id ON reconciliation_discrepancies (user_id);
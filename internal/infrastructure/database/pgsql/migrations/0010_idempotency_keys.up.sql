@@ -0,0 +1,15 @@
+-- Ключи идемпотентности: повтор запроса с тем же ключом получает сохраненный ответ
+CREATE TABLE idempotency_keys (
+    user_id       bigint       NOT NULL REFERENCES users (id),
+    key           varchar(255) NOT NULL,
+    fingerprint   varchar(64)  NOT NULL,
+    status_code   integer,
+    content_type  varchar(100) NOT NULL DEFAULT '',
+    response_body bytea,
+    applied_at    timestamptz,
+    locked_at     timestamptz  NOT NULL,
+    created_at    timestamptz  NOT NULL,
+    expires_at    timestamptz  NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
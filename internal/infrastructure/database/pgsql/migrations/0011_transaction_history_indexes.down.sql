@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+
+DROP INDEX IF EXISTS idx_transactions_user_id_amount;
+DROP INDEX IF EXISTS idx_transactions_user_id_created_at;
@@ -0,0 +1,7 @@
+-- Индексы для постраничной истории транзакций: курсор сравнивает пару
+-- (ключ сортировки, id), поэтому id входит в индекс последней колонкой
+CREATE INDEX idx_transactions_user_id_created_at ON transactions (user_id, created_at, id);
+CREATE INDEX idx_transactions_user_id_amount ON transactions (user_id, amount, id);
+
+-- Индекс по одному user_id покрывается составными индексами
+DROP INDEX IF EXISTS idx_transactions_user_id;
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_spin_results_user_id ON spin_results (user_id);
+DROP INDEX IF EXISTS idx_spin_results_user_id_created_at;
+
+ALTER TABLE spin_results DROP COLUMN IF EXISTS round_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS round_id;
@@ -0,0 +1,11 @@
+-- Идентификатор раунда связывает результат спина с транзакциями ставки и выигрыша
+-- У раундов, сыгранных до этой миграции, идентификатора нет
+ALTER TABLE transactions ADD COLUMN round_id uuid;
+ALTER TABLE spin_results ADD COLUMN round_id uuid;
+
+CREATE INDEX idx_transactions_round_id ON transactions (round_id);
+CREATE UNIQUE INDEX idx_spin_results_round_id ON spin_results (round_id);
+
+-- Постраничная история спинов: курсор сравнивает пару (created_at, id)
+CREATE INDEX idx_spin_results_user_id_created_at ON spin_results (user_id, created_at, id);
+DROP INDEX IF EXISTS idx_spin_results_user_id;
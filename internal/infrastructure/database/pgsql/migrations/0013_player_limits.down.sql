@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS play_sessions;
+DROP TABLE IF EXISTS player_limits;
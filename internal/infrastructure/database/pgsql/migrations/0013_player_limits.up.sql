@@ -0,0 +1,29 @@
+-- Лимиты ответственной игры: пополнения, проигрыш и ставки за период, длительность сессии
+-- value хранит минорные единицы для денежных лимитов и секунды для лимита сессии
+CREATE TABLE player_limits (
+    id                   bigserial    PRIMARY KEY,
+    user_id              bigint       NOT NULL REFERENCES users (id),
+    kind                 varchar(20)  NOT NULL,
+    period               varchar(20)  NOT NULL,
+    value                bigint       NOT NULL,
+    currency             varchar(3)   NOT NULL DEFAULT '',
+    pending_value        bigint,
+    pending_effective_at timestamptz,
+    created_at           timestamptz  NOT NULL,
+    updated_at           timestamptz  NOT NULL,
+    CONSTRAINT chk_player_limits_kind CHECK (kind IN ('deposit', 'loss', 'wager', 'session')),
+    CONSTRAINT chk_player_limits_period CHECK (
+        (kind = 'session' AND period = 'session')
+        OR (kind <> 'session' AND period IN ('day', 'week', 'month'))
+    ),
+    CONSTRAINT chk_player_limits_value CHECK (value > 0),
+    CONSTRAINT chk_player_limits_pending CHECK ((pending_value IS NULL) = (pending_effective_at IS NULL))
+);
+CREATE UNIQUE INDEX idx_player_limits_user_kind_period ON player_limits (user_id, kind, period);
+
+-- Игровая сессия: начинается с первой ставки и заканчивается перерывом в игре
+CREATE TABLE play_sessions (
+    user_id          bigint      PRIMARY KEY REFERENCES users (id),
+    started_at       timestamptz NOT NULL,
+    last_activity_at timestamptz NOT NULL
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS blocked_emails;
+DROP TABLE IF EXISTS exclusion_events;
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_exclusion,
+    DROP CONSTRAINT IF EXISTS chk_users_exclusion_kind,
+    DROP COLUMN IF EXISTS excluded_until,
+    DROP COLUMN IF EXISTS excluded_at,
+    DROP COLUMN IF EXISTS exclusion_kind;
@@ -0,0 +1,32 @@
+-- Самоограничение игрока: перерыв в игре или самоисключение (бессрочное, если excluded_until пуст)
+ALTER TABLE users
+    ADD COLUMN exclusion_kind varchar(20),
+    ADD COLUMN excluded_at    timestamptz,
+    ADD COLUMN excluded_until timestamptz,
+    ADD CONSTRAINT chk_users_exclusion_kind CHECK (exclusion_kind IN ('cool_off', 'self_exclusion')),
+    ADD CONSTRAINT chk_users_exclusion CHECK (
+        (exclusion_kind IS NULL) = (excluded_at IS NULL)
+        AND (exclusion_kind IS NOT NULL OR excluded_until IS NULL)
+        AND (exclusion_kind <> 'cool_off' OR excluded_until IS NOT NULL)
+    );
+
+-- Журнал аудита: кто и когда установил или снял самоограничение
+CREATE TABLE exclusion_events (
+    id             bigserial    PRIMARY KEY,
+    user_id        bigint       NOT NULL REFERENCES users (id),
+    actor_id       bigint       NOT NULL REFERENCES users (id),
+    action         varchar(10)  NOT NULL CHECK (action IN ('set', 'lift')),
+    kind           varchar(20)  NOT NULL CHECK (kind IN ('cool_off', 'self_exclusion')),
+    excluded_until timestamptz,
+    reason         varchar(255) NOT NULL DEFAULT '',
+    created_at     timestamptz  NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_exclusion_events_user_id ON exclusion_events (user_id, created_at);
+
+-- Email игроков с бессрочным самоисключением: с ними нельзя зарегистрировать новый аккаунт
+CREATE TABLE blocked_emails (
+    email      varchar(100) PRIMARY KEY,
+    user_id    bigint       NOT NULL REFERENCES users (id),
+    created_at timestamptz  NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_blocked_emails_user_id ON blocked_emails (user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE spin_results
+    DROP CONSTRAINT IF EXISTS chk_spin_results_jackpot,
+    DROP COLUMN IF EXISTS jackpot_amount;
+
+DROP TABLE IF EXISTS jackpot_winners;
+DROP TABLE IF EXISTS jackpot_pools;
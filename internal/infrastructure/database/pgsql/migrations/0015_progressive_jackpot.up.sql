@@ -0,0 +1,25 @@
+-- Пул прогрессивного джекпота: один на валюту. Сумма пула совпадает с остатком
+-- системного счета jackpot в главной книге
+CREATE TABLE jackpot_pools (
+    id         bigserial   PRIMARY KEY,
+    currency   varchar(3)  NOT NULL UNIQUE,
+    amount     bigint      NOT NULL CHECK (amount >= 0),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- История выигрышей джекпота
+CREATE TABLE jackpot_winners (
+    id         bigserial   PRIMARY KEY,
+    user_id    bigint      NOT NULL REFERENCES users (id),
+    round_id   uuid        NOT NULL,
+    bet_amount bigint      NOT NULL CHECK (bet_amount > 0),
+    amount     bigint      NOT NULL CHECK (amount >= 0),
+    currency   varchar(3)  NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_jackpot_winners_currency ON jackpot_winners (currency, created_at);
+
+-- Часть выигрыша спина, выплаченная из пула джекпота
+ALTER TABLE spin_results
+    ADD COLUMN jackpot_amount bigint NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_spin_results_jackpot CHECK (jackpot_amount >= 0 AND jackpot_amount <= win_amount);
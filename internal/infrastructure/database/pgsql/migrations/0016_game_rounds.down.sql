@@ -0,0 +1,21 @@
+-- Раунды других игр не имеют символов трех барабанов и при откате удаляются
+DELETE FROM spin_results WHERE game_id <> 'classic';
+
+ALTER TABLE spin_results
+    ADD COLUMN reel1 integer,
+    ADD COLUMN reel2 integer,
+    ADD COLUMN reel3 integer;
+
+UPDATE spin_results SET
+    reel1 = (outcome -> 'reels' ->> 0)::integer,
+    reel2 = (outcome -> 'reels' ->> 1)::integer,
+    reel3 = (outcome -> 'reels' ->> 2)::integer;
+
+DROP INDEX IF EXISTS idx_spin_results_game_id;
+
+ALTER TABLE spin_results
+    ALTER COLUMN reel1 SET NOT NULL,
+    ALTER COLUMN reel2 SET NOT NULL,
+    ALTER COLUMN reel3 SET NOT NULL,
+    DROP COLUMN outcome,
+    DROP COLUMN game_id;
@@ -0,0 +1,15 @@
+-- Раунды всех игр хранятся в spin_results: символы классического автомата
+-- переносятся в исход раунда в формате JSON, специфичном для игры
+ALTER TABLE spin_results
+    ADD COLUMN game_id varchar(32) NOT NULL DEFAULT 'classic',
+    ADD COLUMN outcome jsonb;
+
+UPDATE spin_results SET outcome = jsonb_build_object('reels', jsonb_build_array(reel1, reel2, reel3));
+
+ALTER TABLE spin_results
+    ALTER COLUMN outcome SET NOT NULL,
+    DROP COLUMN reel1,
+    DROP COLUMN reel2,
+    DROP COLUMN reel3;
+
+CREATE INDEX idx_spin_results_game_id ON spin_results (game_id);
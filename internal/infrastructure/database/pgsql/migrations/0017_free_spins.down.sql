@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS idx_spin_results_trigger_spin_id;
+
+ALTER TABLE spin_results
+    DROP CONSTRAINT IF EXISTS chk_spin_results_free_spin,
+    DROP COLUMN IF EXISTS win_multiplier_den,
+    DROP COLUMN IF EXISTS win_multiplier_num,
+    DROP COLUMN IF EXISTS trigger_spin_id;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS chk_users_free_spins,
+    DROP COLUMN IF EXISTS free_spins_awarded_at,
+    DROP COLUMN IF EXISTS free_spins_won,
+    DROP COLUMN IF EXISTS free_spins_trigger_spin_id,
+    DROP COLUMN IF EXISTS free_spins_multiplier_den,
+    DROP COLUMN IF EXISTS free_spins_multiplier_num,
+    DROP COLUMN IF EXISTS free_spins_remaining,
+    DROP COLUMN IF EXISTS free_spins_bet,
+    DROP COLUMN IF EXISTS free_spins_game_id;
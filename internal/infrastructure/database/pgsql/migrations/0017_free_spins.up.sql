@@ -0,0 +1,40 @@
+-- Бесплатные спины: бонус хранится на пользователе, пока не сыграны все спины
+ALTER TABLE users
+    ADD COLUMN free_spins_game_id         varchar(32),
+    ADD COLUMN free_spins_bet             bigint,
+    ADD COLUMN free_spins_remaining       integer,
+    ADD COLUMN free_spins_multiplier_num  bigint,
+    ADD COLUMN free_spins_multiplier_den  bigint,
+    ADD COLUMN free_spins_trigger_spin_id bigint REFERENCES spin_results (id),
+    ADD COLUMN free_spins_won             bigint,
+    ADD COLUMN free_spins_awarded_at      timestamptz,
+    ADD CONSTRAINT chk_users_free_spins CHECK (
+        (free_spins_game_id IS NULL
+            AND free_spins_bet IS NULL
+            AND free_spins_remaining IS NULL
+            AND free_spins_multiplier_num IS NULL
+            AND free_spins_multiplier_den IS NULL
+            AND free_spins_trigger_spin_id IS NULL
+            AND free_spins_won IS NULL
+            AND free_spins_awarded_at IS NULL)
+        OR (free_spins_game_id IS NOT NULL
+            AND free_spins_bet > 0
+            AND free_spins_remaining > 0
+            AND free_spins_multiplier_num > 0
+            AND free_spins_multiplier_den > 0
+            AND free_spins_trigger_spin_id IS NOT NULL
+            AND free_spins_won >= 0
+            AND free_spins_awarded_at IS NOT NULL)
+    );
+
+-- Сыгранный бесплатный спин ссылается на спин, запустивший бонус, и хранит коэффициент бонуса
+ALTER TABLE spin_results
+    ADD COLUMN trigger_spin_id    bigint REFERENCES spin_results (id),
+    ADD COLUMN win_multiplier_num bigint,
+    ADD COLUMN win_multiplier_den bigint,
+    ADD CONSTRAINT chk_spin_results_free_spin CHECK (
+        (trigger_spin_id IS NULL AND win_multiplier_num IS NULL AND win_multiplier_den IS NULL)
+        OR (trigger_spin_id IS NOT NULL AND win_multiplier_num > 0 AND win_multiplier_den > 0)
+    );
+
+CREATE INDEX idx_spin_results_trigger_spin_id ON spin_results (trigger_spin_id);
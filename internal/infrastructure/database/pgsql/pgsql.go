@@ -14,6 +14,7 @@ type Storage struct {
 }
 
 // New инициализирует новое подключение к базе данных с использованием GORM
+// Миграции не выполняются: их запускает вызывающая сторона (см. MigrateUp)
 func New(cfg *config.Config) *Storage {
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=UTC",
 		cfg.DBHost,
@@ -43,18 +44,12 @@ func New(cfg *config.Config) *Storage {
 		panic("failed to ping database: " + err.Error())
 	}
 
-	storage := &Storage{
+	return &Storage{
 		DB: db,
 	}
-
-	// Выполняем миграции при инициализации
-	if err := storage.RunMigrations(); err != nil {
-		panic("failed to run migrations: " + err.Error())
-	}
-
-	return storage
 }
 
+
 // Close закрывает соединение с базой данных
 func (s *Storage) Close() error {
 	sqlDB, err := s.DB.DB()
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"encoding/csv"
+	"gambling/internal/application/use_case/statement"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvWriter пишет выписку таблицей: строка начального остатка, транзакции и строки итогов
+// Служебные строки отличаются значением колонки type, поэтому файл целиком
+// открывается в табличном редакторе
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(out io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(out)}
+}
+
+func (c *csvWriter) WriteHeader(h statement.Header) error {
+	c.write("created_at", "id", "round_id", "type", "amount", "balance_before", "balance_after", "currency", "description")
+	c.write(formatTime(h.From), "", "", "opening_balance", "", "", h.OpeningBalance.String(), string(h.Currency), "")
+	return c.w.Error()
+}
+
+func (c *csvWriter) WriteLine(l statement.Line) error {
+	c.write(
+		formatTime(l.CreatedAt),
+		strconv.FormatUint(uint64(l.ID), 10),
+		l.RoundID,
+		string(l.Type),
+		l.Amount.String(),
+		l.BalanceBefore.String(),
+		l.BalanceAfter.String(),
+		string(l.Amount.Currency()),
+		l.Description,
+	)
+	return c.w.Error()
+}
+
+func (c *csvWriter) WriteSummary(s statement.Summary) error {
+	currency := string(s.ClosingBalance.Currency())
+	for _, t := range s.Totals {
+		c.write("", strconv.Itoa(t.Count), "", "total_"+string(t.Type), t.Amount.String(), "", "", currency, "")
+	}
+	c.write("", strconv.Itoa(s.Rounds), "", "rounds", "", "", "", "", "")
+	c.write("", strconv.Itoa(s.RoundsWon), "", "rounds_won", "", "", "", "", "")
+	c.write("", "", "", "net_gaming_result", s.NetGamingResult.String(), "", "", currency, "")
+	c.write("", "", "", "closing_balance", "", "", s.ClosingBalance.String(), currency, "")
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// write пишет строку в буфер csv.Writer; ошибка записи проверяется через Error
+func (c *csvWriter) write(record ...string) {
+	_ = c.w.Write(record)
+}
+
+// formatTime форматирует время в RFC 3339; нулевое время - пустая строка
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
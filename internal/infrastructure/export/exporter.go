@@ -0,0 +1,28 @@
+package export
+
+import (
+	"gambling/internal/application/use_case/statement"
+	"io"
+)
+
+// Exporter реализует порт statement.Exporter: выписка в CSV, JSON или PDF
+type Exporter struct{}
+
+// NewExporter создает новый экспортер выписок
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// NewWriter создает Writer выписки в указанном формате
+func (e *Exporter) NewWriter(format statement.Format, out io.Writer) (statement.Writer, error) {
+	switch format {
+	case statement.FormatCSV:
+		return newCSVWriter(out), nil
+	case statement.FormatJSON:
+		return newJSONWriter(out), nil
+	case statement.FormatPDF:
+		return newPDFWriter(out), nil
+	default:
+		return nil, statement.ErrUnsupportedFormat
+	}
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"gambling/internal/application/use_case/statement"
+	"gambling/internal/domain/money"
+	"io"
+	"time"
+)
+
+// jsonWriter пишет выписку одним JSON объектом, не собирая его в памяти:
+// массив transactions выводится по одной записи, итоги - после него
+type jsonWriter struct {
+	w     *bufio.Writer
+	enc   *json.Encoder
+	lines int
+}
+
+type jsonHeader struct {
+	UserID         uint        `json:"user_id"`
+	Username       string      `json:"username"`
+	Currency       string      `json:"currency"`
+	From           *time.Time  `json:"from,omitempty"`
+	To             time.Time   `json:"to"`
+	GeneratedAt    time.Time   `json:"generated_at"`
+	OpeningBalance money.Money `json:"opening_balance"`
+}
+
+type jsonLine struct {
+	ID            uint        `json:"id"`
+	RoundID       string      `json:"round_id,omitempty"`
+	Type          string      `json:"type"`
+	Amount        money.Money `json:"amount"`
+	BalanceBefore money.Money `json:"balance_before"`
+	BalanceAfter  money.Money `json:"balance_after"`
+	Description   string      `json:"description,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+type jsonTotal struct {
+	Type   string      `json:"type"`
+	Count  int         `json:"count"`
+	Amount money.Money `json:"amount"`
+}
+
+type jsonSummary struct {
+	Totals          []jsonTotal `json:"totals"`
+	Rounds          int         `json:"rounds"`
+	RoundsWon       int         `json:"rounds_won"`
+	NetGamingResult money.Money `json:"net_gaming_result"`
+	ClosingBalance  money.Money `json:"closing_balance"`
+}
+
+func newJSONWriter(out io.Writer) *jsonWriter {
+	w := bufio.NewWriter(out)
+	return &jsonWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// WriteHeader открывает объект: поля заголовка и начало массива transactions
+func (j *jsonWriter) WriteHeader(h statement.Header) error {
+	header := jsonHeader{
+		UserID:         h.UserID,
+		Username:       h.Username,
+		Currency:       string(h.Currency),
+		To:             h.To,
+		GeneratedAt:    h.GeneratedAt,
+		OpeningBalance: h.OpeningBalance,
+	}
+	if !h.From.IsZero() {
+		header.From = &h.From
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	// Поля заголовка и массив объединяются в один объект: закрывающая скобка заменяется
+	if _, err := j.w.Write(data[:len(data)-1]); err != nil {
+		return err
+	}
+	_, err = j.w.WriteString(`,"transactions":[`)
+	return err
+}
+
+func (j *jsonWriter) WriteLine(l statement.Line) error {
+	if j.lines > 0 {
+		if err := j.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	j.lines++
+	return j.enc.Encode(jsonLine{
+		ID:            l.ID,
+		RoundID:       l.RoundID,
+		Type:          string(l.Type),
+		Amount:        l.Amount,
+		BalanceBefore: l.BalanceBefore,
+		BalanceAfter:  l.BalanceAfter,
+		Description:   l.Description,
+		CreatedAt:     l.CreatedAt,
+	})
+}
+
+// WriteSummary закрывает массив transactions, пишет итоги и закрывает объект
+func (j *jsonWriter) WriteSummary(s statement.Summary) error {
+	summary := jsonSummary{
+		Totals:          make([]jsonTotal, len(s.Totals)),
+		Rounds:          s.Rounds,
+		RoundsWon:       s.RoundsWon,
+		NetGamingResult: s.NetGamingResult,
+		ClosingBalance:  s.ClosingBalance,
+	}
+	for i, t := range s.Totals {
+		summary.Totals[i] = jsonTotal{Type: string(t.Type), Count: t.Count, Amount: t.Amount}
+	}
+	if _, err := j.w.WriteString(`],"summary":`); err != nil {
+		return err
+	}
+	if err := j.enc.Encode(summary); err != nil {
+		return err
+	}
+	if _, err := j.w.WriteString("}\n"); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"gambling/internal/application/use_case/statement"
+	"io"
+	"strings"
+)
+
+// Разметка страницы A4 в пунктах: моноширинный шрифт позволяет выравнивать
+// колонки пробелами
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+	pdfFontSize   = 8
+	pdfLeading    = 11
+	pdfLinesPage  = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// Номера объектов, которые известны заранее; страницы нумеруются после них
+const (
+	pdfCatalogID = 1
+	pdfPagesID   = 2
+	pdfFontID    = 3
+	pdfFirstID   = 4
+)
+
+// pdfWriter пишет выписку в PDF потоком: каждая заполненная страница сразу
+// уходит в out, в памяти остается только текущая страница и смещения объектов
+// для таблицы xref. Стандартный шрифт Courier не содержит кириллицы, поэтому
+// в PDF пишутся коды типов вместо описаний, а прочие символы вне ASCII заменяются
+type pdfWriter struct {
+	w       *bufio.Writer
+	offset  int64
+	offsets map[int]int64
+	nextID  int
+	pageIDs []int
+	lines   []string
+}
+
+func newPDFWriter(out io.Writer) *pdfWriter {
+	return &pdfWriter{
+		w:       bufio.NewWriter(out),
+		offsets: make(map[int]int64),
+		nextID:  pdfFirstID,
+	}
+}
+
+func (p *pdfWriter) WriteHeader(h statement.Header) error {
+	if err := p.print("%%PDF-1.4\n"); err != nil {
+		return err
+	}
+	if err := p.object(pdfFontID, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>"); err != nil {
+		return err
+	}
+
+	from := "account opening"
+	if !h.From.IsZero() {
+		from = h.From.UTC().Format("2006-01-02 15:04")
+	}
+	lines := []string{
+		"ACCOUNT STATEMENT",
+		"",
+		fmt.Sprintf("Player:          %s (id %d)", h.Username, h.UserID),
+		fmt.Sprintf("Period (UTC):    %s - %s", from, h.To.UTC().Format("2006-01-02 15:04")),
+		fmt.Sprintf("Generated (UTC): %s", h.GeneratedAt.UTC().Format("2006-01-02 15:04")),
+		fmt.Sprintf("Opening balance: %s %s", h.OpeningBalance.String(), h.Currency),
+		"",
+		fmt.Sprintf("%-16s %10s  %-18s %14s %14s %14s", "Date", "ID", "Type", "Amount", "Before", "After"),
+		strings.Repeat("-", 93),
+	}
+	for _, line := range lines {
+		if err := p.addLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *pdfWriter) WriteLine(l statement.Line) error {
+	return p.addLine(fmt.Sprintf("%-16s %10d  %-18s %14s %14s %14s",
+		l.CreatedAt.UTC().Format("2006-01-02 15:04"),
+		l.ID,
+		l.Type,
+		l.Amount.String(),
+		l.BalanceBefore.String(),
+		l.BalanceAfter.String(),
+	))
+}
+
+func (p *pdfWriter) WriteSummary(s statement.Summary) error {
+	currency := s.ClosingBalance.Currency()
+	lines := []string{strings.Repeat("-", 93), "", "TOTALS"}
+	for _, t := range s.Totals {
+		lines = append(lines, fmt.Sprintf("  %-20s %8d  %14s %s", t.Type, t.Count, t.Amount.String(), currency))
+	}
+	lines = append(lines,
+		"",
+		fmt.Sprintf("Rounds played:     %d (won %d)", s.Rounds, s.RoundsWon),
+		fmt.Sprintf("Net gaming result: %s %s", s.NetGamingResult.String(), currency),
+		fmt.Sprintf("Closing balance:   %s %s", s.ClosingBalance.String(), currency),
+	)
+	for _, line := range lines {
+		if err := p.addLine(line); err != nil {
+			return err
+		}
+	}
+	if err := p.flushPage(); err != nil {
+		return err
+	}
+	return p.finish()
+}
+
+// addLine добавляет строку на текущую страницу, выводя заполненную страницу
+func (p *pdfWriter) addLine(line string) error {
+	p.lines = append(p.lines, line)
+	if len(p.lines) < pdfLinesPage {
+		return nil
+	}
+	return p.flushPage()
+}
+
+// flushPage записывает текущую страницу: поток содержимого и объект страницы
+func (p *pdfWriter) flushPage() error {
+	if len(p.lines) == 0 && len(p.pageIDs) > 0 {
+		return nil
+	}
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+	for _, line := range p.lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", pdfEscape(line))
+	}
+	fmt.Fprintf(&content, "(Page %d) Tj\nET\n", len(p.pageIDs)+1)
+	p.lines = p.lines[:0]
+
+	contentID, pageID := p.nextID, p.nextID+1
+	p.nextID += 2
+	p.pageIDs = append(p.pageIDs, pageID)
+
+	stream := fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String())
+	if err := p.object(contentID, stream); err != nil {
+		return err
+	}
+	page := fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesID, pdfPageWidth, pdfPageHeight, pdfFontID, contentID)
+	if err := p.object(pageID, page); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// finish записывает дерево страниц, каталог, таблицу xref и трейлер
+func (p *pdfWriter) finish() error {
+	kids := make([]string, len(p.pageIDs))
+	for i, id := range p.pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	pages := fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pageIDs))
+	if err := p.object(pdfPagesID, pages); err != nil {
+		return err
+	}
+	if err := p.object(pdfCatalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesID)); err != nil {
+		return err
+	}
+
+	xref := p.offset
+	if err := p.print("xref\n0 %d\n0000000000 65535 f \n", p.nextID); err != nil {
+		return err
+	}
+	for id := 1; id < p.nextID; id++ {
+		if err := p.print("%010d 00000 n \n", p.offsets[id]); err != nil {
+			return err
+		}
+	}
+	if err := p.print("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.nextID, pdfCatalogID, xref); err != nil {
+		return err
+	}
+	return p.w.Flush()
+}
+
+// object записывает объект и запоминает его смещение для xref
+func (p *pdfWriter) object(id int, body string) error {
+	p.offsets[id] = p.offset
+	return p.print("%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+func (p *pdfWriter) print(format string, args ...interface{}) error {
+	n, err := fmt.Fprintf(p.w, format, args...)
+	p.offset += int64(n)
+	return err
+}
+
+// pdfEscape экранирует строку для текстового оператора PDF
+// Символы вне печатного ASCII заменяются на '?'
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package paytable
+
+import (
+	"fmt"
+	"gambling/internal/domain/spin"
+	"os"
+)
+
+// Load загружает и проверяет таблицу выплат из JSON-файла
+// Если путь не задан, используется встроенная таблица по умолчанию
+func Load(path string) (*spin.Paytable, error) {
+	if path == "" {
+		return spin.DefaultPaytable(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read paytable %s: %w", path, err)
+	}
+
+	p, err := spin.ParsePaytable(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load paytable %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// MustLoad загружает таблицу выплат и паникует при ошибке
+// Используется при старте приложения: с неверной таблицей играть нельзя
+func MustLoad(path string) *spin.Paytable {
+	p, err := Load(path)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
+
+// LoadVideo загружает и проверяет таблицу видеослота из JSON-файла
+// Если путь не задан, используется встроенная таблица по умолчанию
+func LoadVideo(path string) (*spin.VideoPaytable, error) {
+	if path == "" {
+		return spin.DefaultVideoPaytable(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read video paytable %s: %w", path, err)
+	}
+
+	p, err := spin.ParseVideoPaytable(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load video paytable %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// MustLoadVideo загружает таблицу видеослота и паникует при ошибке
+func MustLoadVideo(path string) *spin.VideoPaytable {
+	p, err := LoadVideo(path)
+	if err != nil {
+		panic(err.Error())
+	}
+	return p
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/user"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ExclusionRepository реализует интерфейс exclusion.Repository
+type ExclusionRepository struct {
+	db *gorm.DB
+}
+
+// NewExclusionRepository создает новый репозиторий журнала самоограничений
+func NewExclusionRepository(db *gorm.DB) *ExclusionRepository {
+	return &ExclusionRepository{db: db}
+}
+
+// CreateEvent добавляет запись в журнал аудита
+func (r *ExclusionRepository) CreateEvent(e *exclusion.Event) error {
+	dbEvent := &DBExclusionEvent{
+		UserID:        e.UserID,
+		ActorID:       e.ActorID,
+		Action:        string(e.Action),
+		Kind:          string(e.Kind),
+		ExcludedUntil: e.Until,
+		Reason:        e.Reason,
+	}
+	if err := r.db.Create(dbEvent).Error; err != nil {
+		return err
+	}
+	e.ID = dbEvent.ID
+	e.CreatedAt = dbEvent.CreatedAt
+	return nil
+}
+
+// GetEventsByUserID возвращает журнал пользователя, новые записи первыми
+func (r *ExclusionRepository) GetEventsByUserID(userID uint, limit int) ([]*exclusion.Event, error) {
+	query := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var dbEvents []DBExclusionEvent
+	if err := query.Find(&dbEvents).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*exclusion.Event, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		result[i] = &exclusion.Event{
+			ID:        dbEvent.ID,
+			UserID:    dbEvent.UserID,
+			ActorID:   dbEvent.ActorID,
+			Action:    exclusion.Action(dbEvent.Action),
+			Kind:      user.ExclusionKind(dbEvent.Kind),
+			Until:     dbEvent.ExcludedUntil,
+			Reason:    dbEvent.Reason,
+			CreatedAt: dbEvent.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// BlockEmail запрещает регистрацию с email пользователя
+func (r *ExclusionRepository) BlockEmail(email string, userID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&DBBlockedEmail{
+		Email:  exclusion.NormalizeEmail(email),
+		UserID: userID,
+	}).Error
+}
+
+// UnblockEmail снимает запрет регистрации, установленный для пользователя
+func (r *ExclusionRepository) UnblockEmail(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&DBBlockedEmail{}).Error
+}
+
+// IsEmailBlocked проверяет, запрещена ли регистрация с email
+func (r *ExclusionRepository) IsEmailBlocked(email string) (bool, error) {
+	var count int64
+	err := r.db.Model(&DBBlockedEmail{}).
+		Where("email = ?", exclusion.NormalizeEmail(email)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DBExclusionEvent представляет модель БД для записи журнала самоограничений
+type DBExclusionEvent struct {
+	ID            uint   `gorm:"primaryKey"`
+	UserID        uint   `gorm:"not null;index"`
+	ActorID       uint   `gorm:"not null"`
+	Action        string `gorm:"not null;size:10"`
+	Kind          string `gorm:"not null;size:20"`
+	ExcludedUntil *time.Time
+	Reason        string    `gorm:"not null;size:255"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBExclusionEvent) TableName() string {
+	return "exclusion_events"
+}
+
+// DBBlockedEmail представляет модель БД для email, заблокированного бессрочным самоисключением
+type DBBlockedEmail struct {
+	Email     string    `gorm:"primaryKey;size:100"`
+	UserID    uint      `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBBlockedEmail) TableName() string {
+	return "blocked_emails"
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/idempotency"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository реализует интерфейс idempotency.Repository
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository создает новый репозиторий ключей идемпотентности
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Create сохраняет новую запись
+// Вставка без конфликта атомарно резервирует ключ: из параллельных запросов
+// с одним ключом запись создаст только один
+func (r *IdempotencyRepository) Create(record *idempotency.Record) error {
+	dbRecord := toDBIdempotencyKey(record)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dbRecord)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return idempotency.ErrKeyExists
+	}
+	return nil
+}
+
+// Get возвращает запись по пользователю и ключу
+func (r *IdempotencyRepository) Get(userID uint, key string) (*idempotency.Record, error) {
+	var dbRecord DBIdempotencyKey
+	if err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&dbRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, idempotency.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return toDomainIdempotencyKey(&dbRecord), nil
+}
+
+// TakeOver перехватывает зависшую запись, если ее не перехватил другой запрос
+func (r *IdempotencyRepository) TakeOver(record *idempotency.Record, lockedAt time.Time) (bool, error) {
+	result := r.db.Model(&DBIdempotencyKey{}).
+		Where("user_id = ? AND key = ? AND locked_at = ? AND status_code IS NULL", record.UserID, record.Key, record.LockedAt).
+		Update("locked_at", lockedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	record.LockedAt = lockedAt
+	return true, nil
+}
+
+// MarkApplied отмечает, что операция с ключом зафиксирована
+func (r *IdempotencyRepository) MarkApplied(userID uint, key string) error {
+	result := r.db.Model(&DBIdempotencyKey{}).
+		Where("user_id = ? AND key = ? AND applied_at IS NULL", userID, key).
+		Update("applied_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.Get(userID, key); err != nil {
+			return err
+		}
+		return idempotency.ErrAlreadyApplied
+	}
+	return nil
+}
+
+// Complete сохраняет ответ на первый запрос
+func (r *IdempotencyRepository) Complete(record *idempotency.Record) error {
+	return r.db.Model(&DBIdempotencyKey{}).
+		Where("user_id = ? AND key = ?", record.UserID, record.Key).
+		Updates(map[string]interface{}{
+			"status_code":   record.StatusCode,
+			"content_type":  record.ContentType,
+			"response_body": record.Body,
+		}).Error
+}
+
+// Delete удаляет запись
+func (r *IdempotencyRepository) Delete(userID uint, key string) error {
+	return r.db.Where("user_id = ? AND key = ?", userID, key).Delete(&DBIdempotencyKey{}).Error
+}
+
+// Release удаляет незавершенную запись, по которой операция не зафиксирована
+func (r *IdempotencyRepository) Release(userID uint, key string) error {
+	return r.db.
+		Where("user_id = ? AND key = ? AND applied_at IS NULL AND status_code IS NULL", userID, key).
+		Delete(&DBIdempotencyKey{}).Error
+}
+
+// DeleteExpired удаляет записи с истекшим сроком хранения
+func (r *IdempotencyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&DBIdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+// DBIdempotencyKey представляет модель БД для ключа идемпотентности
+type DBIdempotencyKey struct {
+	UserID       uint   `gorm:"primaryKey"`
+	Key          string `gorm:"primaryKey;size:255"`
+	Fingerprint  string `gorm:"not null;size:64"`
+	StatusCode   *int   // NULL, пока первый запрос не завершен
+	ContentType  string `gorm:"not null;size:100"`
+	ResponseBody []byte
+	AppliedAt    *time.Time
+	LockedAt     time.Time `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
+
+func (DBIdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+func toDBIdempotencyKey(record *idempotency.Record) *DBIdempotencyKey {
+	dbRecord := &DBIdempotencyKey{
+		UserID:       record.UserID,
+		Key:          record.Key,
+		Fingerprint:  record.Fingerprint,
+		ContentType:  record.ContentType,
+		ResponseBody: record.Body,
+		AppliedAt:    record.AppliedAt,
+		LockedAt:     record.LockedAt,
+		CreatedAt:    record.CreatedAt,
+		ExpiresAt:    record.ExpiresAt,
+	}
+	if record.StatusCode != 0 {
+		statusCode := record.StatusCode
+		dbRecord.StatusCode = &statusCode
+	}
+	return dbRecord
+}
+
+func toDomainIdempotencyKey(dbRecord *DBIdempotencyKey) *idempotency.Record {
+	record := &idempotency.Record{
+		UserID:      dbRecord.UserID,
+		Key:         dbRecord.Key,
+		Fingerprint: dbRecord.Fingerprint,
+		ContentType: dbRecord.ContentType,
+		Body:        dbRecord.ResponseBody,
+		AppliedAt:   dbRecord.AppliedAt,
+		LockedAt:    dbRecord.LockedAt,
+		CreatedAt:   dbRecord.CreatedAt,
+		ExpiresAt:   dbRecord.ExpiresAt,
+	}
+	if dbRecord.StatusCode != nil {
+		record.StatusCode = *dbRecord.StatusCode
+	}
+	return record
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JackpotRepository реализует интерфейс jackpot.Repository
+type JackpotRepository struct {
+	db *gorm.DB
+}
+
+// NewJackpotRepository создает новый репозиторий джекпота
+func NewJackpotRepository(db *gorm.DB) *JackpotRepository {
+	return &JackpotRepository{db: db}
+}
+
+// Get возвращает пул в валюте currency
+func (r *JackpotRepository) Get(currency money.Currency) (*jackpot.Pool, error) {
+	return r.get(r.db, currency)
+}
+
+// GetForUpdate возвращает пул с блокировкой строки
+// Взносы и выплаты всех спинов проходят через эту строку по очереди
+func (r *JackpotRepository) GetForUpdate(currency money.Currency) (*jackpot.Pool, error) {
+	return r.get(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), currency)
+}
+
+func (r *JackpotRepository) get(db *gorm.DB, currency money.Currency) (*jackpot.Pool, error) {
+	var dbPool DBJackpotPool
+	if err := db.Where("currency = ?", string(currency)).First(&dbPool).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, jackpot.ErrPoolNotFound
+		}
+		return nil, err
+	}
+	return &jackpot.Pool{
+		ID:        dbPool.ID,
+		Amount:    money.New(dbPool.Amount, currency),
+		UpdatedAt: dbPool.UpdatedAt,
+	}, nil
+}
+
+// Create создает пул, если пула в его валюте еще нет
+// Конфликт по валюте означает, что пул уже создан параллельной транзакцией
+func (r *JackpotRepository) Create(pool *jackpot.Pool) (bool, error) {
+	dbPool := &DBJackpotPool{
+		Currency: string(pool.Currency()),
+		Amount:   pool.Amount.Amount(),
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dbPool)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	pool.ID = dbPool.ID
+	pool.UpdatedAt = dbPool.UpdatedAt
+	return true, nil
+}
+
+// Update сохраняет сумму пула
+func (r *JackpotRepository) Update(pool *jackpot.Pool) error {
+	pool.UpdatedAt = time.Now()
+	return r.db.Model(&DBJackpotPool{}).
+		Where("id = ?", pool.ID).
+		Updates(map[string]interface{}{
+			"amount":     pool.Amount.Amount(),
+			"updated_at": pool.UpdatedAt,
+		}).Error
+}
+
+// CreateWinner добавляет запись в историю выигрышей джекпота
+func (r *JackpotRepository) CreateWinner(winner *jackpot.Winner) error {
+	dbWinner := &DBJackpotWinner{
+		UserID:    winner.UserID,
+		RoundID:   winner.RoundID,
+		BetAmount: winner.BetAmount.Amount(),
+		Amount:    winner.Amount.Amount(),
+		Currency:  string(winner.Amount.Currency()),
+		CreatedAt: winner.CreatedAt,
+	}
+	if err := r.db.Create(dbWinner).Error; err != nil {
+		return err
+	}
+	winner.ID = dbWinner.ID
+	winner.CreatedAt = dbWinner.CreatedAt
+	return nil
+}
+
+// ListWinners возвращает последние выигрыши джекпота, новые первыми
+func (r *JackpotRepository) ListWinners(currency money.Currency, limit int) ([]*jackpot.Winner, error) {
+	query := r.db.Where("currency = ?", string(currency)).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var dbWinners []DBJackpotWinner
+	if err := query.Find(&dbWinners).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*jackpot.Winner, len(dbWinners))
+	for i, dbWinner := range dbWinners {
+		result[i] = &jackpot.Winner{
+			ID:        dbWinner.ID,
+			UserID:    dbWinner.UserID,
+			RoundID:   dbWinner.RoundID,
+			BetAmount: money.New(dbWinner.BetAmount, currency),
+			Amount:    money.New(dbWinner.Amount, currency),
+			CreatedAt: dbWinner.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// DBJackpotPool представляет модель БД для пула джекпота
+type DBJackpotPool struct {
+	ID        uint      `gorm:"primaryKey"`
+	Currency  string    `gorm:"not null;size:3;uniqueIndex"`
+	Amount    int64     `gorm:"not null;type:bigint"` // Сумма хранится в минорных единицах
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+func (DBJackpotPool) TableName() string {
+	return "jackpot_pools"
+}
+
+// DBJackpotWinner представляет модель БД для выигрыша джекпота
+type DBJackpotWinner struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	RoundID   string    `gorm:"not null;type:uuid"`
+	BetAmount int64     `gorm:"not null;type:bigint"`
+	Amount    int64     `gorm:"not null;type:bigint"`
+	Currency  string    `gorm:"not null;size:3;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBJackpotWinner) TableName() string {
+	return "jackpot_winners"
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LedgerRepository реализует интерфейс ledger.Repository
+// Счета создаются при первой проводке, поэтому их не нужно заводить заранее
+type LedgerRepository struct {
+	db *gorm.DB
+}
+
+// NewLedgerRepository создает новый репозиторий главной книги
+func NewLedgerRepository(db *gorm.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Post записывает проводки
+// Баланс проводок проверяется до записи, а в БД дополнительно стоит отложенная
+// проверка, которая не даст зафиксировать несбалансированную транзакцию
+func (r *LedgerRepository) Post(entries []*ledger.Entry) error {
+	if err := ledger.Validate(entries); err != nil {
+		return err
+	}
+
+	dbEntries := make([]DBLedgerEntry, len(entries))
+	for i, e := range entries {
+		accountID, err := r.accountID(e.Account, e.Amount.Currency())
+		if err != nil {
+			return err
+		}
+		dbEntries[i] = DBLedgerEntry{
+			TransactionID: e.TransactionID,
+			AccountID:     accountID,
+			Amount:        e.Amount.Amount(),
+			Currency:      string(e.Amount.Currency()),
+		}
+	}
+
+	if err := r.db.Create(&dbEntries).Error; err != nil {
+		return err
+	}
+	for i, e := range entries {
+		e.ID = dbEntries[i].ID
+		e.CreatedAt = dbEntries[i].CreatedAt
+	}
+	return nil
+}
+
+// Balance возвращает остаток счета как сумму его проводок
+func (r *LedgerRepository) Balance(account ledger.Account, currency money.Currency) (money.Money, error) {
+	var total int64
+	err := r.db.Model(&DBLedgerEntry{}).
+		Joins("JOIN ledger_accounts a ON a.id = ledger_entries.account_id").
+		Where("a.type = ? AND a.currency = ?", string(account.Type), string(currency)).
+		Where(userIDCondition(account)).
+		Select("COALESCE(SUM(ledger_entries.amount), 0)").
+		Scan(&total).Error
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.New(total, currency), nil
+}
+
+// WalletBalances возвращает остатки кошельков всех пользователей
+// Кошелек ищется в валюте пользователя: у пользователя без проводок остаток нулевой
+func (r *LedgerRepository) WalletBalances() ([]ledger.AccountBalance, error) {
+	var rows []struct {
+		UserID   uint
+		Currency string
+		Balance  int64
+	}
+	err := r.db.Raw(`
+		SELECT u.id AS user_id, u.currency, COALESCE(SUM(e.amount), 0) AS balance
+		FROM users u
+		LEFT JOIN ledger_accounts a
+			ON a.user_id = u.id AND a.type = ? AND a.currency = u.currency
+		LEFT JOIN ledger_entries e ON e.account_id = a.id
+		WHERE u.deleted_at IS NULL
+		GROUP BY u.id, u.currency
+		ORDER BY u.id`, string(ledger.AccountWallet)).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ledger.AccountBalance, len(rows))
+	for i, row := range rows {
+		result[i] = ledger.AccountBalance{
+			Account: ledger.Wallet(row.UserID),
+			Balance: money.New(row.Balance, money.Currency(row.Currency)),
+		}
+	}
+	return result, nil
+}
+
+// SystemBalances возвращает остатки системных счетов казино
+func (r *LedgerRepository) SystemBalances() ([]ledger.AccountBalance, error) {
+	var rows []struct {
+		Type     string
+		Currency string
+		Balance  int64
+	}
+	err := r.db.Raw(`
+		SELECT a.type, a.currency, COALESCE(SUM(e.amount), 0) AS balance
+		FROM ledger_accounts a
+		LEFT JOIN ledger_entries e ON e.account_id = a.id
+		WHERE a.user_id IS NULL
+		GROUP BY a.type, a.currency
+		ORDER BY a.type, a.currency`).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ledger.AccountBalance, len(rows))
+	for i, row := range rows {
+		result[i] = ledger.AccountBalance{
+			Account: ledger.System(ledger.AccountType(row.Type)),
+			Balance: money.New(row.Balance, money.Currency(row.Currency)),
+		}
+	}
+	return result, nil
+}
+
+// accountID возвращает ID счета, создавая счет при первом обращении
+// Параллельное создание одного счета разрешается уникальным индексом:
+// проигравшая вставка ничего не делает, и ID читается повторно
+func (r *LedgerRepository) accountID(account ledger.Account, currency money.Currency) (uint, error) {
+	var dbAccount DBLedgerAccount
+	err := r.db.Where("type = ? AND currency = ?", string(account.Type), string(currency)).
+		Where(userIDCondition(account)).
+		Limit(1).
+		Find(&dbAccount).Error
+	if err != nil {
+		return 0, err
+	}
+	if dbAccount.ID != 0 {
+		return dbAccount.ID, nil
+	}
+
+	dbAccount = DBLedgerAccount{
+		Type:     string(account.Type),
+		Currency: string(currency),
+	}
+	if !account.IsSystem() {
+		userID := account.UserID
+		dbAccount.UserID = &userID
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&dbAccount).Error; err != nil {
+		return 0, err
+	}
+	if dbAccount.ID != 0 {
+		return dbAccount.ID, nil
+	}
+
+	err = r.db.Where("type = ? AND currency = ?", string(account.Type), string(currency)).
+		Where(userIDCondition(account)).
+		First(&dbAccount).Error
+	return dbAccount.ID, err
+}
+
+// userIDCondition возвращает условие на владельца счета
+func userIDCondition(account ledger.Account) clause.Expression {
+	if account.IsSystem() {
+		return clause.Expr{SQL: "user_id IS NULL"}
+	}
+	return clause.Eq{Column: clause.Column{Name: "user_id"}, Value: account.UserID}
+}
+
+// DBLedgerAccount представляет модель БД для счета главной книги
+type DBLedgerAccount struct {
+	ID        uint      `gorm:"primaryKey"`
+	Type      string    `gorm:"not null;size:30"`
+	UserID    *uint     // NULL у системных счетов
+	Currency  string    `gorm:"not null;size:3"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBLedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+// DBLedgerEntry представляет модель БД для проводки
+type DBLedgerEntry struct {
+	ID            uint      `gorm:"primaryKey"`
+	TransactionID uint      `gorm:"index"` // NULL у проводок начальных остатков
+	AccountID     uint      `gorm:"not null;index"`
+	Amount        int64     `gorm:"not null;type:bigint"` // Со знаком, в минорных единицах
+	Currency      string    `gorm:"not null;size:3"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBLedgerEntry) TableName() string {
+	return "ledger_entries"
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LimitRepository реализует интерфейс limit.Repository
+type LimitRepository struct {
+	db *gorm.DB
+}
+
+// NewLimitRepository создает новый репозиторий лимитов
+func NewLimitRepository(db *gorm.DB) *LimitRepository {
+	return &LimitRepository{db: db}
+}
+
+// GetByUserID возвращает лимиты пользователя
+func (r *LimitRepository) GetByUserID(userID uint) ([]*limit.Limit, error) {
+	var dbLimits []DBPlayerLimit
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&dbLimits).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*limit.Limit, len(dbLimits))
+	for i, dbLimit := range dbLimits {
+		result[i] = toDomainLimit(&dbLimit)
+	}
+	return result, nil
+}
+
+// Save создает лимит или обновляет существующий
+func (r *LimitRepository) Save(l *limit.Limit) error {
+	dbLimit := toDBLimit(l)
+	if err := r.db.Save(dbLimit).Error; err != nil {
+		return err
+	}
+	l.ID = dbLimit.ID
+	l.CreatedAt = dbLimit.CreatedAt
+	l.UpdatedAt = dbLimit.UpdatedAt
+	return nil
+}
+
+// Delete удаляет лимит
+func (r *LimitRepository) Delete(id uint) error {
+	return r.db.Delete(&DBPlayerLimit{}, id).Error
+}
+
+// GetSession возвращает игровую сессию пользователя
+func (r *LimitRepository) GetSession(userID uint) (*limit.PlaySession, error) {
+	var dbSession DBPlaySession
+	if err := r.db.Where("user_id = ?", userID).First(&dbSession).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, limit.ErrSessionMissing
+		}
+		return nil, err
+	}
+	return &limit.PlaySession{
+		UserID:         dbSession.UserID,
+		StartedAt:      dbSession.StartedAt,
+		LastActivityAt: dbSession.LastActivityAt,
+	}, nil
+}
+
+// SaveSession создает сессию или обновляет существующую
+func (r *LimitRepository) SaveSession(s *limit.PlaySession) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"started_at", "last_activity_at"}),
+	}).Create(&DBPlaySession{
+		UserID:         s.UserID,
+		StartedAt:      s.StartedAt,
+		LastActivityAt: s.LastActivityAt,
+	}).Error
+}
+
+// DBPlayerLimit представляет модель БД для лимита ответственной игры
+type DBPlayerLimit struct {
+	ID                 uint   `gorm:"primaryKey"`
+	UserID             uint   `gorm:"not null"`
+	Kind               string `gorm:"not null;size:20"`
+	Period             string `gorm:"not null;size:20"`
+	Value              int64  `gorm:"not null;type:bigint"` // Минорные единицы или секунды
+	Currency           string `gorm:"not null;size:3"`      // Пусто у лимита сессии
+	PendingValue       *int64 `gorm:"type:bigint"`          // NULL, если ослабление не ожидается
+	PendingEffectiveAt *time.Time
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+func (DBPlayerLimit) TableName() string {
+	return "player_limits"
+}
+
+// DBPlaySession представляет модель БД для игровой сессии
+type DBPlaySession struct {
+	UserID         uint      `gorm:"primaryKey"`
+	StartedAt      time.Time `gorm:"not null"`
+	LastActivityAt time.Time `gorm:"not null"`
+}
+
+func (DBPlaySession) TableName() string {
+	return "play_sessions"
+}
+
+func toDBLimit(l *limit.Limit) *DBPlayerLimit {
+	dbLimit := &DBPlayerLimit{
+		ID:        l.ID,
+		UserID:    l.UserID,
+		Kind:      string(l.Kind),
+		Period:    string(l.Period),
+		Value:     l.Value,
+		Currency:  string(l.Currency),
+		CreatedAt: l.CreatedAt,
+	}
+	if l.Pending != nil {
+		value, effectiveAt := l.Pending.Value, l.Pending.EffectiveAt
+		dbLimit.PendingValue = &value
+		dbLimit.PendingEffectiveAt = &effectiveAt
+	}
+	return dbLimit
+}
+
+func toDomainLimit(dbLimit *DBPlayerLimit) *limit.Limit {
+	l := &limit.Limit{
+		ID:        dbLimit.ID,
+		UserID:    dbLimit.UserID,
+		Kind:      limit.Kind(dbLimit.Kind),
+		Period:    limit.Period(dbLimit.Period),
+		Value:     dbLimit.Value,
+		Currency:  money.Currency(dbLimit.Currency),
+		CreatedAt: dbLimit.CreatedAt,
+		UpdatedAt: dbLimit.UpdatedAt,
+	}
+	if dbLimit.PendingValue != nil && dbLimit.PendingEffectiveAt != nil {
+		l.Pending = &limit.Change{Value: *dbLimit.PendingValue, EffectiveAt: *dbLimit.PendingEffectiveAt}
+	}
+	return l
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/reconciliation"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// reconciliationBatchSize - сколько расхождений вставляется одним запросом
+const reconciliationBatchSize = 500
+
+// ReconciliationRepository реализует интерфейс reconciliation.Repository
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+// NewReconciliationRepository создает новый репозиторий отчетов сверки
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// Save сохраняет запуск сверки и его расхождения в одной транзакции
+func (r *ReconciliationRepository) Save(run *reconciliation.Run) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		dbRun := &DBReconciliationRun{
+			StartedAt:     run.StartedAt,
+			FinishedAt:    run.FinishedAt,
+			UsersChecked:  run.UsersChecked,
+			Discrepancies: len(run.Discrepancies),
+		}
+		if err := tx.Create(dbRun).Error; err != nil {
+			return err
+		}
+		run.ID = dbRun.ID
+
+		if len(run.Discrepancies) == 0 {
+			return nil
+		}
+		dbDiscrepancies := make([]DBReconciliationDiscrepancy, len(run.Discrepancies))
+		for i, d := range run.Discrepancies {
+			d.RunID = run.ID
+			dbDiscrepancies[i] = toDBDiscrepancy(d)
+		}
+		if err := tx.CreateInBatches(&dbDiscrepancies, reconciliationBatchSize).Error; err != nil {
+			return err
+		}
+		for i, d := range run.Discrepancies {
+			d.ID = dbDiscrepancies[i].ID
+		}
+		return nil
+	})
+}
+
+// GetLatest возвращает последний запуск сверки с расхождениями
+func (r *ReconciliationRepository) GetLatest() (*reconciliation.Run, error) {
+	var dbRun DBReconciliationRun
+	if err := r.db.Order("id DESC").First(&dbRun).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, reconciliation.ErrRunNotFound
+		}
+		return nil, err
+	}
+
+	var dbDiscrepancies []DBReconciliationDiscrepancy
+	if err := r.db.Where("run_id = ?", dbRun.ID).Order("id ASC").Find(&dbDiscrepancies).Error; err != nil {
+		return nil, err
+	}
+
+	run := &reconciliation.Run{
+		ID:            dbRun.ID,
+		StartedAt:     dbRun.StartedAt,
+		FinishedAt:    dbRun.FinishedAt,
+		UsersChecked:  dbRun.UsersChecked,
+		Discrepancies: make([]*reconciliation.Discrepancy, len(dbDiscrepancies)),
+	}
+	for i, dbDiscrepancy := range dbDiscrepancies {
+		run.Discrepancies[i] = toDomainDiscrepancy(&dbDiscrepancy)
+	}
+	return run, nil
+}
+
+// DBReconciliationRun представляет модель БД для запуска сверки
+type DBReconciliationRun struct {
+	ID            uint      `gorm:"primaryKey"`
+	StartedAt     time.Time `gorm:"not null"`
+	FinishedAt    time.Time `gorm:"not null"`
+	UsersChecked  int       `gorm:"not null"`
+	Discrepancies int       `gorm:"not null"`
+}
+
+func (DBReconciliationRun) TableName() string {
+	return "reconciliation_runs"
+}
+
+// DBReconciliationDiscrepancy представляет модель БД для расхождения
+type DBReconciliationDiscrepancy struct {
+	ID            uint   `gorm:"primaryKey"`
+	RunID         uint   `gorm:"not null;index"`
+	UserID        uint   `gorm:"not null"`
+	Kind          string `gorm:"not null;size:30"`
+	TransactionID *uint
+	SpinID        *uint
+	Expected      int64  `gorm:"not null;type:bigint"` // В минорных единицах
+	Actual        int64  `gorm:"not null;type:bigint"`
+	Currency      string `gorm:"not null;size:3"`
+}
+
+func (DBReconciliationDiscrepancy) TableName() string {
+	return "reconciliation_discrepancies"
+}
+
+func toDBDiscrepancy(d *reconciliation.Discrepancy) DBReconciliationDiscrepancy {
+	return DBReconciliationDiscrepancy{
+		ID:            d.ID,
+		RunID:         d.RunID,
+		UserID:        d.UserID,
+		Kind:          string(d.Kind),
+		TransactionID: nullableID(d.TransactionID),
+		SpinID:        nullableID(d.SpinID),
+		Expected:      d.Expected.Amount(),
+		Actual:        d.Actual.Amount(),
+		Currency:      string(d.Expected.Currency()),
+	}
+}
+
+func toDomainDiscrepancy(dbDiscrepancy *DBReconciliationDiscrepancy) *reconciliation.Discrepancy {
+	currency := money.Currency(dbDiscrepancy.Currency)
+	return &reconciliation.Discrepancy{
+		ID:            dbDiscrepancy.ID,
+		RunID:         dbDiscrepancy.RunID,
+		UserID:        dbDiscrepancy.UserID,
+		Kind:          reconciliation.Kind(dbDiscrepancy.Kind),
+		TransactionID: valueOfID(dbDiscrepancy.TransactionID),
+		SpinID:        valueOfID(dbDiscrepancy.SpinID),
+		Expected:      money.New(dbDiscrepancy.Expected, currency),
+		Actual:        money.New(dbDiscrepancy.Actual, currency),
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/session"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RefreshTokenRepository реализует интерфейс session.Repository
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository создает новый репозиторий refresh токенов
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create сохраняет новый refresh токен
+func (r *RefreshTokenRepository) Create(token *session.RefreshToken) error {
+	dbToken := toDBRefreshToken(token)
+	if err := r.db.Create(dbToken).Error; err != nil {
+		return err
+	}
+	token.ID = dbToken.ID
+	token.CreatedAt = dbToken.CreatedAt
+	return nil
+}
+
+// GetByHashForUpdate возвращает токен по хешу с блокировкой строки
+// Блокировка действует до завершения транзакции, поэтому метод имеет смысл только внутри UnitOfWork
+func (r *RefreshTokenRepository) GetByHashForUpdate(tokenHash string) (*session.RefreshToken, error) {
+	var dbToken DBRefreshToken
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		First(&dbToken).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, session.ErrInvalidToken
+		}
+		return nil, err
+	}
+	return toDomainRefreshToken(&dbToken), nil
+}
+
+// Update сохраняет отзыв токена
+func (r *RefreshTokenRepository) Update(token *session.RefreshToken) error {
+	return r.db.Model(&DBRefreshToken{}).Where("id = ?", token.ID).
+		Update("revoked_at", token.RevokedAt).Error
+}
+
+// RevokeFamily отзывает все действующие токены семейства
+func (r *RefreshTokenRepository) RevokeFamily(familyID string, at time.Time) error {
+	return r.db.Model(&DBRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+// RevokeAllByUserID отзывает все действующие токены пользователя
+func (r *RefreshTokenRepository) RevokeAllByUserID(userID uint, at time.Time) error {
+	return r.db.Model(&DBRefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// DBRefreshToken представляет модель БД для refresh токена
+type DBRefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	FamilyID  string    `gorm:"not null;size:32;index"`
+	TokenHash string    `gorm:"not null;size:64;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (DBRefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func toDBRefreshToken(token *session.RefreshToken) *DBRefreshToken {
+	return &DBRefreshToken{
+		ID:        token.ID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+func toDomainRefreshToken(dbToken *DBRefreshToken) *session.RefreshToken {
+	return &session.RefreshToken{
+		ID:        dbToken.ID,
+		UserID:    dbToken.UserID,
+		FamilyID:  dbToken.FamilyID,
+		TokenHash: dbToken.TokenHash,
+		ExpiresAt: dbToken.ExpiresAt,
+		RevokedAt: dbToken.RevokedAt,
+		CreatedAt: dbToken.CreatedAt,
+	}
+}
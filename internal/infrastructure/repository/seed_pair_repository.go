@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/fairness"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedPairRepository реализует интерфейс fairness.Repository
+type SeedPairRepository struct {
+	db *gorm.DB
+}
+
+// NewSeedPairRepository создает новый репозиторий пар сидов
+func NewSeedPairRepository(db *gorm.DB) *SeedPairRepository {
+	return &SeedPairRepository{db: db}
+}
+
+// Create создает новую пару сидов
+func (r *SeedPairRepository) Create(pair *fairness.SeedPair) error {
+	dbPair := toDBSeedPair(pair)
+	if err := r.db.Create(dbPair).Error; err != nil {
+		return err
+	}
+	pair.ID = dbPair.ID
+	pair.CreatedAt = dbPair.CreatedAt
+	return nil
+}
+
+// GetByID возвращает пару сидов по ID
+func (r *SeedPairRepository) GetByID(id uint) (*fairness.SeedPair, error) {
+	var dbPair DBSeedPair
+	if err := r.db.First(&dbPair, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fairness.ErrSeedNotFound
+		}
+		return nil, err
+	}
+	return toDomainSeedPair(&dbPair), nil
+}
+
+// GetActiveByUserID возвращает активную пару сидов пользователя
+func (r *SeedPairRepository) GetActiveByUserID(userID uint) (*fairness.SeedPair, error) {
+	return r.getActive(r.db, userID)
+}
+
+// GetActiveByUserIDForUpdate возвращает активную пару сидов с блокировкой строки
+// Блокировка действует до завершения транзакции, поэтому метод имеет смысл только внутри UnitOfWork
+func (r *SeedPairRepository) GetActiveByUserIDForUpdate(userID uint) (*fairness.SeedPair, error) {
+	return r.getActive(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), userID)
+}
+
+func (r *SeedPairRepository) getActive(db *gorm.DB, userID uint) (*fairness.SeedPair, error) {
+	var dbPair DBSeedPair
+	if err := db.Where("user_id = ? AND active", userID).First(&dbPair).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fairness.ErrSeedNotFound
+		}
+		return nil, err
+	}
+	return toDomainSeedPair(&dbPair), nil
+}
+
+// ListRevealedByUserID возвращает раскрытые пары сидов пользователя, начиная с последних
+func (r *SeedPairRepository) ListRevealedByUserID(userID uint, limit int) ([]*fairness.SeedPair, error) {
+	var dbPairs []DBSeedPair
+	query := r.db.Where("user_id = ? AND revealed_at IS NOT NULL", userID).Order("revealed_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&dbPairs).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*fairness.SeedPair, len(dbPairs))
+	for i, dbPair := range dbPairs {
+		result[i] = toDomainSeedPair(&dbPair)
+	}
+	return result, nil
+}
+
+// Update сохраняет изменения пары сидов (nonce, активность, раскрытие)
+func (r *SeedPairRepository) Update(pair *fairness.SeedPair) error {
+	return r.db.Model(&DBSeedPair{}).Where("id = ?", pair.ID).Updates(map[string]interface{}{
+		"client_seed": pair.ClientSeed,
+		"nonce":       pair.Nonce,
+		"active":      pair.Active,
+		"revealed_at": pair.RevealedAt,
+	}).Error
+}
+
+// DBSeedPair представляет модель БД для пары сидов
+type DBSeedPair struct {
+	ID             uint       `gorm:"primaryKey"`
+	UserID         uint       `gorm:"not null;index;uniqueIndex:idx_seed_pairs_active_user,where:active"` // Одна активная пара на пользователя
+	ServerSeed     string     `gorm:"not null;size:64"`
+	ServerSeedHash string     `gorm:"not null;size:64;uniqueIndex"`
+	ClientSeed     string     `gorm:"not null;size:64"`
+	Nonce          uint64     `gorm:"not null;default:0"`
+	Active         bool       `gorm:"not null;default:true"`
+	RevealedAt     *time.Time `gorm:"index"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+}
+
+func (DBSeedPair) TableName() string {
+	return "seed_pairs"
+}
+
+func toDBSeedPair(pair *fairness.SeedPair) *DBSeedPair {
+	return &DBSeedPair{
+		ID:             pair.ID,
+		UserID:         pair.UserID,
+		ServerSeed:     pair.ServerSeed,
+		ServerSeedHash: pair.ServerSeedHash,
+		ClientSeed:     pair.ClientSeed,
+		Nonce:          pair.Nonce,
+		Active:         pair.Active,
+		RevealedAt:     pair.RevealedAt,
+		CreatedAt:      pair.CreatedAt,
+	}
+}
+
+func toDomainSeedPair(dbPair *DBSeedPair) *fairness.SeedPair {
+	return &fairness.SeedPair{
+		ID:             dbPair.ID,
+		UserID:         dbPair.UserID,
+		ServerSeed:     dbPair.ServerSeed,
+		ServerSeedHash: dbPair.ServerSeedHash,
+		ClientSeed:     dbPair.ClientSeed,
+		Nonce:          dbPair.Nonce,
+		Active:         dbPair.Active,
+		RevealedAt:     dbPair.RevealedAt,
+		CreatedAt:      dbPair.CreatedAt,
+	}
+}
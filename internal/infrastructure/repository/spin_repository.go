@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/domain/money"
 	"gambling/internal/domain/spin"
 	"time"
 
@@ -28,6 +31,18 @@ func (r *SpinRepository) Create(result *spin.Result) error {
 	return nil
 }
 
+// GetByID возвращает результат спина по ID
+func (r *SpinRepository) GetByID(id uint) (*spin.Result, error) {
+	var dbResult DBSpinResult
+	if err := r.db.First(&dbResult, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, spin.ErrResultNotFound
+		}
+		return nil, err
+	}
+	return toDomainSpinResult(&dbResult), nil
+}
+
 // GetByUserID возвращает историю спинов пользователя
 func (r *SpinRepository) GetByUserID(userID uint, limit int) ([]*spin.Result, error) {
 	var dbResults []DBSpinResult
@@ -46,18 +61,71 @@ func (r *SpinRepository) GetByUserID(userID uint, limit int) ([]*spin.Result, er
 	return result, nil
 }
 
+// Find возвращает страницу истории спинов, начиная с последних
+// Страницы выбираются по ключу (created_at, id) по индексу (user_id, created_at, id)
+func (r *SpinRepository) Find(q spin.Query) (*spin.Page, error) {
+	query := r.db.Where("user_id = ?", q.UserID)
+	switch q.Outcome {
+	case spin.OutcomeWin:
+		query = query.Where("is_win")
+	case spin.OutcomeLoss:
+		query = query.Where("NOT is_win")
+	}
+	if !q.MinBet.IsZero() {
+		query = query.Where("bet_amount >= ?", q.MinBet.Amount())
+	}
+	if !q.MaxBet.IsZero() {
+		query = query.Where("bet_amount <= ?", q.MaxBet.Amount())
+	}
+	if !q.From.IsZero() {
+		query = query.Where("created_at >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		query = query.Where("created_at < ?", q.To)
+	}
+	if q.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", time.UnixMicro(q.After.Key), q.After.ID)
+	}
+
+	// Лишняя запись показывает, что за страницей есть продолжение
+	var dbResults []DBSpinResult
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(q.Limit + 1).Find(&dbResults).Error; err != nil {
+		return nil, err
+	}
+
+	page := &spin.Page{}
+	if len(dbResults) > q.Limit {
+		dbResults = dbResults[:q.Limit]
+		next := toDomainSpinResult(&dbResults[len(dbResults)-1]).Cursor()
+		page.Next = &next
+	}
+	page.Results = make([]*spin.Result, len(dbResults))
+	for i, dbResult := range dbResults {
+		page.Results[i] = toDomainSpinResult(&dbResult)
+	}
+	return page, nil
+}
+
 // DBSpinResult представляет модель БД для результата спина
 type DBSpinResult struct {
-	ID        uint           `gorm:"primaryKey"`
-	UserID    uint           `gorm:"not null;index"`
-	BetAmount float64        `gorm:"not null;type:decimal(15,2)"`
-	WinAmount float64        `gorm:"not null;type:decimal(15,2)"`
-	Reel1     int            `gorm:"not null"`
-	Reel2     int            `gorm:"not null"`
-	Reel3     int            `gorm:"not null"`
-	IsWin     bool           `gorm:"not null"`
-	CreatedAt time.Time      `gorm:"autoCreateTime"`
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID               uint    `gorm:"primaryKey"`
+	UserID           uint    `gorm:"not null;index"`
+	GameID           string  `gorm:"not null;size:32;default:classic;index"`
+	RoundID          *string `gorm:"type:uuid;uniqueIndex"` // NULL у спинов до появления раундов
+	BetAmount        int64   `gorm:"not null;type:bigint"`  // Суммы хранятся в минорных единицах
+	WinAmount        int64   `gorm:"not null;type:bigint"`
+	JackpotAmount    int64   `gorm:"not null;type:bigint;default:0"`
+	Currency         string  `gorm:"not null;size:3;default:RUB"`
+	Outcome          string  `gorm:"not null;type:jsonb"` // Исход раунда в формате игры
+	IsWin            bool    `gorm:"not null"`
+	PaytableVersion  string  `gorm:"not null;size:32;default:classic-1"`
+	SeedPairID       *uint   `gorm:"index"`
+	Nonce            uint64  `gorm:"not null;default:0"`
+	TriggerSpinID    *uint   `gorm:"index"` // NULL у платных раундов
+	WinMultiplierNum *int64  // Коэффициент бонуса бесплатного спина; NULL у платных раундов
+	WinMultiplierDen *int64
+	CreatedAt        time.Time      `gorm:"autoCreateTime"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
 
 func (DBSpinResult) TableName() string {
@@ -65,30 +133,83 @@ func (DBSpinResult) TableName() string {
 }
 
 func toDBSpinResult(result *spin.Result) *DBSpinResult {
-	return &DBSpinResult{
-		ID:        result.ID,
-		UserID:    result.UserID,
-		BetAmount: result.BetAmount,
-		WinAmount: result.WinAmount,
-		Reel1:     result.Reel1,
-		Reel2:     result.Reel2,
-		Reel3:     result.Reel3,
-		IsWin:     result.IsWin,
-		CreatedAt: result.CreatedAt,
+	dbResult := &DBSpinResult{
+		ID:              result.ID,
+		UserID:          result.UserID,
+		GameID:          result.GameID,
+		RoundID:         nullableRoundID(result.RoundID),
+		BetAmount:       result.BetAmount.Amount(),
+		WinAmount:       result.WinAmount.Amount(),
+		JackpotAmount:   result.JackpotAmount.Amount(),
+		Currency:        string(result.BetAmount.Currency()),
+		Outcome:         string(result.Outcome),
+		IsWin:           result.IsWin,
+		PaytableVersion: result.PaytableVersion,
+		SeedPairID:      nullableID(result.SeedPairID),
+		Nonce:           result.Nonce,
+		TriggerSpinID:   nullableID(result.TriggerSpinID),
+		CreatedAt:       result.CreatedAt,
+	}
+	if !result.WinMultiplier.IsZero() {
+		num, den := result.WinMultiplier.Num(), result.WinMultiplier.Den()
+		dbResult.WinMultiplierNum, dbResult.WinMultiplierDen = &num, &den
 	}
+	return dbResult
 }
 
 func toDomainSpinResult(dbResult *DBSpinResult) *spin.Result {
-	return &spin.Result{
-		ID:        dbResult.ID,
-		UserID:    dbResult.UserID,
-		BetAmount: dbResult.BetAmount,
-		WinAmount: dbResult.WinAmount,
-		Reel1:     dbResult.Reel1,
-		Reel2:     dbResult.Reel2,
-		Reel3:     dbResult.Reel3,
-		IsWin:     dbResult.IsWin,
-		CreatedAt: dbResult.CreatedAt,
+	currency := money.Currency(dbResult.Currency)
+	result := &spin.Result{
+		ID:              dbResult.ID,
+		UserID:          dbResult.UserID,
+		GameID:          dbResult.GameID,
+		RoundID:         valueOfRoundID(dbResult.RoundID),
+		BetAmount:       money.New(dbResult.BetAmount, currency),
+		WinAmount:       money.New(dbResult.WinAmount, currency),
+		JackpotAmount:   money.New(dbResult.JackpotAmount, currency),
+		Outcome:         json.RawMessage(dbResult.Outcome),
+		IsWin:           dbResult.IsWin,
+		PaytableVersion: dbResult.PaytableVersion,
+		SeedPairID:      valueOfID(dbResult.SeedPairID),
+		Nonce:           dbResult.Nonce,
+		TriggerSpinID:   valueOfID(dbResult.TriggerSpinID),
+		CreatedAt:       dbResult.CreatedAt,
+	}
+	if dbResult.WinMultiplierNum != nil && dbResult.WinMultiplierDen != nil {
+		// Некорректный коэффициент не пройдет CHECK в БД, поэтому ошибку можно не обрабатывать
+		result.WinMultiplier, _ = spin.NewMultiplier(*dbResult.WinMultiplierNum, *dbResult.WinMultiplierDen)
+	}
+	return result
+}
+
+// nullableID преобразует нулевой ID в NULL для необязательных внешних ключей
+func nullableID(id uint) *uint {
+	if id == 0 {
+		return nil
 	}
+	return &id
 }
 
+// valueOfID преобразует NULL во внешнем ключе в нулевой ID
+func valueOfID(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// nullableRoundID преобразует пустой идентификатор раунда в NULL
+func nullableRoundID(roundID string) *string {
+	if roundID == "" {
+		return nil
+	}
+	return &roundID
+}
+
+// valueOfRoundID преобразует NULL в пустой идентификатор раунда
+func valueOfRoundID(roundID *string) string {
+	if roundID == nil {
+		return ""
+	}
+	return *roundID
+}
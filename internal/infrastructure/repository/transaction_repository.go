@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/money"
 	"gambling/internal/domain/transaction"
 	"time"
 
@@ -17,15 +19,23 @@ func NewTransactionRepository(db *gorm.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
-// Create создает новую транзакцию
+// Create создает новую транзакцию и отражает ее проводками в главной книге
+// Запись транзакции и проводки сохраняются вместе или не сохраняются вовсе
 func (r *TransactionRepository) Create(tx *transaction.Transaction) error {
-	dbTx := toDBTransaction(tx)
-	if err := r.db.Create(dbTx).Error; err != nil {
-		return err
-	}
-	tx.ID = dbTx.ID
-	tx.CreatedAt = dbTx.CreatedAt
-	return nil
+	return r.db.Transaction(func(db *gorm.DB) error {
+		dbTx := toDBTransaction(tx)
+		if err := db.Create(dbTx).Error; err != nil {
+			return err
+		}
+		tx.ID = dbTx.ID
+		tx.CreatedAt = dbTx.CreatedAt
+
+		entries, err := ledger.Postings(tx)
+		if err != nil {
+			return err
+		}
+		return NewLedgerRepository(db).Post(entries)
+	})
 }
 
 // GetByUserID возвращает все транзакции пользователя
@@ -46,17 +56,138 @@ func (r *TransactionRepository) GetByUserID(userID uint, limit int) ([]*transact
 	return result, nil
 }
 
+// GetByRoundID возвращает транзакции игрового раунда в порядке создания
+func (r *TransactionRepository) GetByRoundID(roundID string) ([]*transaction.Transaction, error) {
+	var dbTxs []DBTransaction
+	if err := r.db.Where("round_id = ?", roundID).Order("id ASC").Find(&dbTxs).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*transaction.Transaction, len(dbTxs))
+	for i, dbTx := range dbTxs {
+		result[i] = toDomainTransaction(&dbTx)
+	}
+	return result, nil
+}
+
+// GetChainByUserID возвращает все транзакции пользователя в порядке создания
+// Порядок задается ID: внутри одной единицы работы время создания может совпадать
+func (r *TransactionRepository) GetChainByUserID(userID uint) ([]*transaction.Transaction, error) {
+	var dbTxs []DBTransaction
+	if err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&dbTxs).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*transaction.Transaction, len(dbTxs))
+	for i, dbTx := range dbTxs {
+		result[i] = toDomainTransaction(&dbTx)
+	}
+	return result, nil
+}
+
+// TotalsSince возвращает суммы транзакций пользователя по типам, созданных начиная с since
+func (r *TransactionRepository) TotalsSince(userID uint, since time.Time) (map[transaction.Type]money.Money, error) {
+	var rows []struct {
+		Type     string
+		Currency string
+		Total    int64
+	}
+	err := r.db.Model(&DBTransaction{}).
+		Select("type, currency, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Group("type, currency").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[transaction.Type]money.Money, len(rows))
+	for _, row := range rows {
+		totals[transaction.Type(row.Type)] = money.New(row.Total, money.Currency(row.Currency))
+	}
+	return totals, nil
+}
+
+// Find возвращает страницу истории транзакций
+// Страницы выбираются по ключу (keyset): условие на пару (ключ сортировки, id)
+// использует индексы (user_id, created_at, id) и (user_id, amount, id)
+func (r *TransactionRepository) Find(q transaction.Query) (*transaction.Page, error) {
+	query := r.db.Where("user_id = ?", q.UserID)
+	if len(q.Types) > 0 {
+		types := make([]string, len(q.Types))
+		for i, t := range q.Types {
+			types[i] = string(t)
+		}
+		query = query.Where("type IN ?", types)
+	}
+	if !q.From.IsZero() {
+		query = query.Where("created_at >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		query = query.Where("created_at < ?", q.To)
+	}
+
+	column, desc := "created_at", true
+	switch q.Sort {
+	case transaction.SortOldest:
+		desc = false
+	case transaction.SortAmountDesc:
+		column = "amount"
+	case transaction.SortAmountAsc:
+		column, desc = "amount", false
+	}
+
+	if q.After != nil {
+		var key interface{} = q.After.Key
+		if column == "created_at" {
+			key = time.UnixMicro(q.After.Key)
+		}
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		query = query.Where("("+column+", id) "+op+" (?, ?)", key, q.After.ID)
+	}
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+	query = query.Order(column + direction).Order("id" + direction)
+
+	// Лишняя запись показывает, что за страницей есть продолжение
+	var dbTxs []DBTransaction
+	if err := query.Limit(q.Limit + 1).Find(&dbTxs).Error; err != nil {
+		return nil, err
+	}
+
+	page := &transaction.Page{}
+	if len(dbTxs) > q.Limit {
+		dbTxs = dbTxs[:q.Limit]
+		last := toDomainTransaction(&dbTxs[len(dbTxs)-1])
+		next := last.CursorFor(q.Sort)
+		page.Next = &next
+	}
+	page.Transactions = make([]*transaction.Transaction, len(dbTxs))
+	for i, dbTx := range dbTxs {
+		page.Transactions[i] = toDomainTransaction(&dbTx)
+	}
+	return page, nil
+}
+
 // DBTransaction представляет модель БД для транзакции
 type DBTransaction struct {
-	ID            uint            `gorm:"primaryKey"`
-	UserID        uint            `gorm:"not null;index"`
-	Type          string          `gorm:"not null;type:varchar(20)"`
-	Amount        float64         `gorm:"not null;type:decimal(15,2)"`
-	BalanceBefore float64         `gorm:"not null;type:decimal(15,2)"`
-	BalanceAfter  float64         `gorm:"not null;type:decimal(15,2)"`
-	Description   string          `gorm:"size:255"`
-	CreatedAt     time.Time       `gorm:"autoCreateTime"`
-	DeletedAt     gorm.DeletedAt  `gorm:"index"`
+	ID            uint           `gorm:"primaryKey"`
+	UserID        uint           `gorm:"not null;index"`
+	RoundID       *string        `gorm:"type:uuid;index"` // NULL вне игрового раунда
+	Type          string         `gorm:"not null;type:varchar(20)"`
+	Amount        int64          `gorm:"not null;type:bigint"` // Суммы хранятся в минорных единицах
+	BalanceBefore int64          `gorm:"not null;type:bigint"`
+	BalanceAfter  int64          `gorm:"not null;type:bigint"`
+	Currency      string         `gorm:"not null;size:3;default:RUB"`
+	Description   string         `gorm:"size:255"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
 func (DBTransaction) TableName() string {
@@ -67,25 +198,28 @@ func toDBTransaction(tx *transaction.Transaction) *DBTransaction {
 	return &DBTransaction{
 		ID:            tx.ID,
 		UserID:        tx.UserID,
+		RoundID:       nullableRoundID(tx.RoundID),
 		Type:          string(tx.Type),
-		Amount:        tx.Amount,
-		BalanceBefore: tx.BalanceBefore,
-		BalanceAfter:  tx.BalanceAfter,
+		Amount:        tx.Amount.Amount(),
+		BalanceBefore: tx.BalanceBefore.Amount(),
+		BalanceAfter:  tx.BalanceAfter.Amount(),
+		Currency:      string(tx.Amount.Currency()),
 		Description:   tx.Description,
 		CreatedAt:     tx.CreatedAt,
 	}
 }
 
 func toDomainTransaction(dbTx *DBTransaction) *transaction.Transaction {
+	currency := money.Currency(dbTx.Currency)
 	return &transaction.Transaction{
 		ID:            dbTx.ID,
 		UserID:        dbTx.UserID,
+		RoundID:       valueOfRoundID(dbTx.RoundID),
 		Type:          transaction.Type(dbTx.Type),
-		Amount:        dbTx.Amount,
-		BalanceBefore: dbTx.BalanceBefore,
-		BalanceAfter:  dbTx.BalanceAfter,
+		Amount:        money.New(dbTx.Amount, currency),
+		BalanceBefore: money.New(dbTx.BalanceBefore, currency),
+		BalanceAfter:  money.New(dbTx.BalanceAfter, currency),
 		Description:   dbTx.Description,
 		CreatedAt:     dbTx.CreatedAt,
 	}
 }
-
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/idempotency"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/ledger"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/uow"
+	"gambling/internal/domain/user"
+	"gambling/internal/domain/withdrawal"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork реализует интерфейс uow.UnitOfWork поверх транзакций GORM
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork создает новую единицу работы
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do выполняет fn в одной транзакции БД
+// Если fn возвращает ошибку или паникует, транзакция откатывается
+func (u *UnitOfWork) Do(fn func(repos uow.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(newTxRepositories(tx))
+	})
+}
+
+// txRepositories содержит репозитории, привязанные к транзакции
+type txRepositories struct {
+	users        *UserRepository
+	transactions *TransactionRepository
+	spins        *SpinRepository
+	seeds        *SeedPairRepository
+	tokens       *RefreshTokenRepository
+	withdrawals  *WithdrawalRepository
+	ledger       *LedgerRepository
+	idempotency  *IdempotencyRepository
+	limits       *LimitRepository
+	exclusions   *ExclusionRepository
+	jackpots     *JackpotRepository
+}
+
+func newTxRepositories(tx *gorm.DB) *txRepositories {
+	return &txRepositories{
+		users:        NewUserRepository(tx),
+		transactions: NewTransactionRepository(tx),
+		spins:        NewSpinRepository(tx),
+		seeds:        NewSeedPairRepository(tx),
+		tokens:       NewRefreshTokenRepository(tx),
+		withdrawals:  NewWithdrawalRepository(tx),
+		ledger:       NewLedgerRepository(tx),
+		idempotency:  NewIdempotencyRepository(tx),
+		limits:       NewLimitRepository(tx),
+		exclusions:   NewExclusionRepository(tx),
+		jackpots:     NewJackpotRepository(tx),
+	}
+}
+
+func (r *txRepositories) Users() user.Repository {
+	return r.users
+}
+
+func (r *txRepositories) Transactions() transaction.Repository {
+	return r.transactions
+}
+
+func (r *txRepositories) Spins() spin.Repository {
+	return r.spins
+}
+
+func (r *txRepositories) Seeds() fairness.Repository {
+	return r.seeds
+}
+
+func (r *txRepositories) RefreshTokens() session.Repository {
+	return r.tokens
+}
+
+func (r *txRepositories) Withdrawals() withdrawal.Repository {
+	return r.withdrawals
+}
+
+func (r *txRepositories) Ledger() ledger.Repository {
+	return r.ledger
+}
+
+func (r *txRepositories) Idempotency() idempotency.Repository {
+	return r.idempotency
+}
+
+func (r *txRepositories) Limits() limit.Repository {
+	return r.limits
+}
+
+func (r *txRepositories) Exclusions() exclusion.Repository {
+	return r.exclusions
+}
+
+func (r *txRepositories) Jackpots() jackpot.Repository {
+	return r.jackpots
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"errors"
+	"gambling/internal/domain/money"
 	"gambling/internal/domain/user"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository реализует интерфейс user.Repository
@@ -49,6 +51,20 @@ func (r *UserRepository) GetByID(id uint) (*user.User, error) {
 	return toDomainModel(&dbUser), nil
 }
 
+// GetByIDForUpdate возвращает пользователя по ID с блокировкой строки (SELECT ... FOR UPDATE)
+// Блокировка действует до завершения транзакции, поэтому метод имеет смысл
+// только внутри UnitOfWork
+func (r *UserRepository) GetByIDForUpdate(id uint) (*user.User, error) {
+	var dbUser DBUser
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&dbUser, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, user.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return toDomainModel(&dbUser), nil
+}
+
 // GetByUsername возвращает пользователя по имени пользователя
 func (r *UserRepository) GetByUsername(username string) (*user.User, error) {
 	var dbUser DBUser
@@ -73,9 +89,64 @@ func (r *UserRepository) GetByEmail(email string) (*user.User, error) {
 	return toDomainModel(&dbUser), nil
 }
 
+// ListIDs возвращает ID пользователей больше afterID по возрастанию
+func (r *UserRepository) ListIDs(afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&DBUser{}).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 // UpdateBalance обновляет баланс пользователя
-func (r *UserRepository) UpdateBalance(userID uint, newBalance float64) error {
-	return r.db.Model(&DBUser{}).Where("id = ?", userID).Update("balance", newBalance).Error
+func (r *UserRepository) UpdateBalance(userID uint, newBalance money.Money) error {
+	return r.db.Model(&DBUser{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"balance":  newBalance.Amount(),
+		"currency": string(newBalance.Currency()),
+	}).Error
+}
+
+// UpdateRole обновляет роль пользователя
+func (r *UserRepository) UpdateRole(userID uint, role user.Role) error {
+	result := r.db.Model(&DBUser{}).Where("id = ?", userID).Update("role", string(role))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateExclusion сохраняет самоограничение пользователя; nil снимает его
+func (r *UserRepository) UpdateExclusion(userID uint, exclusion *user.Exclusion) error {
+	kind, startedAt, until := exclusionColumns(exclusion)
+	result := r.db.Model(&DBUser{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"exclusion_kind": kind,
+		"excluded_at":    startedAt,
+		"excluded_until": until,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdateFreeSpins сохраняет бонус бесплатных спинов пользователя; nil снимает его
+func (r *UserRepository) UpdateFreeSpins(userID uint, freeSpins *user.FreeSpins) error {
+	result := r.db.Model(&DBUser{}).Where("id = ?", userID).Updates(freeSpinsColumns(freeSpins).columns())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return user.ErrUserNotFound
+	}
+	return nil
 }
 
 // Update обновляет данные пользователя
@@ -87,42 +158,130 @@ func (r *UserRepository) Update(u *user.User) error {
 // DBUser представляет модель БД для пользователя
 // Это техническая деталь инфраструктуры, отделенная от домена
 type DBUser struct {
-	ID           uint           `gorm:"primaryKey"`
-	Username     string         `gorm:"uniqueIndex;not null;size:50"`
-	Email        string         `gorm:"uniqueIndex;not null;size:100"`
-	PasswordHash string         `gorm:"not null;size:255"`
-	Balance      float64        `gorm:"not null;default:0;type:decimal(15,2)"`
-	CreatedAt    time.Time      `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	ID            uint    `gorm:"primaryKey"`
+	Username      string  `gorm:"uniqueIndex;not null;size:50"`
+	Email         string  `gorm:"uniqueIndex;not null;size:100"`
+	PasswordHash  string  `gorm:"not null;size:255"`
+	Balance       int64   `gorm:"not null;default:0;type:bigint"` // Кэш остатка кошелька в главной книге, в минорных единицах
+	Currency      string  `gorm:"not null;size:3;default:RUB"`
+	Role          string  `gorm:"not null;size:20;default:player"`
+	ExclusionKind *string `gorm:"size:20"` // NULL, если самоограничение не установлено
+	ExcludedAt    *time.Time
+	ExcludedUntil *time.Time     // NULL у бессрочного самоисключения
+	FreeSpins     DBFreeSpins    `gorm:"embedded;embeddedPrefix:free_spins_"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
 func (DBUser) TableName() string {
 	return "users"
 }
 
+// DBFreeSpins представляет колонки бонуса бесплатных спинов в таблице users
+// Все колонки NULL, если бонуса нет; суммы в валюте пользователя, в минорных единицах
+type DBFreeSpins struct {
+	GameID        *string `gorm:"size:32"`
+	Bet           *int64
+	Remaining     *int
+	MultiplierNum *int64
+	MultiplierDen *int64
+	TriggerSpinID *uint
+	Won           *int64
+	AwardedAt     *time.Time
+}
+
+// columns возвращает значения колонок для частичного обновления пользователя
+func (f DBFreeSpins) columns() map[string]interface{} {
+	return map[string]interface{}{
+		"free_spins_game_id":         f.GameID,
+		"free_spins_bet":             f.Bet,
+		"free_spins_remaining":       f.Remaining,
+		"free_spins_multiplier_num":  f.MultiplierNum,
+		"free_spins_multiplier_den":  f.MultiplierDen,
+		"free_spins_trigger_spin_id": f.TriggerSpinID,
+		"free_spins_won":             f.Won,
+		"free_spins_awarded_at":      f.AwardedAt,
+	}
+}
+
 // toDBModel преобразует доменную сущность в модель БД
 func toDBModel(u *user.User) *DBUser {
-	return &DBUser{
+	dbUser := &DBUser{
 		ID:           u.ID,
 		Username:     u.Username,
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
-		Balance:      u.Balance,
+		Balance:      u.Balance.Amount(),
+		Currency:     string(u.Balance.Currency()),
+		Role:         string(u.Role),
 	}
+	dbUser.ExclusionKind, dbUser.ExcludedAt, dbUser.ExcludedUntil = exclusionColumns(u.Exclusion)
+	dbUser.FreeSpins = freeSpinsColumns(u.FreeSpins)
+	return dbUser
 }
 
 // toDomainModel преобразует модель БД в доменную сущность
 func toDomainModel(dbUser *DBUser) *user.User {
-	return &user.User{
+	u := &user.User{
 		ID:           dbUser.ID,
 		Username:     dbUser.Username,
 		Email:        dbUser.Email,
 		PasswordHash: dbUser.PasswordHash,
-		Balance:      dbUser.Balance,
+		Balance:      money.New(dbUser.Balance, money.Currency(dbUser.Currency)),
+		Role:         user.Role(dbUser.Role),
 		CreatedAt:    dbUser.CreatedAt,
 		UpdatedAt:    dbUser.UpdatedAt,
 		DeletedAt:    dbUser.DeletedAt,
 	}
+	if dbUser.ExclusionKind != nil && dbUser.ExcludedAt != nil {
+		u.Exclusion = &user.Exclusion{
+			Kind:      user.ExclusionKind(*dbUser.ExclusionKind),
+			StartedAt: *dbUser.ExcludedAt,
+			Until:     dbUser.ExcludedUntil,
+		}
+	}
+	if f := dbUser.FreeSpins; f.GameID != nil && f.Bet != nil && f.Remaining != nil &&
+		f.MultiplierNum != nil && f.MultiplierDen != nil && f.TriggerSpinID != nil && f.Won != nil && f.AwardedAt != nil {
+		currency := money.Currency(dbUser.Currency)
+		u.FreeSpins = &user.FreeSpins{
+			GameID:        *f.GameID,
+			Bet:           money.New(*f.Bet, currency),
+			Remaining:     *f.Remaining,
+			MultiplierNum: *f.MultiplierNum,
+			MultiplierDen: *f.MultiplierDen,
+			TriggerSpinID: *f.TriggerSpinID,
+			Won:           money.New(*f.Won, currency),
+			AwardedAt:     *f.AwardedAt,
+		}
+	}
+	return u
+}
+
+// exclusionColumns раскладывает самоограничение по колонкам таблицы users
+func exclusionColumns(exclusion *user.Exclusion) (*string, *time.Time, *time.Time) {
+	if exclusion == nil {
+		return nil, nil, nil
+	}
+	kind, startedAt := string(exclusion.Kind), exclusion.StartedAt
+	return &kind, &startedAt, exclusion.Until
 }
 
+// freeSpinsColumns раскладывает бонус бесплатных спинов по колонкам таблицы users
+func freeSpinsColumns(freeSpins *user.FreeSpins) DBFreeSpins {
+	if freeSpins == nil {
+		return DBFreeSpins{}
+	}
+	f := *freeSpins
+	bet, won := f.Bet.Amount(), f.Won.Amount()
+	return DBFreeSpins{
+		GameID:        &f.GameID,
+		Bet:           &bet,
+		Remaining:     &f.Remaining,
+		MultiplierNum: &f.MultiplierNum,
+		MultiplierDen: &f.MultiplierDen,
+		TriggerSpinID: &f.TriggerSpinID,
+		Won:           &won,
+		AwardedAt:     &f.AwardedAt,
+	}
+}
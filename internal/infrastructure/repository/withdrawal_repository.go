@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"errors"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/withdrawal"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WithdrawalRepository реализует интерфейс withdrawal.Repository
+type WithdrawalRepository struct {
+	db *gorm.DB
+}
+
+// NewWithdrawalRepository создает новый репозиторий заявок на вывод
+func NewWithdrawalRepository(db *gorm.DB) *WithdrawalRepository {
+	return &WithdrawalRepository{db: db}
+}
+
+// Create создает новую заявку на вывод
+func (r *WithdrawalRepository) Create(w *withdrawal.Withdrawal) error {
+	dbWithdrawal := toDBWithdrawal(w)
+	if err := r.db.Create(dbWithdrawal).Error; err != nil {
+		return err
+	}
+	w.ID = dbWithdrawal.ID
+	w.CreatedAt = dbWithdrawal.CreatedAt
+	return nil
+}
+
+// GetByIDForUpdate возвращает заявку по ID с блокировкой строки
+// Блокировка действует до завершения транзакции, поэтому метод имеет смысл только внутри UnitOfWork
+func (r *WithdrawalRepository) GetByIDForUpdate(id uint) (*withdrawal.Withdrawal, error) {
+	var dbWithdrawal DBWithdrawal
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&dbWithdrawal, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, withdrawal.ErrWithdrawalNotFound
+		}
+		return nil, err
+	}
+	return toDomainWithdrawal(&dbWithdrawal), nil
+}
+
+// Update сохраняет решение по заявке
+func (r *WithdrawalRepository) Update(w *withdrawal.Withdrawal) error {
+	return r.db.Model(&DBWithdrawal{}).Where("id = ?", w.ID).Updates(map[string]interface{}{
+		"status":      string(w.Status),
+		"reviewed_by": w.ReviewedBy,
+		"reason":      w.Reason,
+		"reviewed_at": w.ReviewedAt,
+	}).Error
+}
+
+// GetByUserID возвращает заявки пользователя, начиная с последних
+func (r *WithdrawalRepository) GetByUserID(userID uint, limit int) ([]*withdrawal.Withdrawal, error) {
+	return r.find(r.db.Where("user_id = ?", userID).Order("created_at DESC"), limit)
+}
+
+// GetByStatus возвращает заявки в заданном состоянии, начиная с самых старых
+func (r *WithdrawalRepository) GetByStatus(status withdrawal.Status, limit int) ([]*withdrawal.Withdrawal, error) {
+	return r.find(r.db.Where("status = ?", string(status)).Order("created_at ASC"), limit)
+}
+
+func (r *WithdrawalRepository) find(query *gorm.DB, limit int) ([]*withdrawal.Withdrawal, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var dbWithdrawals []DBWithdrawal
+	if err := query.Find(&dbWithdrawals).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]*withdrawal.Withdrawal, len(dbWithdrawals))
+	for i, dbWithdrawal := range dbWithdrawals {
+		result[i] = toDomainWithdrawal(&dbWithdrawal)
+	}
+	return result, nil
+}
+
+// DBWithdrawal представляет модель БД для заявки на вывод
+type DBWithdrawal struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index"`
+	Amount      int64  `gorm:"not null;type:bigint"` // В минорных единицах
+	Currency    string `gorm:"not null;size:3"`
+	Destination string `gorm:"not null;size:100"`
+	Status      string `gorm:"not null;size:20;index"`
+	ReviewedBy  *uint
+	Reason      string    `gorm:"size:255"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ReviewedAt  *time.Time
+}
+
+func (DBWithdrawal) TableName() string {
+	return "withdrawals"
+}
+
+func toDBWithdrawal(w *withdrawal.Withdrawal) *DBWithdrawal {
+	return &DBWithdrawal{
+		ID:          w.ID,
+		UserID:      w.UserID,
+		Amount:      w.Amount.Amount(),
+		Currency:    string(w.Amount.Currency()),
+		Destination: w.Destination,
+		Status:      string(w.Status),
+		ReviewedBy:  w.ReviewedBy,
+		Reason:      w.Reason,
+		CreatedAt:   w.CreatedAt,
+		ReviewedAt:  w.ReviewedAt,
+	}
+}
+
+func toDomainWithdrawal(dbWithdrawal *DBWithdrawal) *withdrawal.Withdrawal {
+	return &withdrawal.Withdrawal{
+		ID:          dbWithdrawal.ID,
+		UserID:      dbWithdrawal.UserID,
+		Amount:      money.New(dbWithdrawal.Amount, money.Currency(dbWithdrawal.Currency)),
+		Destination: dbWithdrawal.Destination,
+		Status:      withdrawal.Status(dbWithdrawal.Status),
+		ReviewedBy:  dbWithdrawal.ReviewedBy,
+		Reason:      dbWithdrawal.Reason,
+		CreatedAt:   dbWithdrawal.CreatedAt,
+		ReviewedAt:  dbWithdrawal.ReviewedAt,
+	}
+}
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/user"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinSecretLength - минимальная длина секрета HS256 в байтах
+const MinSecretLength = 32
+
+// ErrWeakSecret возвращается, если секрет подписи слишком короткий
+var ErrWeakSecret = fmt.Errorf("секрет подписи токенов должен быть не короче %d байт", MinSecretLength)
+
+// jwtHeader - заголовок всех выпускаемых токенов; другие алгоритмы не принимаются
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// JWTSigner реализует session.TokenSigner: JWT с подписью HMAC-SHA256
+type JWTSigner struct {
+	secret []byte
+	issuer string
+}
+
+// NewJWTSigner создает подписчик токенов с заданным секретом и издателем
+func NewJWTSigner(secret []byte, issuer string) (*JWTSigner, error) {
+	if len(secret) < MinSecretLength {
+		return nil, ErrWeakSecret
+	}
+	return &JWTSigner{
+		secret: secret,
+		issuer: issuer,
+	}, nil
+}
+
+// jwtClaims - зарегистрированные поля JWT (RFC 7519)
+type jwtClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Sign выпускает подписанный токен
+func (s *JWTSigner) Sign(claims session.Claims) (string, error) {
+	payload, err := json.Marshal(jwtClaims{
+		Issuer:    s.issuer,
+		Subject:   strconv.FormatUint(uint64(claims.UserID), 10),
+		Role:      string(claims.Role),
+		IssuedAt:  claims.IssuedAt.Unix(),
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + s.sign(signingInput), nil
+}
+
+// Parse проверяет подпись, издателя и срок действия токена
+func (s *JWTSigner) Parse(token string) (*session.Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, session.ErrInvalidToken
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(signingInput))) {
+		return nil, session.ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, session.ErrInvalidToken
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, session.ErrInvalidToken
+	}
+	if claims.Issuer != s.issuer {
+		return nil, session.ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || userID == 0 {
+		return nil, session.ErrInvalidToken
+	}
+
+	role, err := user.ParseRole(claims.Role)
+	if err != nil {
+		return nil, session.ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, session.ErrTokenExpired
+	}
+
+	return &session.Claims{
+		UserID:    uint(userID),
+		Role:      role,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *JWTSigner) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
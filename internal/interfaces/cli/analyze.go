@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"gambling/internal/application/use_case/analysis"
+	"gambling/internal/config"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"io"
+	"os"
+)
+
+// Analyze выполняет команду `gambling analyze`: точный расчет PAR sheet для таблицы выплат игры
+// База данных для команды не нужна
+func Analyze(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	gameID := fs.String("game", spin.ClassicGameID, gameUsage)
+	paytablePath := fs.String("paytable", "", paytableUsage)
+	betStr := fs.String("bet", "1.00", "ставка на раунд, для которой считаются выплаты с учетом округления")
+	format := fs.String("format", "text", "формат вывода: text, csv, markdown")
+	output := fs.String("output", "", "файл для экспорта (по умолчанию стандартный вывод)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	render, ok := parSheetRenderers[*format]
+	if !ok {
+		return fmt.Errorf("неизвестный формат %q: ожидается text, csv или markdown", *format)
+	}
+
+	bet, err := money.Parse(*betStr, money.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("неверная ставка %q: %w", *betStr, err)
+	}
+
+	g, err := loadGame(cfg, *gameID, *paytablePath)
+	if err != nil {
+		return err
+	}
+
+	var sheet *analysis.ParSheet
+	switch service := g.(type) {
+	case *spin.Service:
+		sheet, err = analysis.NewAnalyzeUseCase(service).Execute(analysis.AnalyzeCommand{BetAmount: bet})
+	case *spin.VideoService:
+		sheet, err = analysis.NewAnalyzeVideoUseCase(service).Execute(analysis.AnalyzeCommand{BetAmount: bet})
+	default:
+		return fmt.Errorf("анализ игры %q не поддерживается", g.ID())
+	}
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return render(w, sheet)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"gambling/internal/config"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/rng"
+	"gambling/internal/domain/spin"
+	"gambling/internal/infrastructure/paytable"
+	"io"
+	"strings"
+)
+
+// gameUsage - описание флага -game офлайн-команд
+const gameUsage = "игра: classic (классический автомат) или video (видеослот 5x3)"
+
+// paytableUsage - описание флага -paytable офлайн-команд
+const paytableUsage = "путь к JSON-таблице выплат игры (по умолчанию PAYTABLE_PATH или VIDEO_PAYTABLE_PATH, иначе встроенная)"
+
+// loadGame загружает математику игры gameID
+// Если path не задан, берется таблица этой игры из конфигурации
+func loadGame(cfg *config.Config, gameID, path string) (game.Game, error) {
+	switch gameID {
+	case spin.ClassicGameID:
+		if path == "" {
+			path = cfg.PaytablePath
+		}
+		p, err := paytable.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		return spin.NewService(p, rng.NewCryptoSource()), nil
+	case spin.VideoGameID:
+		if path == "" {
+			path = cfg.VideoPaytablePath
+		}
+		p, err := paytable.LoadVideo(path)
+		if err != nil {
+			return nil, err
+		}
+		return spin.NewVideoService(p), nil
+	default:
+		return nil, fmt.Errorf("неизвестная игра %q: ожидается %s или %s", gameID, spin.ClassicGameID, spin.VideoGameID)
+	}
+}
+
+// writeVideoGrid выводит окно видеослота, отмечая звездочкой символы выигравших линий
+func writeVideoGrid(w io.Writer, outcome *spin.VideoOutcome) {
+	var winning [spin.VideoRowCount][spin.VideoReelCount]bool
+	for _, win := range outcome.Lines {
+		for reel, row := range win.Rows {
+			winning[row][reel] = true
+		}
+	}
+
+	for row, symbols := range outcome.Grid {
+		cells := make([]string, len(symbols))
+		for reel, symbol := range symbols {
+			mark := " "
+			if winning[row][reel] {
+				mark = "*"
+			}
+			cells[reel] = fmt.Sprintf("%d%s", symbol, mark)
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(cells, " "))
+	}
+	for _, win := range outcome.Lines {
+		fmt.Fprintf(w, "  Линия %d: %d x %d, x%s = %s\n", win.Line, win.Count, win.Symbol, win.Multiplier, win.Win.Format())
+	}
+	if outcome.Scatter != nil {
+		fmt.Fprintf(w, "  Scatter: %s\n", scatterSummary(outcome.Scatter))
+	}
+}
+
+// scatterSummary описывает выплату scatter и выигранные бесплатные спины одной строкой
+func scatterSummary(win *spin.ScatterWin) string {
+	summary := fmt.Sprintf("%d x %d, x%s = %s", win.Count, win.Symbol, win.Multiplier, win.Win.Format())
+	if win.FreeSpins > 0 {
+		summary += fmt.Sprintf(", бесплатных спинов: %d", win.FreeSpins)
+	}
+	return summary
+}
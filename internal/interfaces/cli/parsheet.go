@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"gambling/internal/application/use_case/analysis"
+	"gambling/internal/domain/money"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+)
+
+// ratPrecision - число знаков после запятой при выводе вероятностей и долей
+const ratPrecision = 8
+
+// parSheetRenderers сопоставляет формат вывода с функцией отрисовки PAR sheet
+var parSheetRenderers = map[string]func(io.Writer, *analysis.ParSheet) error{
+	"text":     writeParSheetText,
+	"csv":      writeParSheetCSV,
+	"markdown": writeParSheetMarkdown,
+	"md":       writeParSheetMarkdown,
+}
+
+// parSheetSummary возвращает сводные показатели PAR sheet в виде пар "название - значение"
+func parSheetSummary(sheet *analysis.ParSheet) [][2]string {
+	maxWinMultiplier := new(big.Rat).SetFrac64(sheet.MaxWin.Amount(), sheet.BetAmount.Amount())
+	rows := [][2]string{
+		{"Версия таблицы выплат", sheet.PaytableVersion},
+		{"Ставка", sheet.BetAmount.Format()},
+		{"Число исходов (с учетом весов)", sheet.TotalWeight.String()},
+		{"RTP, %", percent(sheet.RTP)},
+		{"RTP (точная дробь)", sheet.RTP.RatString()},
+		{"Частота выигрыша, %", percent(sheet.HitFrequency)},
+		{"Выигрыш в среднем раз в N спинов", oneIn(sheet.HitFrequency)},
+		{"Дисперсия", strconv.FormatFloat(sheet.Variance, 'f', 4, 64)},
+		{"Стандартное отклонение", strconv.FormatFloat(sheet.StdDev, 'f', 4, 64)},
+		{"Индекс волатильности (90%)", strconv.FormatFloat(sheet.VolatilityIndex, 'f', 4, 64)},
+		{"Максимальный выигрыш", fmt.Sprintf("%s (x%s)", sheet.MaxWin.Format(), trimRat(maxWinMultiplier))},
+		{"Вероятность максимального выигрыша", sheet.MaxWinProbability.FloatString(12)},
+		{"Максимальный выигрыш раз в N спинов", oneIn(sheet.MaxWinProbability)},
+	}
+	if sheet.Lines > 0 {
+		// Комбинации линейной игры считаются для одной линии, коэффициент - к ставке на линию
+		lineBet := sheet.BetAmount.MulRatio(1, int64(sheet.Lines), money.RoundDown)
+		rows = slices.Insert(rows, 2, [2]string{
+			"Линий выплат / ставка на линию", fmt.Sprintf("%d / %s", sheet.Lines, lineBet.Format()),
+		})
+	}
+	if sheet.JackpotProbability != nil {
+		rows = append(rows,
+			[2]string{"Вероятность джекпота", sheet.JackpotProbability.FloatString(12)},
+			[2]string{"Джекпот раз в N спинов", oneIn(sheet.JackpotProbability)},
+		)
+	}
+	if sheet.FreeSpinsProbability != nil {
+		rows = append(rows,
+			[2]string{"Вероятность бесплатных спинов", sheet.FreeSpinsProbability.FloatString(12)},
+			[2]string{"Бесплатные спины раз в N спинов", oneIn(sheet.FreeSpinsProbability)},
+			[2]string{"Бесплатных спинов за бонус в среднем", sheet.FreeSpinsPerBonus.FloatString(4)},
+			[2]string{"Вклад бесплатных спинов в RTP, %", percent(sheet.FreeSpinsRTP)},
+		)
+	}
+	return rows
+}
+
+func writeParSheetText(w io.Writer, sheet *analysis.ParSheet) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PAR SHEET")
+	for _, row := range parSheetSummary(sheet) {
+		fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Комбинация\tВероятность\tВыигрыш\tКоэффициент\tВклад в RTP, %")
+	for _, c := range sheet.Combinations {
+		fmt.Fprintf(tw, "%s\t%s\t%s\tx%s\t%s\n",
+			c.Label,
+			c.Probability.FloatString(ratPrecision),
+			c.WinAmount.Format(),
+			trimRat(c.Multiplier),
+			percent(c.Contribution),
+		)
+	}
+
+	return tw.Flush()
+}
+
+func writeParSheetCSV(w io.Writer, sheet *analysis.ParSheet) error {
+	cw := csv.NewWriter(w)
+
+	// Первая секция - сводные показатели, вторая (после пустой строки) - комбинации
+	_ = cw.Write([]string{"metric", "value"})
+	for _, row := range parSheetSummary(sheet) {
+		_ = cw.Write(row[:])
+	}
+	cw.Flush()
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	_ = cw.Write([]string{"combination", "weight", "probability", "win_amount", "multiplier", "rtp_contribution"})
+	for _, c := range sheet.Combinations {
+		_ = cw.Write([]string{
+			c.Label,
+			c.Weight.String(),
+			c.Probability.FloatString(12),
+			c.WinAmount.String(),
+			trimRat(c.Multiplier),
+			c.Contribution.FloatString(12),
+		})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func writeParSheetMarkdown(w io.Writer, sheet *analysis.ParSheet) error {
+	fmt.Fprintf(w, "# PAR sheet: %s\n\n", sheet.PaytableVersion)
+
+	fmt.Fprintln(w, "| Показатель | Значение |")
+	fmt.Fprintln(w, "|---|---|")
+	for _, row := range parSheetSummary(sheet) {
+		fmt.Fprintf(w, "| %s | %s |\n", row[0], row[1])
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "## Выигрышные комбинации")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Комбинация | Вес | Вероятность | Выигрыш | Коэффициент | Вклад в RTP, % |")
+	fmt.Fprintln(w, "|---|---:|---:|---:|---:|---:|")
+	for _, c := range sheet.Combinations {
+		_, err := fmt.Fprintf(w, "| %s | %s | %s | %s | x%s | %s |\n",
+			c.Label,
+			c.Weight.String(),
+			c.Probability.FloatString(ratPrecision),
+			c.WinAmount.Format(),
+			trimRat(c.Multiplier),
+			percent(c.Contribution),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// percent возвращает долю в процентах с фиксированной точностью
+func percent(r *big.Rat) string {
+	return new(big.Rat).Mul(r, big.NewRat(100, 1)).FloatString(ratPrecision - 2)
+}
+
+// oneIn возвращает величину 1/p, например "1 из 8000000" для редких событий
+func oneIn(p *big.Rat) string {
+	if p.Sign() == 0 {
+		return "-"
+	}
+	return new(big.Rat).Inv(p).FloatString(2)
+}
+
+// trimRat возвращает десятичную запись дроби без лишних нулей
+func trimRat(r *big.Rat) string {
+	s := r.FloatString(4)
+	for len(s) > 0 && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if len(s) > 0 && s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"gambling/internal/application/use_case/analysis"
+	"gambling/internal/config"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"io"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// histogramWidth - ширина самого длинного столбца гистограммы в символах
+const histogramWidth = 40
+
+// Simulate выполняет команду `gambling simulate`: Monte Carlo симуляция автомата
+// База данных для команды не нужна
+func Simulate(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	gameID := fs.String("game", spin.ClassicGameID, gameUsage)
+	paytablePath := fs.String("paytable", "", paytableUsage)
+	spins := fs.Int64("spins", 10_000_000, "общее число спинов")
+	workers := fs.Int("workers", runtime.NumCPU(), "число параллельных воркеров")
+	seed := fs.Int64("seed", 0, "базовое зерно генератора (0 - случайное)")
+	betStr := fs.String("bet", "1.00", "ставка на спин")
+	balanceStr := fs.String("balance", "100.00", "стартовый баланс для оценки вероятности разорения (0 - не оценивать)")
+	sessionSpins := fs.Int64("session-spins", 1000, "максимальная длина игровой сессии в спинах")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	bet, err := money.Parse(*betStr, money.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("неверная ставка %q: %w", *betStr, err)
+	}
+	balance, err := money.Parse(*balanceStr, money.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("неверный баланс %q: %w", *balanceStr, err)
+	}
+
+	g, err := loadGame(cfg, *gameID, *paytablePath)
+	if err != nil {
+		return err
+	}
+	machine, ok := g.(analysis.Machine)
+	if !ok {
+		return fmt.Errorf("симуляция игры %q не поддерживается", g.ID())
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	simulateUseCase := analysis.NewSimulateUseCase(machine)
+	result, err := simulateUseCase.Execute(analysis.SimulateCommand{
+		Spins:           *spins,
+		Workers:         *workers,
+		Seed:            *seed,
+		BetAmount:       bet,
+		StartingBalance: balance,
+		SessionSpins:    *sessionSpins,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeSimulation(stdout, result, *seed)
+}
+
+func writeSimulation(w io.Writer, r *analysis.SimulateResult, seed int64) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "MONTE CARLO SIMULATION")
+	fmt.Fprintf(tw, "Версия таблицы выплат:\t%s\n", r.PaytableVersion)
+	fmt.Fprintf(tw, "Спинов / воркеров:\t%d / %d\n", r.Spins, r.Workers)
+	fmt.Fprintf(tw, "Зерно:\t%d\n", seed)
+	fmt.Fprintf(tw, "Время:\t%s (%.0f спинов/с)\n", r.Duration.Round(time.Millisecond), float64(r.Spins)/r.Duration.Seconds())
+	fmt.Fprintf(tw, "Сумма ставок / выплат:\t%s / %s\n", r.TotalBet.Format(), r.TotalWin.Format())
+	fmt.Fprintf(tw, "RTP:\t%.4f%% (95%% ДИ: %.4f%% - %.4f%%)\n", r.RTP*100, r.RTPLow*100, r.RTPHigh*100)
+	fmt.Fprintf(tw, "Стандартное отклонение:\t%.4f\n", r.StdDev)
+	fmt.Fprintf(tw, "Частота выигрыша:\t%.4f%%\n", r.HitFrequency*100)
+	if r.JackpotHits > 0 {
+		fmt.Fprintf(tw, "Джекпотов (выплаты из пула не входят в RTP):\t%d\n", r.JackpotHits)
+	}
+	if r.FreeSpinsTriggers > 0 {
+		fmt.Fprintf(tw, "Бонусов / бесплатных спинов:\t%d / %d (бонус раз в %.0f спинов)\n",
+			r.FreeSpinsTriggers, r.FreeSpinsPlayed, float64(r.Spins)/float64(r.FreeSpinsTriggers))
+	}
+	fmt.Fprintf(tw, "Самая длинная серия проигрышей:\t%d\n", r.LongestLosingStreak)
+	if r.Sessions > 0 {
+		fmt.Fprintf(tw, "Вероятность разорения:\t%.4f%% (95%% ДИ: %.4f%% - %.4f%%, сессий: %d)\n",
+			r.RuinProbability*100, r.RuinLow*100, r.RuinHigh*100, r.Sessions)
+	}
+	fmt.Fprintln(tw)
+
+	var maxCount int64
+	for _, b := range r.Histogram {
+		maxCount = max(maxCount, b.Count)
+	}
+
+	fmt.Fprintln(tw, "Выигрыш\tКоличество\tДоля, %\t")
+	for _, b := range r.Histogram {
+		bar := strings.Repeat("█", int(float64(histogramWidth)*float64(b.Count)/float64(maxCount)))
+		if bar == "" {
+			bar = "▏"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.6f\t%s\n", b.WinAmount.Format(), b.Count, float64(b.Count)/float64(r.Spins)*100, bar)
+	}
+
+	return tw.Flush()
+}
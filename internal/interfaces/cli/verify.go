@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gambling/internal/config"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"io"
+	"text/tabwriter"
+)
+
+// Verify выполняет команду `gambling verify`: пересчет исхода спина по раскрытому серверному сиду
+// Команда работает без базы данных, так что игрок может проверить спин независимо от казино
+func Verify(cfg *config.Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	gameID := fs.String("game", spin.ClassicGameID, gameUsage)
+	paytablePath := fs.String("paytable", "", paytableUsage)
+	serverSeed := fs.String("server-seed", "", "раскрытый серверный сид")
+	serverSeedHash := fs.String("server-seed-hash", "", "хеш серверного сида, выданный до игры (необязательно)")
+	clientSeed := fs.String("client-seed", "", "клиентский сид")
+	nonce := fs.Uint64("nonce", 0, "nonce спина")
+	betStr := fs.String("bet", "1.00", "ставка спина")
+	multiplierStr := fs.String("free-spin-multiplier", "", "коэффициент бонуса, если проверяется бесплатный спин")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *serverSeed == "" || *clientSeed == "" {
+		return errors.New("необходимо указать -server-seed и -client-seed")
+	}
+
+	bet, err := money.Parse(*betStr, money.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("неверная ставка %q: %w", *betStr, err)
+	}
+
+	var multiplier spin.Multiplier
+	if *multiplierStr != "" {
+		if multiplier, err = spin.ParseMultiplier(*multiplierStr); err != nil {
+			return fmt.Errorf("неверный коэффициент бонуса %q: %w", *multiplierStr, err)
+		}
+	}
+
+	g, err := loadGame(cfg, *gameID, *paytablePath)
+	if err != nil {
+		return err
+	}
+	outcome, err := g.Play(fairness.NewStream(*serverSeed, *clientSeed, *nonce), bet)
+	if err != nil {
+		return err
+	}
+	win := outcome.Payout
+	if !multiplier.IsZero() {
+		// Выигрыш бесплатного спина умножается на коэффициент бонуса
+		if win, err = spin.FreeSpinWin(outcome.Payout, multiplier); err != nil {
+			return err
+		}
+	}
+	hash := fairness.HashServerSeed(*serverSeed)
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Игра / версия таблицы выплат:\t%s / %s\n", g.ID(), g.Version())
+	fmt.Fprintf(tw, "Хеш серверного сида:\t%s\n", hash)
+	if *serverSeedHash != "" {
+		status := "совпадает"
+		if *serverSeedHash != hash {
+			status = "НЕ СОВПАДАЕТ"
+		}
+		fmt.Fprintf(tw, "Выданный хеш:\t%s (%s)\n", *serverSeedHash, status)
+	}
+	fmt.Fprintf(tw, "Клиентский сид / nonce:\t%s / %d\n", *clientSeed, *nonce)
+	switch g.ID() {
+	case spin.ClassicGameID:
+		classic, err := spin.DecodeClassicOutcome(outcome.Data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "Символы:\t%d %d %d\n", classic.Reels[0], classic.Reels[1], classic.Reels[2])
+		if classic.Scatter != nil {
+			fmt.Fprintf(tw, "Scatter:\t%s\n", scatterSummary(classic.Scatter))
+		}
+	case spin.VideoGameID:
+		// Окно выводится после таблицы: его строки не выравниваются по колонкам
+	default:
+		fmt.Fprintf(tw, "Исход:\t%s\n", outcome.Data)
+	}
+	if multiplier.IsZero() {
+		fmt.Fprintf(tw, "Ставка / выигрыш:\t%s / %s\n", bet.Format(), win.Format())
+	} else {
+		fmt.Fprintf(tw, "Ставка / выигрыш бесплатного спина:\t%s / %s (%s x%s)\n",
+			bet.Format(), win.Format(), outcome.Payout.Format(), multiplier)
+	}
+	if outcome.FreeSpins != nil {
+		fmt.Fprintf(tw, "Бесплатные спины:\t%d\n", outcome.FreeSpins.Spins)
+	}
+	if outcome.Jackpot {
+		fmt.Fprintln(tw, "Джекпот:\tда (сумма выплаты зависит от пула)")
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if g.ID() == spin.VideoGameID {
+		video, err := spin.DecodeVideoOutcome(outcome.Data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, "Окно (* - символы выигравших линий):")
+		writeVideoGrid(stdout, video)
+	}
+
+	if *serverSeedHash != "" && *serverSeedHash != hash {
+		return errors.New("хеш серверного сида не совпадает с выданным")
+	}
+	return nil
+}
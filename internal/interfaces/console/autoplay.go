@@ -0,0 +1,188 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/spin"
+	"gambling/internal/domain/money"
+	spinDomain "gambling/internal/domain/spin"
+	"gambling/internal/domain/user"
+	"strconv"
+	"strings"
+)
+
+// stopReasonText описывает причины остановки автоигры
+var stopReasonText = map[spin.StopReason]string{
+	spin.StopCompleted:         "сыграны все спины",
+	spin.StopBalanceBelow:      "баланс ниже заданного",
+	spin.StopWinAbove:          "крупный выигрыш",
+	spin.StopLossAbove:         "проигрыш больше заданного",
+	spin.StopJackpot:           "выигран джекпот",
+	spin.StopInsufficientFunds: "недостаточно средств для ставки",
+	spin.StopLimit:             "сработал лимит ответственной игры",
+	spin.StopCancelled:         "остановлено игроком",
+	spin.StopError:             "ошибка при игре",
+}
+
+// autoplay запускает серию спинов с одной ставкой и условиями остановки
+// Ход игры показывается счетчиком в одной строке, Enter останавливает автоигру
+func (c *Console) autoplay() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🔁 АВТОИГРА")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Текущий баланс: %s\n", c.currentBalance.Format())
+	fmt.Println("1. Классический автомат (3 барабана)")
+	fmt.Printf("2. Видеослот 5x3 (%d линий)\n", c.videoPaytable.Lines())
+	fmt.Print("Выберите игру: ")
+
+	c.scanner.Scan()
+	cmd := spin.AutoplayCommand{UserID: c.currentUserID}
+	currency := c.currentBalance.Currency()
+	switch strings.TrimSpace(c.scanner.Text()) {
+	case "1":
+		cmd.GameID = spinDomain.ClassicGameID
+		fmt.Print("Введите сумму ставки: ")
+		bet, ok := c.readAmount()
+		if !ok {
+			return
+		}
+		cmd.BetAmount = bet
+	case "2":
+		cmd.GameID = spinDomain.VideoGameID
+		fmt.Printf("Введите ставку на линию (линий: %d): ", c.videoPaytable.Lines())
+		lineBet, ok := c.readAmount()
+		if !ok {
+			return
+		}
+		cmd.LineBet = lineBet
+	default:
+		fmt.Println("❌ Неверный выбор. Попробуйте снова.")
+		return
+	}
+
+	fmt.Print("Число спинов: ")
+	c.scanner.Scan()
+	spins, err := strconv.Atoi(strings.TrimSpace(c.scanner.Text()))
+	if err != nil || spins <= 0 {
+		fmt.Println("❌ Неверное число спинов!")
+		fmt.Println()
+		return
+	}
+	cmd.Spins = spins
+
+	fmt.Println("Условия остановки (пусто - не проверять):")
+	var ok bool
+	if cmd.StopBalanceBelow, ok = c.readStopAmount("  баланс ниже: ", currency); !ok {
+		return
+	}
+	if cmd.StopWinAbove, ok = c.readStopAmount("  выигрыш спина больше: ", currency); !ok {
+		return
+	}
+	if cmd.StopLossAbove, ok = c.readStopAmount("  общий проигрыш больше: ", currency); !ok {
+		return
+	}
+	if cmd.GameID == spinDomain.ClassicGameID {
+		fmt.Print("  остановить при джекпоте (д/н): ")
+		c.scanner.Scan()
+		answer := strings.ToLower(strings.TrimSpace(c.scanner.Text()))
+		cmd.StopOnJackpot = answer == "д" || answer == "y"
+	}
+
+	// Enter в любой момент отменяет автоигру. Строку читает отдельная горутина,
+	// поэтому после автоигры ее нужно дождаться, иначе она перехватит ввод меню
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pressed := make(chan struct{})
+	go func() {
+		c.scanner.Scan()
+		close(pressed)
+		cancel()
+	}()
+
+	fmt.Println()
+	fmt.Println("▶️  Автоигра запущена. Нажмите Enter, чтобы остановить")
+	result, err := c.autoplayUseCase.Execute(ctx, cmd, func(s *spin.SpinResult, total *spin.AutoplayResult) {
+		label := fmt.Sprintf("Спин %d/%d", total.PaidSpins, cmd.Spins)
+		if s.FreeSpin {
+			label = fmt.Sprintf("Бесплатный спин %d", total.FreeSpins)
+		}
+		fmt.Printf("\r🎰 %s | выигрыш %s | итог %s | баланс %s   ",
+			label, s.WinAmount.Format(), total.Net.Format(), s.Balance.Format())
+	})
+	fmt.Println()
+
+	if err != nil {
+		switch {
+		case errors.Is(err, spin.ErrInvalidAutoplay):
+			fmt.Printf("❌ %v\n", err)
+		case errors.Is(err, user.ErrInsufficientFunds):
+			fmt.Println("❌ Недостаточно средств!")
+		default:
+			fmt.Printf("❌ Ошибка при игре: %v\n", err)
+		}
+	} else {
+		c.currentBalance = result.Balance
+		c.showAutoplayResult(result)
+	}
+
+	select {
+	case <-pressed:
+	default:
+		fmt.Print("Нажмите Enter, чтобы вернуться в меню")
+		<-pressed
+	}
+	fmt.Println()
+}
+
+// showAutoplayResult показывает итоги автоигры
+func (c *Console) showAutoplayResult(result *spin.AutoplayResult) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("⏹  Автоигра остановлена: %s\n", stopReasonText[result.StopReason])
+	if result.Err != nil {
+		fmt.Printf("   %v\n", result.Err)
+	}
+	fmt.Printf("Спинов: %d", result.PaidSpins)
+	if result.FreeSpins > 0 {
+		fmt.Printf(" (и %d бесплатных)", result.FreeSpins)
+	}
+	fmt.Println()
+	fmt.Printf("Ставки: %s, выигрыши: %s, итог: %s\n",
+		result.TotalBet.Format(), result.TotalWin.Format(), result.Net.Format())
+	fmt.Printf("Крупнейший выигрыш: %s\n", result.BiggestWin.Format())
+	if result.JackpotAmount.IsPositive() {
+		fmt.Printf("💎 Джекпот: %s\n", result.JackpotAmount.Format())
+	}
+	fmt.Printf("💰 Ваш баланс: %s\n", result.Balance.Format())
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
+
+// readAmount читает положительную сумму ставки в валюте игрока
+func (c *Console) readAmount() (money.Money, bool) {
+	c.scanner.Scan()
+	amount, err := money.Parse(strings.TrimSpace(c.scanner.Text()), c.currentBalance.Currency())
+	if err != nil || !amount.IsPositive() {
+		fmt.Println("❌ Неверная сумма ставки!")
+		fmt.Println()
+		return money.Money{}, false
+	}
+	return amount, true
+}
+
+// readStopAmount читает необязательную сумму условия остановки (пусто - ноль)
+func (c *Console) readStopAmount(prompt string, currency money.Currency) (money.Money, bool) {
+	fmt.Print(prompt)
+	c.scanner.Scan()
+	text := strings.TrimSpace(c.scanner.Text())
+	if text == "" {
+		return money.Zero(currency), true
+	}
+	amount, err := money.Parse(text, currency)
+	if err != nil || amount.IsNegative() {
+		fmt.Println("❌ Неверная сумма!")
+		fmt.Println()
+		return money.Money{}, false
+	}
+	return amount, true
+}
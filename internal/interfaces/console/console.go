@@ -2,10 +2,18 @@ package console
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"gambling/internal/application/use_case/auth"
 	"gambling/internal/application/use_case/balance"
+	"gambling/internal/application/use_case/exclusions"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/application/use_case/limits"
 	"gambling/internal/application/use_case/spin"
+	"gambling/internal/application/use_case/statement"
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/money"
+	spinDomain "gambling/internal/domain/spin"
 	"gambling/internal/domain/user"
 	"math/rand"
 	"os"
@@ -17,29 +25,71 @@ import (
 // Console представляет консольный интерфейс для игры
 // Использует use cases из Application слоя
 type Console struct {
-	registerUseCase *auth.RegisterUseCase
-	loginUseCase    *auth.LoginUseCase
-	depositUseCase  *balance.DepositUseCase
-	spinUseCase     *spin.SpinUseCase
-	scanner         *bufio.Scanner
-	currentUserID   uint
-	currentUsername string
-	currentBalance  float64
+	registerUseCase          *auth.RegisterUseCase
+	loginUseCase             *auth.LoginUseCase
+	depositUseCase           *balance.DepositUseCase
+	withdrawUseCase          *balance.WithdrawUseCase
+	approveWithdrawalUseCase *balance.ApproveWithdrawalUseCase
+	rejectWithdrawalUseCase  *balance.RejectWithdrawalUseCase
+	listWithdrawalsUseCase   *balance.ListWithdrawalsUseCase
+	listTransactionsUseCase  *history.ListTransactionsUseCase
+	generateStatementUseCase *statement.GenerateUseCase
+	setLimitUseCase          *limits.SetLimitUseCase
+	listLimitsUseCase        *limits.ListLimitsUseCase
+	excludeUseCase           *exclusions.ExcludeUseCase
+	spinUseCase              *spin.SpinUseCase
+	getFreeSpinsUseCase      *spin.GetFreeSpinsUseCase
+	autoplayUseCase          *spin.AutoplayUseCase
+	paytable                 *spinDomain.Paytable
+	videoPaytable            *spinDomain.VideoPaytable
+	scanner                  *bufio.Scanner
+	currentUserID            uint
+	currentUsername          string
+	currentBalance           money.Money
+	currentRole              user.Role
+	currentExclusion         *user.Exclusion
+}
+
+// UseCases содержит use cases, которые использует консольный интерфейс
+type UseCases struct {
+	Register          *auth.RegisterUseCase
+	Login             *auth.LoginUseCase
+	Deposit           *balance.DepositUseCase
+	Withdraw          *balance.WithdrawUseCase
+	ApproveWithdrawal *balance.ApproveWithdrawalUseCase
+	RejectWithdrawal  *balance.RejectWithdrawalUseCase
+	ListWithdrawals   *balance.ListWithdrawalsUseCase
+	ListTransactions  *history.ListTransactionsUseCase
+	GenerateStatement *statement.GenerateUseCase
+	SetLimit          *limits.SetLimitUseCase
+	ListLimits        *limits.ListLimitsUseCase
+	Exclude           *exclusions.ExcludeUseCase
+	Spin              *spin.SpinUseCase
+	GetFreeSpins      *spin.GetFreeSpinsUseCase
+	Autoplay          *spin.AutoplayUseCase
 }
 
 // NewConsole создает новый экземпляр консольного интерфейса
-func NewConsole(
-	registerUseCase *auth.RegisterUseCase,
-	loginUseCase *auth.LoginUseCase,
-	depositUseCase *balance.DepositUseCase,
-	spinUseCase *spin.SpinUseCase,
-) *Console {
+func NewConsole(useCases UseCases, paytable *spinDomain.Paytable, videoPaytable *spinDomain.VideoPaytable) *Console {
 	return &Console{
-		registerUseCase: registerUseCase,
-		loginUseCase:    loginUseCase,
-		depositUseCase:  depositUseCase,
-		spinUseCase:     spinUseCase,
-		scanner:         bufio.NewScanner(os.Stdin),
+		registerUseCase:          useCases.Register,
+		loginUseCase:             useCases.Login,
+		depositUseCase:           useCases.Deposit,
+		withdrawUseCase:          useCases.Withdraw,
+		approveWithdrawalUseCase: useCases.ApproveWithdrawal,
+		rejectWithdrawalUseCase:  useCases.RejectWithdrawal,
+		listWithdrawalsUseCase:   useCases.ListWithdrawals,
+		listTransactionsUseCase:  useCases.ListTransactions,
+		generateStatementUseCase: useCases.GenerateStatement,
+		setLimitUseCase:          useCases.SetLimit,
+		listLimitsUseCase:        useCases.ListLimits,
+		excludeUseCase:           useCases.Exclude,
+		spinUseCase:              useCases.Spin,
+		getFreeSpinsUseCase:      useCases.GetFreeSpins,
+		autoplayUseCase:          useCases.Autoplay,
+		paytable:                 paytable,
+		videoPaytable:            videoPaytable,
+		scanner:                  bufio.NewScanner(os.Stdin),
 	}
 }
 
@@ -90,37 +140,101 @@ func (c *Console) showMainMenu() {
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════")
 	fmt.Printf("👤 Пользователь: %s\n", c.currentUsername)
-	fmt.Printf("💰 Баланс: %.2f ₽\n", c.currentBalance)
+	fmt.Printf("💰 Баланс: %s\n", c.currentBalance.Format())
+	if c.excluded() {
+		fmt.Printf("⛔ %s: доступен только вывод средств\n", c.currentExclusionText())
+	}
 	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("1. Пополнить баланс")
-	fmt.Println("2. Играть в спинах")
-	fmt.Println("3. Выйти из аккаунта")
-	fmt.Println("4. Выход из программы")
+	if !c.excluded() {
+		fmt.Println("1. Пополнить баланс")
+		fmt.Println("2. Играть")
+	}
+	fmt.Println("3. Вывести средства")
+	fmt.Println("4. Мои заявки на вывод")
+	fmt.Println("5. История транзакций")
+	fmt.Println("6. Выписка по счету")
+	fmt.Println("7. Лимиты ответственной игры")
+	fmt.Println("8. Перерыв в игре и самоисключение")
+	fmt.Println("9. Выйти из аккаунта")
+	fmt.Println("10. Выход из программы")
+	if c.currentRole == user.RoleOperator {
+		fmt.Println("11. Рассмотреть заявки на вывод")
+		fmt.Println("12. История транзакций игрока")
+	}
 	fmt.Println("═══════════════════════════════════════")
 	fmt.Print("Выберите действие: ")
 
 	c.scanner.Scan()
 	choice := strings.TrimSpace(c.scanner.Text())
 
+	// Скрытые из меню пункты недоступны и при вводе номера вручную
+	if c.excluded() && (choice == "1" || choice == "2") {
+		fmt.Println("❌ Неверный выбор. Попробуйте снова.")
+		return
+	}
+
 	switch choice {
 	case "1":
 		c.deposit()
 	case "2":
-		c.playSpin()
+		c.chooseGame()
 	case "3":
-		c.currentUserID = 0
-		c.currentUsername = ""
-		c.currentBalance = 0
+		c.withdraw()
+	case "4":
+		c.showWithdrawals()
+	case "5":
+		c.showHistory()
+	case "6":
+		c.exportStatement()
+	case "7":
+		c.manageLimits()
+	case "8":
+		c.selfExclude()
+	case "9":
+		c.logout()
 		fmt.Println("✅ Вы вышли из аккаунта")
 		fmt.Println()
-	case "4":
+	case "10":
 		fmt.Println("До свидания!")
 		os.Exit(0)
+	case "11":
+		if c.currentRole != user.RoleOperator {
+			fmt.Println("❌ Неверный выбор. Попробуйте снова.")
+			return
+		}
+		c.reviewWithdrawals()
+	case "12":
+		if c.currentRole != user.RoleOperator {
+			fmt.Println("❌ Неверный выбор. Попробуйте снова.")
+			return
+		}
+		c.showPlayerHistory()
 	default:
 		fmt.Println("❌ Неверный выбор. Попробуйте снова.")
 	}
 }
 
+// logout завершает сеанс текущего пользователя в консоли
+func (c *Console) logout() {
+	c.currentUserID = 0
+	c.currentUsername = ""
+	c.currentBalance = money.Money{}
+	c.currentRole = ""
+	c.currentExclusion = nil
+}
+
+// excluded проверяет, действует ли у текущего пользователя самоограничение
+// Пока оно действует, пополнение и игра в меню скрыты
+func (c *Console) excluded() bool {
+	return c.currentExclusion != nil && c.currentExclusion.ActiveAt(time.Now())
+}
+
+// currentExclusionText описывает действующее самоограничение текущего пользователя
+func (c *Console) currentExclusionText() string {
+	e := c.currentExclusion
+	return (&user.ExcludedError{Kind: e.Kind, Until: e.Until}).Error()
+}
+
 // register обрабатывает регистрацию
 func (c *Console) register() {
 	fmt.Println()
@@ -156,6 +270,8 @@ func (c *Console) register() {
 	if err != nil {
 		if err == user.ErrUserAlreadyExists {
 			fmt.Println("❌ Пользователь с таким именем или email уже существует!")
+		} else if errors.Is(err, exclusion.ErrEmailBlocked) {
+			fmt.Println("⛔ Регистрация с этим email невозможна: действует бессрочное самоисключение")
 		} else {
 			fmt.Printf("❌ Ошибка при регистрации: %v\n", err)
 		}
@@ -166,6 +282,7 @@ func (c *Console) register() {
 	c.currentUserID = result.ID
 	c.currentUsername = result.Username
 	c.currentBalance = result.Balance
+	c.currentRole = user.RolePlayer
 	fmt.Printf("✅ Регистрация успешна! Добро пожаловать, %s!\n", result.Username)
 	fmt.Println()
 }
@@ -210,8 +327,13 @@ func (c *Console) login() {
 	c.currentUserID = result.ID
 	c.currentUsername = result.Username
 	c.currentBalance = result.Balance
+	c.currentRole = result.Role
+	c.currentExclusion = result.Exclusion
 	fmt.Printf("✅ Вход выполнен! Добро пожаловать, %s!\n", result.Username)
-	fmt.Printf("💰 Ваш баланс: %.2f ₽\n", result.Balance)
+	fmt.Printf("💰 Ваш баланс: %s\n", result.Balance.Format())
+	if c.excluded() {
+		fmt.Printf("⛔ %s. Пополнять баланс и играть нельзя, вывести средства можно\n", c.currentExclusionText())
+	}
 	fmt.Println()
 }
 
@@ -221,14 +343,14 @@ func (c *Console) deposit() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("💳 ПОПОЛНЕНИЕ БАЛАНСА")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Текущий баланс: %.2f ₽\n", c.currentBalance)
+	fmt.Printf("Текущий баланс: %s\n", c.currentBalance.Format())
 	fmt.Print("Введите сумму для пополнения: ")
 
 	c.scanner.Scan()
 	amountStr := strings.TrimSpace(c.scanner.Text())
 
-	amount, err := strconv.ParseFloat(amountStr, 64)
-	if err != nil || amount <= 0 {
+	amount, err := money.Parse(amountStr, c.currentBalance.Currency())
+	if err != nil || !amount.IsPositive() {
 		fmt.Println("❌ Неверная сумма!")
 		fmt.Println()
 		return
@@ -247,8 +369,8 @@ func (c *Console) deposit() {
 	}
 
 	c.currentBalance = result.Balance
-	fmt.Printf("✅ Баланс успешно пополнен на %.2f ₽\n", amount)
-	fmt.Printf("💰 Новый баланс: %.2f ₽\n", result.Balance)
+	fmt.Printf("✅ Баланс успешно пополнен на %s\n", amount.Format())
+	fmt.Printf("💰 Новый баланс: %s\n", result.Balance.Format())
 	fmt.Println()
 }
 
@@ -258,20 +380,20 @@ func (c *Console) playSpin() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println("🎰 ИГРА НА СПИНАХ")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Printf("Текущий баланс: %.2f ₽\n", c.currentBalance)
+	fmt.Printf("Текущий баланс: %s\n", c.currentBalance.Format())
 	fmt.Print("Введите сумму ставки: ")
 
 	c.scanner.Scan()
 	betStr := strings.TrimSpace(c.scanner.Text())
 
-	betAmount, err := strconv.ParseFloat(betStr, 64)
-	if err != nil || betAmount <= 0 {
+	betAmount, err := money.Parse(betStr, c.currentBalance.Currency())
+	if err != nil || !betAmount.IsPositive() {
 		fmt.Println("❌ Неверная сумма ставки!")
 		fmt.Println()
 		return
 	}
 
-	if betAmount > c.currentBalance {
+	if c.currentBalance.LessThan(betAmount) {
 		fmt.Println("❌ Недостаточно средств на балансе!")
 		fmt.Println()
 		return
@@ -283,6 +405,7 @@ func (c *Console) playSpin() {
 
 	cmd := spin.SpinCommand{
 		UserID:    c.currentUserID,
+		GameID:    spinDomain.ClassicGameID,
 		BetAmount: betAmount,
 	}
 
@@ -290,6 +413,8 @@ func (c *Console) playSpin() {
 	if err != nil {
 		if err == user.ErrInsufficientFunds {
 			fmt.Println("❌ Недостаточно средств!")
+		} else if errors.Is(err, user.ErrFreeSpinsPending) {
+			fmt.Println("❌ Сначала сыграйте бесплатные спины!")
 		} else {
 			fmt.Printf("❌ Ошибка при игре: %v\n", err)
 		}
@@ -300,19 +425,37 @@ func (c *Console) playSpin() {
 	c.currentBalance = result.Balance
 
 	// Показываем анимацию вращения барабанов
-	c.animateSpin(result.Reel1, result.Reel2, result.Reel3)
-
-	if result.IsWin {
-		fmt.Printf("🎉 ВЫИГРЫШ! Вы выиграли %.2f ₽\n", result.WinAmount)
+	outcome, err := spinDomain.DecodeClassicOutcome(result.Outcome)
+	if err != nil {
+		fmt.Printf("❌ Ошибка при показе результата: %v\n", err)
+		fmt.Println()
+		return
+	}
+	c.animateSpin(outcome.Reels[0], outcome.Reels[1], outcome.Reels[2])
+	showScatter(outcome.Scatter)
+
+	if result.JackpotAmount.IsPositive() {
+		fmt.Printf("💎 ДЖЕКПОТ! Вы выиграли %s, из них джекпот %s\n",
+			result.WinAmount.Format(), result.JackpotAmount.Format())
+	} else if result.IsWin {
+		fmt.Printf("🎉 ВЫИГРЫШ! Вы выиграли %s\n", result.WinAmount.Format())
 	} else {
 		fmt.Println("😔 Не повезло, попробуйте еще раз!")
 	}
 
-	fmt.Printf("💰 Ваш баланс: %.2f ₽\n", result.Balance)
+	fmt.Printf("💰 Ваш баланс: %s\n", result.Balance.Format())
+	if result.ServerSeedHash != "" {
+		fmt.Printf("🔐 Спин #%d: хеш сервера %s, клиентский сид %s, nonce %d\n",
+			result.SpinID, result.ServerSeedHash, result.ClientSeed, result.Nonce)
+	}
 	fmt.Println()
 
 	// Показываем правила выигрыша
 	c.showWinRules()
+
+	if result.FreeSpinsAwarded > 0 {
+		c.playFreeSpins()
+	}
 }
 
 // animateSpin показывает анимацию вращения барабанов с постепенным замедлением
@@ -348,16 +491,16 @@ func (c *Console) spinReel1(rng *rand.Rand, totalSpins, finalSymbol int) {
 
 	// Быстрое вращение
 	for i := 0; i < fastSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [ ] [ ]          ║", symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [ ] [ ]          ║", c.symbolLabel(symbol))
 		time.Sleep(50 * time.Millisecond)
 	}
 
 	// Замедление перед остановкой
 	delays := []time.Duration{100, 150, 200, 250, 300}
 	for i := 0; i < slowSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [ ] [ ]          ║", symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [ ] [ ]          ║", c.symbolLabel(symbol))
 		if i < len(delays) {
 			time.Sleep(delays[i])
 		} else {
@@ -366,7 +509,7 @@ func (c *Console) spinReel1(rng *rand.Rand, totalSpins, finalSymbol int) {
 	}
 
 	// Финальный символ
-	fmt.Printf("\r║         [%d] [ ] [ ]          ║", finalSymbol)
+	fmt.Printf("\r║         [%s] [ ] [ ]          ║", c.symbolLabel(finalSymbol))
 }
 
 // spinReel2 вращает второй барабан
@@ -376,16 +519,16 @@ func (c *Console) spinReel2(rng *rand.Rand, totalSpins, reel1, finalSymbol int)
 
 	// Быстрое вращение
 	for i := 0; i < fastSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [%d] [ ]          ║", reel1, symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [%s] [ ]          ║", c.symbolLabel(reel1), c.symbolLabel(symbol))
 		time.Sleep(50 * time.Millisecond)
 	}
 
 	// Замедление перед остановкой
 	delays := []time.Duration{100, 150, 200, 250, 300}
 	for i := 0; i < slowSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [%d] [ ]          ║", reel1, symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [%s] [ ]          ║", c.symbolLabel(reel1), c.symbolLabel(symbol))
 		if i < len(delays) {
 			time.Sleep(delays[i])
 		} else {
@@ -394,7 +537,7 @@ func (c *Console) spinReel2(rng *rand.Rand, totalSpins, reel1, finalSymbol int)
 	}
 
 	// Финальный символ
-	fmt.Printf("\r║         [%d] [%d] [ ]          ║", reel1, finalSymbol)
+	fmt.Printf("\r║         [%s] [%s] [ ]          ║", c.symbolLabel(reel1), c.symbolLabel(finalSymbol))
 }
 
 // spinReel3 вращает третий барабан
@@ -404,16 +547,16 @@ func (c *Console) spinReel3(rng *rand.Rand, totalSpins, reel1, reel2, finalSymbo
 
 	// Быстрое вращение
 	for i := 0; i < fastSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [%d] [%d]          ║", reel1, reel2, symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [%s] [%s]          ║", c.symbolLabel(reel1), c.symbolLabel(reel2), c.symbolLabel(symbol))
 		time.Sleep(50 * time.Millisecond)
 	}
 
 	// Замедление перед остановкой
 	delays := []time.Duration{100, 150, 200, 250, 300}
 	for i := 0; i < slowSpins; i++ {
-		symbol := rng.Intn(10)
-		fmt.Printf("\r║         [%d] [%d] [%d]          ║", reel1, reel2, symbol)
+		symbol := c.randomSymbol(rng)
+		fmt.Printf("\r║         [%s] [%s] [%s]          ║", c.symbolLabel(reel1), c.symbolLabel(reel2), c.symbolLabel(symbol))
 		if i < len(delays) {
 			time.Sleep(delays[i])
 		} else {
@@ -422,28 +565,105 @@ func (c *Console) spinReel3(rng *rand.Rand, totalSpins, reel1, reel2, finalSymbo
 	}
 
 	// Финальный символ
-	fmt.Printf("\r║         [%d] [%d] [%d]          ║", reel1, reel2, finalSymbol)
+	fmt.Printf("\r║         [%s] [%s] [%s]          ║", c.symbolLabel(reel1), c.symbolLabel(reel2), c.symbolLabel(finalSymbol))
+}
+
+// randomSymbol возвращает случайный символ таблицы для анимации вращения
+func (c *Console) randomSymbol(rng *rand.Rand) int {
+	return c.paytable.Symbols[rng.Intn(len(c.paytable.Symbols))].Symbol
 }
 
-// showWinRules показывает правила выигрыша
+// symbolLabel возвращает обозначение символа классического автомата:
+// W - wild, S - scatter, остальные символы - их номер
+func (c *Console) symbolLabel(symbol int) string {
+	return symbolLabel(symbol, c.paytable.Wild, c.paytable.Scatter)
+}
+
+// symbolLabel возвращает обозначение символа с учетом ролей wild и scatter
+func symbolLabel(symbol int, wild *spinDomain.WildRule, scatter *spinDomain.ScatterRule) string {
+	switch {
+	case wild != nil && symbol == wild.Symbol:
+		return "W"
+	case scatter != nil && symbol == scatter.Symbol:
+		return "S"
+	default:
+		return strconv.Itoa(symbol)
+	}
+}
+
+// showWinRules показывает правила выигрыша из текущей таблицы выплат
 func (c *Console) showWinRules() {
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("📋 ПРАВИЛА ВЫИГРЫША:")
+	fmt.Printf("📋 ПРАВИЛА ВЫИГРЫША (%s):\n", c.paytable.Version)
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
-	fmt.Println("Три одинаковых:")
-	fmt.Println("  • Три нуля: x1000")
-	fmt.Println("  • Три 1-3: x50")
-	fmt.Println("  • Три 4-6: x20")
-	fmt.Println("  • Три 7-9: x10")
-	fmt.Println()
-	fmt.Println("Два одинаковых:")
-	fmt.Println("  • Два нуля: x10")
-	fmt.Println("  • Две 1-3: x3")
-	fmt.Println("  • Две 4-6: x2")
-	fmt.Println("  • Две 7-9: x1.5")
-	fmt.Println()
-	fmt.Println("Последовательность (0-1-2 или 7-8-9): x5")
+	if len(c.paytable.Triples) > 0 {
+		fmt.Println("Три одинаковых:")
+		for _, line := range payoutLines(c.paytable.Triples) {
+			fmt.Printf("  • %s\n", line)
+		}
+		fmt.Println()
+	}
+	if len(c.paytable.Pairs) > 0 {
+		fmt.Println("Два одинаковых:")
+		for _, line := range payoutLines(c.paytable.Pairs) {
+			fmt.Printf("  • %s\n", line)
+		}
+		fmt.Println()
+	}
+	for _, line := range sequenceLines(c.paytable.Sequences) {
+		fmt.Printf("Последовательность %s\n", line)
+	}
+	if c.paytable.Wild != nil {
+		fmt.Println("W (wild) заменяет любой символ, кроме S, в тройках и парах")
+	}
+	if scatter := c.paytable.Scatter; scatter != nil {
+		fmt.Println("S (scatter) платит на любом барабане к ставке:")
+		for _, line := range scatterLines(scatter) {
+			fmt.Printf("  • %s\n", line)
+		}
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Println()
 }
 
+// payoutLines группирует подряд идущие символы с одинаковым коэффициентом,
+// например "1-3: x50"
+func payoutLines(payouts []spinDomain.SymbolPayout) []string {
+	var lines []string
+	for i := 0; i < len(payouts); {
+		j := i
+		for j+1 < len(payouts) &&
+			payouts[j+1].Multiplier == payouts[i].Multiplier &&
+			payouts[j+1].Symbol == payouts[j].Symbol+1 {
+			j++
+		}
+
+		label := strconv.Itoa(payouts[i].Symbol)
+		if j > i {
+			label = fmt.Sprintf("%d-%d", payouts[i].Symbol, payouts[j].Symbol)
+		}
+		lines = append(lines, fmt.Sprintf("%s: x%s", label, payouts[i].Multiplier))
+		i = j + 1
+	}
+	return lines
+}
+
+// sequenceLines группирует последовательности с одинаковым коэффициентом,
+// например "(0-1-2 или 7-8-9): x5"
+func sequenceLines(sequences []spinDomain.SequencePayout) []string {
+	var order []spinDomain.Multiplier
+	groups := make(map[spinDomain.Multiplier][]string)
+	for _, seq := range sequences {
+		if _, ok := groups[seq.Multiplier]; !ok {
+			order = append(order, seq.Multiplier)
+		}
+		groups[seq.Multiplier] = append(groups[seq.Multiplier],
+			fmt.Sprintf("%d-%d-%d", seq.Reels[0], seq.Reels[1], seq.Reels[2]))
+	}
+
+	lines := make([]string, 0, len(order))
+	for _, m := range order {
+		lines = append(lines, fmt.Sprintf("(%s): x%s", strings.Join(groups[m], " или "), m))
+	}
+	return lines
+}
@@ -0,0 +1,81 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/exclusions"
+	"gambling/internal/domain/user"
+	"strings"
+)
+
+// selfExclude устанавливает перерыв в игре или самоисключение текущему пользователю
+// После подтверждения пользователь выходит из аккаунта: войти можно будет только
+// после окончания срока
+func (c *Console) selfExclude() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("⛔ ПЕРЕРЫВ В ИГРЕ И САМОИСКЛЮЧЕНИЕ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("1. Перерыв на 24 часа")
+	fmt.Println("2. Перерыв на 7 дней")
+	fmt.Println("3. Перерыв на 30 дней")
+	fmt.Println("4. Самоисключение на 6 месяцев")
+	fmt.Println("5. Самоисключение на 1 год")
+	fmt.Println("6. Самоисключение на 5 лет")
+	fmt.Println("7. Бессрочное самоисключение")
+	fmt.Println("Досрочно снять ограничение нельзя, продлить - можно")
+	fmt.Print("Выберите срок (пусто - назад): ")
+
+	options := map[string]exclusions.ExcludeCommand{
+		"1": {Kind: string(user.ExclusionCoolOff), Period: "24h"},
+		"2": {Kind: string(user.ExclusionCoolOff), Period: "7d"},
+		"3": {Kind: string(user.ExclusionCoolOff), Period: "30d"},
+		"4": {Kind: string(user.ExclusionSelf), Period: "6m"},
+		"5": {Kind: string(user.ExclusionSelf), Period: "1y"},
+		"6": {Kind: string(user.ExclusionSelf), Period: "5y"},
+		"7": {Kind: string(user.ExclusionSelf), Period: "permanent"},
+	}
+
+	c.scanner.Scan()
+	choice := strings.TrimSpace(c.scanner.Text())
+	if choice == "" {
+		fmt.Println()
+		return
+	}
+	cmd, ok := options[choice]
+	if !ok {
+		fmt.Println("❌ Неверный выбор!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Print("Подтвердите, введя \"да\": ")
+	c.scanner.Scan()
+	if strings.TrimSpace(c.scanner.Text()) != "да" {
+		fmt.Println("Отменено")
+		fmt.Println()
+		return
+	}
+
+	cmd.UserID = c.currentUserID
+	cmd.ActorID = c.currentUserID
+	result, err := c.excludeUseCase.Execute(cmd)
+	if err != nil {
+		if errors.Is(err, user.ErrExclusionShortened) {
+			fmt.Println("❌ Действующее ограничение нельзя сократить!")
+		} else {
+			fmt.Printf("❌ Ошибка при установке ограничения: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	if result.Until == nil {
+		fmt.Println("✅ Установлено бессрочное самоисключение")
+	} else {
+		fmt.Printf("✅ Игра ограничена до %s\n", result.Until.Local().Format("02.01.2006 15:04"))
+	}
+	c.logout()
+	fmt.Println("Вы вышли из аккаунта")
+	fmt.Println()
+}
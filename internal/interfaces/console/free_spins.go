@@ -0,0 +1,128 @@
+package console
+
+import (
+	"fmt"
+	"gambling/internal/application/use_case/spin"
+	spinDomain "gambling/internal/domain/spin"
+	"slices"
+	"strings"
+)
+
+// hasFreeSpins проверяет, есть ли у игрока несыгранный бонус бесплатных спинов
+func (c *Console) hasFreeSpins() bool {
+	status, err := c.getFreeSpinsUseCase.Execute(c.currentUserID)
+	return err == nil && status != nil
+}
+
+// playFreeSpins играет бонус бесплатных спинов игрока по одному спину,
+// пока бонус не закончится или игрок не отложит его
+func (c *Console) playFreeSpins() {
+	status, err := c.getFreeSpinsUseCase.Execute(c.currentUserID)
+	if err != nil {
+		fmt.Printf("❌ Ошибка при получении бонуса: %v\n", err)
+		fmt.Println()
+		return
+	}
+	if status == nil {
+		return
+	}
+
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🎁 БЕСПЛАТНЫЕ СПИНЫ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Игра: %s, ставка %s, выигрыши x%s\n", gameTitle(status.GameID), status.Bet.Format(), status.Multiplier)
+
+	won := status.Won
+	remaining := status.Remaining
+	for remaining > 0 {
+		fmt.Printf("Осталось бесплатных спинов: %d. Enter - крутить, 0 - отложить: ", remaining)
+		c.scanner.Scan()
+		if strings.TrimSpace(c.scanner.Text()) == "0" {
+			fmt.Println("⏸  Бонус сохранен. Платные спины станут доступны, когда он будет сыгран")
+			fmt.Println()
+			return
+		}
+
+		result, err := c.spinUseCase.Execute(spin.SpinCommand{
+			UserID:   c.currentUserID,
+			GameID:   status.GameID,
+			FreeSpin: true,
+		})
+		if err != nil {
+			fmt.Printf("❌ Ошибка при игре: %v\n", err)
+			fmt.Println()
+			return
+		}
+		c.currentBalance = result.Balance
+
+		fmt.Println()
+		if err := c.showFreeSpinOutcome(status.GameID, result); err != nil {
+			fmt.Printf("❌ Ошибка при показе результата: %v\n", err)
+		}
+		if result.IsWin {
+			fmt.Printf("🎉 Выигрыш с множителем x%s: %s\n", status.Multiplier, result.WinAmount.Format())
+		}
+		if result.FreeSpinsAwarded > 0 {
+			fmt.Printf("🎁 Бонус запущен повторно: +%d бесплатных спинов!\n", result.FreeSpinsAwarded)
+		}
+		fmt.Println()
+
+		if won, err = won.Add(result.WinAmount); err != nil {
+			fmt.Printf("❌ Ошибка при подсчете выигрыша: %v\n", err)
+			return
+		}
+		remaining = result.FreeSpinsRemaining
+	}
+
+	fmt.Printf("✅ Бесплатные спины сыграны. Выигрыш за бонус: %s\n", won.Format())
+	fmt.Printf("💰 Ваш баланс: %s\n", c.currentBalance.Format())
+	fmt.Println()
+}
+
+// showFreeSpinOutcome показывает символы бесплатного спина
+// Выигрыши линий и scatter показываются без множителя бонуса
+func (c *Console) showFreeSpinOutcome(gameID string, result *spin.SpinResult) error {
+	if gameID == spinDomain.VideoGameID {
+		outcome, err := spinDomain.DecodeVideoOutcome(result.Outcome)
+		if err != nil {
+			return err
+		}
+		c.showVideoGrid(outcome)
+		return nil
+	}
+
+	outcome, err := spinDomain.DecodeClassicOutcome(result.Outcome)
+	if err != nil {
+		return err
+	}
+	c.animateSpin(outcome.Reels[0], outcome.Reels[1], outcome.Reels[2])
+	showScatter(outcome.Scatter)
+	return nil
+}
+
+// gameTitle возвращает название игры для меню
+func gameTitle(gameID string) string {
+	if gameID == spinDomain.VideoGameID {
+		return "видеослот 5x3"
+	}
+	return "классический автомат"
+}
+
+// scatterLines описывает выплаты и бонус scatter, например "3 x S: x5"
+func scatterLines(scatter *spinDomain.ScatterRule) []string {
+	counts := make([]int, 0, len(scatter.Pays))
+	for count := range scatter.Pays {
+		counts = append(counts, count)
+	}
+	slices.Sort(counts)
+
+	lines := make([]string, 0, len(counts)+1)
+	for _, count := range counts {
+		lines = append(lines, fmt.Sprintf("%d x S: x%s", count, scatter.Pays[count]))
+	}
+	if fs := scatter.FreeSpins; fs != nil {
+		lines = append(lines, fmt.Sprintf("%d и больше S: %d бесплатных спинов, выигрыши x%s",
+			fs.Trigger, fs.Spins, fs.Multiplier))
+	}
+	return lines
+}
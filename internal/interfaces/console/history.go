@@ -0,0 +1,180 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"strings"
+	"time"
+)
+
+// historySorts - порядки сортировки в том же порядке, что и в меню
+var historySorts = []transaction.Sort{
+	transaction.SortNewest,
+	transaction.SortOldest,
+	transaction.SortAmountDesc,
+	transaction.SortAmountAsc,
+}
+
+// showHistory показывает историю транзакций текущего пользователя
+func (c *Console) showHistory() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("📜 ИСТОРИЯ ТРАНЗАКЦИЙ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	cmd, ok := c.readHistoryFilters()
+	if !ok {
+		return
+	}
+	cmd.UserID = c.currentUserID
+	c.browseHistory(cmd)
+}
+
+// showPlayerHistory позволяет службе поддержки посмотреть историю транзакций игрока
+func (c *Console) showPlayerHistory() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🔎 ИСТОРИЯ ТРАНЗАКЦИЙ ИГРОКА (ОПЕРАТОР)")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	fmt.Print("Имя пользователя игрока: ")
+	c.scanner.Scan()
+	username := strings.TrimSpace(c.scanner.Text())
+	if username == "" {
+		return
+	}
+
+	cmd, ok := c.readHistoryFilters()
+	if !ok {
+		return
+	}
+	cmd.Username = username
+	c.browseHistory(cmd)
+}
+
+// readHistoryFilters запрашивает фильтры истории; пустой ввод означает отсутствие фильтра
+func (c *Console) readHistoryFilters() (history.ListTransactionsCommand, bool) {
+	var cmd history.ListTransactionsCommand
+
+	fmt.Print("Типы через запятую (deposit, spin, win, withdrawal; пусто - все): ")
+	c.scanner.Scan()
+	for _, t := range strings.Split(c.scanner.Text(), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			cmd.Types = append(cmd.Types, t)
+		}
+	}
+
+	var err error
+	fmt.Print("С даты (YYYY-MM-DD, пусто - без ограничения): ")
+	c.scanner.Scan()
+	if s := strings.TrimSpace(c.scanner.Text()); s != "" {
+		if cmd.From, err = history.ParseDate(s, false); err != nil {
+			fmt.Println("❌ Неверный формат даты!")
+			fmt.Println()
+			return cmd, false
+		}
+	}
+	fmt.Print("По дату включительно (YYYY-MM-DD, пусто - без ограничения): ")
+	c.scanner.Scan()
+	if s := strings.TrimSpace(c.scanner.Text()); s != "" {
+		if cmd.To, err = history.ParseDate(s, true); err != nil {
+			fmt.Println("❌ Неверный формат даты!")
+			fmt.Println()
+			return cmd, false
+		}
+	}
+
+	fmt.Print("Сортировка: 1 - сначала новые, 2 - сначала старые, 3 - по убыванию суммы, 4 - по возрастанию суммы: ")
+	c.scanner.Scan()
+	switch s := strings.TrimSpace(c.scanner.Text()); s {
+	case "":
+	case "1", "2", "3", "4":
+		cmd.Sort = string(historySorts[s[0]-'1'])
+	default:
+		fmt.Println("❌ Неверный выбор.")
+		fmt.Println()
+		return cmd, false
+	}
+
+	return cmd, true
+}
+
+// browseHistory выводит историю постранично, пока пользователь листает страницы
+func (c *Console) browseHistory(cmd history.ListTransactionsCommand) {
+	for {
+		page, err := c.listTransactionsUseCase.Execute(cmd)
+		if err != nil {
+			switch {
+			case errors.Is(err, user.ErrUserNotFound):
+				fmt.Println("❌ Пользователь не найден")
+			case errors.Is(err, transaction.ErrInvalidType):
+				fmt.Println("❌ Неизвестный тип транзакции")
+			case errors.Is(err, transaction.ErrInvalidPeriod):
+				fmt.Println("❌ Начало периода должно быть раньше конца")
+			default:
+				fmt.Printf("❌ Ошибка при получении истории: %v\n", err)
+			}
+			fmt.Println()
+			return
+		}
+		if len(page.Transactions) == 0 && cmd.Cursor == "" {
+			fmt.Println("Транзакций не найдено")
+			fmt.Println()
+			return
+		}
+
+		for _, tx := range page.Transactions {
+			printTransaction(tx)
+		}
+		if page.NextCursor == "" {
+			fmt.Println("— конец истории —")
+			fmt.Println()
+			return
+		}
+
+		fmt.Print("n - следующая страница, пусто - назад: ")
+		c.scanner.Scan()
+		if strings.TrimSpace(c.scanner.Text()) != "n" {
+			fmt.Println()
+			return
+		}
+		cmd.Cursor = page.NextCursor
+	}
+}
+
+func printTransaction(tx history.TransactionResult) {
+	fmt.Printf("#%d  %s  %-20s %12s  баланс %s → %s\n",
+		tx.ID,
+		tx.CreatedAt.In(time.Local).Format("2006-01-02 15:04"),
+		transactionLabel(tx.Type),
+		tx.Amount.Format(),
+		tx.BalanceBefore.Format(),
+		tx.BalanceAfter.Format(),
+	)
+}
+
+func transactionLabel(t transaction.Type) string {
+	switch t {
+	case transaction.TypeDeposit:
+		return "пополнение"
+	case transaction.TypeSpin:
+		return "ставка"
+	case transaction.TypeWin:
+		return "выигрыш"
+	case transaction.TypeJackpotWin:
+		return "джекпот"
+	case transaction.TypeFreeSpinWin:
+		return "бесплатный спин"
+	case transaction.TypeWithdrawal:
+		return "заявка на вывод"
+	case transaction.TypeWithdrawalPayout:
+		return "выплата"
+	case transaction.TypeWithdrawalRefund:
+		return "возврат заявки"
+	default:
+		return string(t)
+	}
+}
@@ -0,0 +1,122 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/limits"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// manageLimits показывает лимиты ответственной игры и позволяет изменить один из них
+func (c *Console) manageLimits() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🛡  ЛИМИТЫ ОТВЕТСТВЕННОЙ ИГРЫ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	results, err := c.listLimitsUseCase.Execute(c.currentUserID)
+	if err != nil {
+		fmt.Printf("❌ Ошибка при получении лимитов: %v\n", err)
+		fmt.Println()
+		return
+	}
+	if len(results) == 0 {
+		fmt.Println("Лимиты не установлены")
+	}
+	for _, result := range results {
+		fmt.Printf("  • %s\n", formatLimit(result))
+	}
+	fmt.Println()
+	fmt.Println("Ужесточение действует сразу, ослабление и снятие - после периода охлаждения")
+	fmt.Print("Вид лимита (deposit, loss, wager, session; пусто - назад): ")
+
+	c.scanner.Scan()
+	kind := strings.TrimSpace(c.scanner.Text())
+	if kind == "" {
+		fmt.Println()
+		return
+	}
+
+	cmd := limits.SetLimitCommand{UserID: c.currentUserID, Kind: kind}
+	if limit.Kind(kind) == limit.KindSession {
+		fmt.Print("Длительность сессии в минутах (0 - снять лимит): ")
+		c.scanner.Scan()
+		minutes, err := strconv.Atoi(strings.TrimSpace(c.scanner.Text()))
+		if err != nil || minutes < 0 {
+			fmt.Println("❌ Неверная длительность!")
+			fmt.Println()
+			return
+		}
+		cmd.Duration = time.Duration(minutes) * time.Minute
+	} else {
+		fmt.Print("Период (day, week, month): ")
+		c.scanner.Scan()
+		cmd.Period = strings.TrimSpace(c.scanner.Text())
+
+		fmt.Print("Сумма лимита (0 - снять лимит): ")
+		c.scanner.Scan()
+		amount, err := money.Parse(strings.TrimSpace(c.scanner.Text()), c.currentBalance.Currency())
+		if err != nil || amount.IsNegative() {
+			fmt.Println("❌ Неверная сумма!")
+			fmt.Println()
+			return
+		}
+		cmd.Amount = amount
+	}
+
+	result, err := c.setLimitUseCase.Execute(cmd)
+	if err != nil {
+		switch {
+		case errors.Is(err, limit.ErrInvalidLimit):
+			fmt.Println("❌ Неизвестный вид или период лимита!")
+		case errors.Is(err, limit.ErrInvalidValue):
+			fmt.Println("❌ Неверное значение лимита!")
+		default:
+			fmt.Printf("❌ Ошибка при изменении лимита: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	switch {
+	case result == nil:
+		fmt.Println("ℹ️  Такой лимит не был установлен")
+	case result.Pending:
+		fmt.Printf("⏳ Изменение вступит в силу %s\n", result.PendingEffectiveAt.Local().Format("02.01.2006 15:04"))
+	default:
+		fmt.Printf("✅ Лимит установлен: %s\n", formatLimit(result))
+	}
+	fmt.Println()
+}
+
+// formatLimit форматирует лимит вместе с использованной частью и ожидающим изменением
+func formatLimit(result *limits.LimitResult) string {
+	var line string
+	if result.Kind == limit.KindSession {
+		line = fmt.Sprintf("session: %s, идет %s",
+			result.Duration, result.Elapsed.Truncate(time.Minute))
+	} else {
+		line = fmt.Sprintf("%s/%s: %s, использовано %s",
+			result.Kind, result.Period, result.Amount.Format(), result.Used.Format())
+	}
+	if !result.Pending {
+		return line
+	}
+
+	var pending string
+	switch {
+	case result.Kind == limit.KindSession && result.PendingDuration == 0,
+		result.Kind != limit.KindSession && result.PendingAmount.IsZero():
+		pending = "снятие"
+	case result.Kind == limit.KindSession:
+		pending = result.PendingDuration.String()
+	default:
+		pending = result.PendingAmount.Format()
+	}
+	return fmt.Sprintf("%s (с %s: %s)",
+		line, result.PendingEffectiveAt.Local().Format("02.01.2006 15:04"), pending)
+}
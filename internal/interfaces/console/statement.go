@@ -0,0 +1,82 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/application/use_case/statement"
+	"gambling/internal/domain/transaction"
+	"os"
+	"strings"
+	"time"
+)
+
+// exportStatement сохраняет выписку текущего пользователя в файл
+func (c *Console) exportStatement() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🧮 ВЫПИСКА ПО СЧЕТУ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	cmd := statement.GenerateCommand{UserID: c.currentUserID}
+	var err error
+	fmt.Print("С даты (YYYY-MM-DD, пусто - с открытия счета): ")
+	c.scanner.Scan()
+	if s := strings.TrimSpace(c.scanner.Text()); s != "" {
+		if cmd.From, err = history.ParseDate(s, false); err != nil {
+			fmt.Println("❌ Неверный формат даты!")
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Print("По дату включительно (YYYY-MM-DD, пусто - по сегодня): ")
+	c.scanner.Scan()
+	if s := strings.TrimSpace(c.scanner.Text()); s != "" {
+		if cmd.To, err = history.ParseDate(s, true); err != nil {
+			fmt.Println("❌ Неверный формат даты!")
+			fmt.Println()
+			return
+		}
+	}
+
+	fmt.Print("Формат: csv, json или pdf (пусто - csv): ")
+	c.scanner.Scan()
+	if cmd.Format, err = statement.ParseFormat(strings.TrimSpace(c.scanner.Text())); err != nil {
+		fmt.Println("❌ Неподдерживаемый формат!")
+		fmt.Println()
+		return
+	}
+
+	defaultPath := fmt.Sprintf("statement-%s-%s.%s", c.currentUsername, time.Now().Format("20060102"), cmd.Format)
+	fmt.Printf("Файл (пусто - %s): ", defaultPath)
+	c.scanner.Scan()
+	path := strings.TrimSpace(c.scanner.Text())
+	if path == "" {
+		path = defaultPath
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("❌ Не удалось создать файл: %v\n", err)
+		fmt.Println()
+		return
+	}
+	err = c.generateStatementUseCase.Execute(cmd, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Неполная выписка не оставляется на диске
+		_ = os.Remove(path)
+		if errors.Is(err, transaction.ErrInvalidPeriod) {
+			fmt.Println("❌ Начало периода должно быть раньше конца")
+		} else {
+			fmt.Printf("❌ Ошибка при построении выписки: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("✅ Выписка сохранена в %s\n", path)
+	fmt.Println()
+}
@@ -0,0 +1,164 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/spin"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	spinDomain "gambling/internal/domain/spin"
+	"gambling/internal/domain/user"
+	"strings"
+)
+
+// chooseGame предлагает выбрать игру
+func (c *Console) chooseGame() {
+	// Пока бонус не сыгран, платные спины недоступны
+	if c.hasFreeSpins() {
+		c.playFreeSpins()
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("1. Классический автомат (3 барабана)")
+	fmt.Printf("2. Видеослот 5x3 (%d линий)\n", c.videoPaytable.Lines())
+	fmt.Println("3. Автоигра")
+	fmt.Print("Выберите игру: ")
+
+	c.scanner.Scan()
+	switch strings.TrimSpace(c.scanner.Text()) {
+	case "1":
+		c.playSpin()
+	case "2":
+		c.playVideo()
+	case "3":
+		c.autoplay()
+	default:
+		fmt.Println("❌ Неверный выбор. Попробуйте снова.")
+	}
+}
+
+// playVideo обрабатывает раунд видеослота: ставка задается на линию
+func (c *Console) playVideo() {
+	lines := c.videoPaytable.Lines()
+
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("🎰 ВИДЕОСЛОТ 5x3 (%s)\n", c.videoPaytable.Version)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Текущий баланс: %s\n", c.currentBalance.Format())
+	fmt.Printf("Введите ставку на линию (линий: %d): ", lines)
+
+	c.scanner.Scan()
+	lineBetStr := strings.TrimSpace(c.scanner.Text())
+
+	lineBet, err := money.Parse(lineBetStr, c.currentBalance.Currency())
+	if err != nil || !lineBet.IsPositive() {
+		fmt.Println("❌ Неверная сумма ставки!")
+		fmt.Println()
+		return
+	}
+
+	totalBet, err := game.TotalBet(lineBet, lines)
+	if err != nil {
+		fmt.Printf("❌ Неверная ставка: %v\n", err)
+		fmt.Println()
+		return
+	}
+	if c.currentBalance.LessThan(totalBet) {
+		fmt.Printf("❌ Недостаточно средств: общая ставка %s\n", totalBet.Format())
+		fmt.Println()
+		return
+	}
+
+	result, err := c.spinUseCase.Execute(spin.SpinCommand{
+		UserID:  c.currentUserID,
+		GameID:  spinDomain.VideoGameID,
+		LineBet: lineBet,
+	})
+	if err != nil {
+		if err == user.ErrInsufficientFunds {
+			fmt.Println("❌ Недостаточно средств!")
+		} else if errors.Is(err, user.ErrFreeSpinsPending) {
+			fmt.Println("❌ Сначала сыграйте бесплатные спины!")
+		} else {
+			fmt.Printf("❌ Ошибка при игре: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	c.currentBalance = result.Balance
+
+	outcome, err := spinDomain.DecodeVideoOutcome(result.Outcome)
+	if err != nil {
+		fmt.Printf("❌ Ошибка при показе результата: %v\n", err)
+		fmt.Println()
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("Общая ставка: %s\n", result.BetAmount.Format())
+	c.showVideoGrid(outcome)
+
+	if result.IsWin {
+		fmt.Printf("🎉 ВЫИГРЫШ! Вы выиграли %s\n", result.WinAmount.Format())
+	} else {
+		fmt.Println("😔 Не повезло, попробуйте еще раз!")
+	}
+	fmt.Printf("💰 Ваш баланс: %s\n", result.Balance.Format())
+	if result.ServerSeedHash != "" {
+		fmt.Printf("🔐 Спин #%d: хеш сервера %s, клиентский сид %s, nonce %d\n",
+			result.SpinID, result.ServerSeedHash, result.ClientSeed, result.Nonce)
+	}
+	fmt.Println()
+
+	if result.FreeSpinsAwarded > 0 {
+		c.playFreeSpins()
+	}
+}
+
+// showVideoGrid показывает окно видеослота, выделяя скобками символы выигравших линий
+// Wild обозначается W, scatter - S
+func (c *Console) showVideoGrid(outcome *spinDomain.VideoOutcome) {
+	var winning [spinDomain.VideoRowCount][spinDomain.VideoReelCount]bool
+	for _, win := range outcome.Lines {
+		for reel, row := range win.Rows {
+			winning[row][reel] = true
+		}
+	}
+
+	fmt.Println("╔═════════════════════════╗")
+	for row, symbols := range outcome.Grid {
+		cells := make([]string, len(symbols))
+		for reel, symbol := range symbols {
+			label := symbolLabel(symbol, c.videoPaytable.Wild, c.videoPaytable.Scatter)
+			if winning[row][reel] {
+				cells[reel] = fmt.Sprintf("[%s]", label)
+			} else {
+				cells[reel] = fmt.Sprintf(" %s ", label)
+			}
+		}
+		fmt.Printf("║ %s ║\n", strings.Join(cells, "  "))
+	}
+	fmt.Println("╚═════════════════════════╝")
+
+	for _, win := range outcome.Lines {
+		fmt.Printf("  ✨ Линия %d: %d x символ %s, x%s = %s\n", win.Line, win.Count,
+			symbolLabel(win.Symbol, c.videoPaytable.Wild, c.videoPaytable.Scatter), win.Multiplier, win.Win.Format())
+	}
+	showScatter(outcome.Scatter)
+}
+
+// showScatter показывает выплату и бонус за scatter
+func showScatter(scatter *spinDomain.ScatterWin) {
+	if scatter == nil {
+		return
+	}
+	if scatter.Win.IsPositive() {
+		fmt.Printf("  ✨ Scatter: %d x S, x%s к общей ставке = %s\n", scatter.Count, scatter.Multiplier, scatter.Win.Format())
+	}
+	if scatter.FreeSpins > 0 {
+		fmt.Printf("  🎁 %d x S: %d бесплатных спинов!\n", scatter.Count, scatter.FreeSpins)
+	}
+}
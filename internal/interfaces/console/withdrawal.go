@@ -0,0 +1,191 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/balance"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/user"
+	"gambling/internal/domain/withdrawal"
+	"strconv"
+	"strings"
+)
+
+// withdrawalsPageSize - сколько заявок показывать в списке
+const withdrawalsPageSize = 20
+
+// withdraw обрабатывает создание заявки на вывод средств
+func (c *Console) withdraw() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🏦 ВЫВОД СРЕДСТВ")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Доступно для вывода: %s\n", c.currentBalance.Format())
+	fmt.Print("Введите сумму для вывода: ")
+
+	c.scanner.Scan()
+	amount, err := money.Parse(strings.TrimSpace(c.scanner.Text()), c.currentBalance.Currency())
+	if err != nil || !amount.IsPositive() {
+		fmt.Println("❌ Неверная сумма!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Print("Реквизиты для выплаты (карта или счет): ")
+	c.scanner.Scan()
+	destination := strings.TrimSpace(c.scanner.Text())
+
+	result, err := c.withdrawUseCase.Execute(balance.WithdrawCommand{
+		UserID:      c.currentUserID,
+		Amount:      amount,
+		Destination: destination,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrInsufficientFunds):
+			fmt.Println("❌ Недостаточно средств!")
+		case errors.Is(err, withdrawal.ErrInvalidDestination):
+			fmt.Println("❌ Неверные реквизиты для выплаты!")
+		default:
+			fmt.Printf("❌ Ошибка при создании заявки: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	c.currentBalance = result.Balance
+	fmt.Printf("✅ Заявка #%d на %s создана и ожидает рассмотрения\n",
+		result.Withdrawal.ID, result.Withdrawal.Amount.Format())
+	fmt.Printf("💰 Новый баланс: %s\n", result.Balance.Format())
+	fmt.Println()
+}
+
+// showWithdrawals показывает заявки на вывод текущего пользователя
+func (c *Console) showWithdrawals() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("📄 МОИ ЗАЯВКИ НА ВЫВОД")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	withdrawals, err := c.listWithdrawalsUseCase.Execute(balance.ListWithdrawalsCommand{
+		UserID: c.currentUserID,
+		Limit:  withdrawalsPageSize,
+	})
+	if err != nil {
+		fmt.Printf("❌ Ошибка при получении заявок: %v\n", err)
+		fmt.Println()
+		return
+	}
+	if len(withdrawals) == 0 {
+		fmt.Println("У вас пока нет заявок на вывод")
+		fmt.Println()
+		return
+	}
+
+	for _, w := range withdrawals {
+		printWithdrawal(w)
+	}
+	fmt.Println()
+}
+
+// reviewWithdrawals позволяет оператору одобрить или отклонить ожидающие заявки
+func (c *Console) reviewWithdrawals() {
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println("🧾 ЗАЯВКИ НА ВЫВОД (ОПЕРАТОР)")
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+
+	withdrawals, err := c.listWithdrawalsUseCase.Execute(balance.ListWithdrawalsCommand{
+		Status: withdrawal.StatusPending,
+		Limit:  withdrawalsPageSize,
+	})
+	if err != nil {
+		fmt.Printf("❌ Ошибка при получении заявок: %v\n", err)
+		fmt.Println()
+		return
+	}
+	if len(withdrawals) == 0 {
+		fmt.Println("Нет заявок, ожидающих рассмотрения")
+		fmt.Println()
+		return
+	}
+
+	for _, w := range withdrawals {
+		printWithdrawal(w)
+	}
+
+	fmt.Print("Номер заявки (пусто - назад): ")
+	c.scanner.Scan()
+	idStr := strings.TrimSpace(c.scanner.Text())
+	if idStr == "" {
+		return
+	}
+	withdrawalID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		fmt.Println("❌ Неверный номер заявки!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Print("Решение: 1 - одобрить, 2 - отклонить: ")
+	c.scanner.Scan()
+	decision := strings.TrimSpace(c.scanner.Text())
+
+	cmd := balance.ReviewWithdrawalCommand{
+		WithdrawalID: uint(withdrawalID),
+		OperatorID:   c.currentUserID,
+	}
+
+	var result *balance.WithdrawalResult
+	switch decision {
+	case "1":
+		result, err = c.approveWithdrawalUseCase.Execute(cmd)
+	case "2":
+		fmt.Print("Причина отказа: ")
+		c.scanner.Scan()
+		cmd.Reason = strings.TrimSpace(c.scanner.Text())
+		result, err = c.rejectWithdrawalUseCase.Execute(cmd)
+	default:
+		fmt.Println("❌ Неверный выбор.")
+		fmt.Println()
+		return
+	}
+	if err != nil {
+		if errors.Is(err, withdrawal.ErrNotPending) {
+			fmt.Println("❌ Заявка уже рассмотрена")
+		} else {
+			fmt.Printf("❌ Ошибка при рассмотрении заявки: %v\n", err)
+		}
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("✅ Заявка #%d: %s\n", result.ID, statusLabel(result.Status))
+	fmt.Println()
+}
+
+func printWithdrawal(w *balance.WithdrawalResult) {
+	fmt.Printf("#%d  %s  %s  %s  → %s\n",
+		w.ID,
+		w.CreatedAt.Format("2006-01-02 15:04"),
+		w.Amount.Format(),
+		statusLabel(w.Status),
+		w.Destination,
+	)
+	if w.Reason != "" {
+		fmt.Printf("     причина: %s\n", w.Reason)
+	}
+}
+
+func statusLabel(status withdrawal.Status) string {
+	switch status {
+	case withdrawal.StatusPending:
+		return "⏳ ожидает"
+	case withdrawal.StatusApproved:
+		return "✅ выплачена"
+	case withdrawal.StatusRejected:
+		return "❌ отклонена"
+	default:
+		return string(status)
+	}
+}
@@ -2,29 +2,43 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"gambling/internal/application/use_case/auth"
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/session"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
 // AuthHandler обрабатывает HTTP запросы для аутентификации
 // Это адаптер, который преобразует HTTP запросы в команды use case
 type AuthHandler struct {
-	registerUseCase *auth.RegisterUseCase
-	loginUseCase    *auth.LoginUseCase
-	logger          *slog.Logger
+	registerUseCase       *auth.RegisterUseCase
+	loginUseCase          *auth.LoginUseCase
+	refreshUseCase        *auth.RefreshUseCase
+	logoutUseCase         *auth.LogoutUseCase
+	revokeSessionsUseCase *auth.RevokeSessionsUseCase
+	logger                *slog.Logger
 }
 
 // NewAuthHandler создает новый экземпляр AuthHandler
 func NewAuthHandler(
 	registerUseCase *auth.RegisterUseCase,
 	loginUseCase *auth.LoginUseCase,
+	refreshUseCase *auth.RefreshUseCase,
+	logoutUseCase *auth.LogoutUseCase,
+	revokeSessionsUseCase *auth.RevokeSessionsUseCase,
 	logger *slog.Logger,
 ) *AuthHandler {
 	return &AuthHandler{
-		registerUseCase: registerUseCase,
-		loginUseCase:    loginUseCase,
-		logger:          logger,
+		registerUseCase:       registerUseCase,
+		loginUseCase:          loginUseCase,
+		refreshUseCase:        refreshUseCase,
+		logoutUseCase:         logoutUseCase,
+		revokeSessionsUseCase: revokeSessionsUseCase,
+		logger:                logger,
 	}
 }
 
@@ -36,11 +50,12 @@ type RegisterRequest struct {
 }
 
 // RegisterResponse представляет ответ на регистрацию
+// Суммы сериализуются точной десятичной строкой, например "100.50"
 type RegisterResponse struct {
-	ID       uint    `json:"id"`
-	Username string  `json:"username"`
-	Email    string  `json:"email"`
-	Balance  float64 `json:"balance"`
+	ID       uint        `json:"id"`
+	Username string      `json:"username"`
+	Email    string      `json:"email"`
+	Balance  money.Money `json:"balance"`
 }
 
 // Register обрабатывает запрос на регистрацию
@@ -68,6 +83,10 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	result, err := h.registerUseCase.Execute(cmd)
 	if err != nil {
 		h.logger.Error("failed to register user", "error", err)
+		if errors.Is(err, exclusion.ErrEmailBlocked) {
+			http.Error(w, "Регистрация с этим email невозможна: действует бессрочное самоисключение", http.StatusForbidden)
+			return
+		}
 		if err.Error() == "пользователь уже существует" {
 			http.Error(w, "Пользователь уже существует", http.StatusConflict)
 			return
@@ -96,12 +115,31 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+// TokenResponse представляет выданную пару токенов
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int64     `json:"expires_in"` // Секунды до истечения access токена
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
 // LoginResponse представляет ответ на вход
+// can_play равен false, пока действует самоограничение exclusion: игроку доступен
+// только вывод средств
 type LoginResponse struct {
-	ID       uint    `json:"id"`
-	Username string  `json:"username"`
-	Email    string  `json:"email"`
-	Balance  float64 `json:"balance"`
+	ID        uint               `json:"id"`
+	Username  string             `json:"username"`
+	Email     string             `json:"email"`
+	Balance   money.Money        `json:"balance"`
+	CanPlay   bool               `json:"can_play"`
+	Exclusion *ExclusionResponse `json:"exclusion,omitempty"`
+	TokenResponse
+}
+
+// RefreshTokenRequest представляет запрос с refresh токеном
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // Login обрабатывает запрос на вход
@@ -137,10 +175,19 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := LoginResponse{
-		ID:       result.ID,
-		Username: result.Username,
-		Email:    result.Email,
-		Balance:  result.Balance,
+		ID:            result.ID,
+		Username:      result.Username,
+		Email:         result.Email,
+		Balance:       result.Balance,
+		CanPlay:       result.Exclusion == nil,
+		TokenResponse: toTokenResponse(result.Tokens),
+	}
+	if e := result.Exclusion; e != nil {
+		response.Exclusion = &ExclusionResponse{
+			Kind:      string(e.Kind),
+			StartedAt: e.StartedAt,
+			Until:     e.Until,
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -149,3 +196,101 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Refresh обменивает refresh токен на новую пару токенов
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.decodeRefreshToken(w, r)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.refreshUseCase.Execute(auth.RefreshCommand{RefreshToken: req.RefreshToken})
+	if err != nil {
+		h.handleSessionError(w, "failed to refresh tokens", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toTokenResponse(tokens)); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// Logout завершает сессию, к которой относится refresh токен
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := h.decodeRefreshToken(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.logoutUseCase.Execute(auth.LogoutCommand{
+		UserID:       userID,
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		h.handleSessionError(w, "failed to logout", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeSessions завершает все сессии пользователя на всех устройствах
+func (h *AuthHandler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.revokeSessionsUseCase.Execute(auth.RevokeSessionsCommand{UserID: userID}); err != nil {
+		h.logger.Error("failed to revoke sessions", "error", err)
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AuthHandler) decodeRefreshToken(w http.ResponseWriter, r *http.Request) (*RefreshTokenRequest, bool) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return nil, false
+	}
+	if req.RefreshToken == "" {
+		http.Error(w, "refresh_token обязателен", http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+// handleSessionError преобразует ошибки сессий в HTTP ответы
+func (h *AuthHandler) handleSessionError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, session.ErrTokenReused):
+		http.Error(w, "Токен уже использован: сессия завершена, войдите заново", http.StatusUnauthorized)
+	case errors.Is(err, session.ErrTokenExpired):
+		http.Error(w, "Срок действия токена истек", http.StatusUnauthorized)
+	case errors.Is(err, session.ErrInvalidToken):
+		http.Error(w, "Неверный токен", http.StatusUnauthorized)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func toTokenResponse(tokens *auth.Tokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:      tokens.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(time.Until(tokens.AccessExpiresAt).Round(time.Second).Seconds()),
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
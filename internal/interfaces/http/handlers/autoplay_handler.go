@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/spin"
+	"gambling/internal/domain/money"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AutoplayHandler обрабатывает HTTP запросы автоигры
+type AutoplayHandler struct {
+	autoplayUseCase *spin.AutoplayUseCase
+	logger          *slog.Logger
+}
+
+// NewAutoplayHandler создает новый экземпляр AutoplayHandler
+func NewAutoplayHandler(autoplayUseCase *spin.AutoplayUseCase, logger *slog.Logger) *AutoplayHandler {
+	return &AutoplayHandler{
+		autoplayUseCase: autoplayUseCase,
+		logger:          logger,
+	}
+}
+
+// AutoplayRequest представляет запрос на автоигру
+// Условия остановки необязательны: не указанное условие не проверяется
+type AutoplayRequest struct {
+	BetAmount money.Money `json:"bet_amount"`
+	// LineBet - ставка на линию для игр с линиями выплат; заменяет bet_amount
+	LineBet          money.Money `json:"line_bet"`
+	Spins            int         `json:"spins"`
+	StopBalanceBelow money.Money `json:"stop_balance_below"`
+	StopWinAbove     money.Money `json:"stop_win_above"`
+	StopLossAbove    money.Money `json:"stop_loss_above"`
+	StopOnJackpot    bool        `json:"stop_on_jackpot"`
+}
+
+// AutoplayResponse представляет итоги автоигры - последнее событие потока
+// Сыгранные спины передаются до него отдельными событиями spin
+type AutoplayResponse struct {
+	StopReason string `json:"stop_reason"`
+	// Error - причина остановки при stop_reason limit или error
+	Error      string      `json:"error,omitempty"`
+	PaidSpins  int         `json:"paid_spins"`
+	FreeSpins  int         `json:"free_spins"`
+	TotalBet   money.Money `json:"total_bet"`
+	TotalWin   money.Money `json:"total_win"`
+	Net        money.Money `json:"net"`
+	BiggestWin money.Money `json:"biggest_win"`
+	// JackpotAmount - сумма выигранных джекпотов, входит в total_win
+	JackpotAmount *money.Money `json:"jackpot_amount,omitempty"`
+	Balance       money.Money  `json:"balance"`
+}
+
+// AutoplayEvent представляет событие потока в формате NDJSON:
+// event - spin (data - раунд, как в /games/{id}/play) или done (data - итоги)
+type AutoplayEvent struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// Autoplay обрабатывает запрос на автоигру в игре, указанной в пути
+// Ход игры передается потоком: с заголовком Accept: text/event-stream - как Server-Sent
+// Events, иначе - как NDJSON (по JSON объекту в строке). Если клиент отключился,
+// автоигра останавливается перед следующим спином
+func (h *AutoplayHandler) Autoplay(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req AutoplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	stream := newEventStream(w, strings.Contains(r.Header.Get("Accept"), "text/event-stream"))
+	cmd := spin.AutoplayCommand{
+		UserID:           userID,
+		GameID:           chi.URLParam(r, "gameID"),
+		BetAmount:        req.BetAmount,
+		LineBet:          req.LineBet,
+		Spins:            req.Spins,
+		StopBalanceBelow: req.StopBalanceBelow,
+		StopWinAbove:     req.StopWinAbove,
+		StopLossAbove:    req.StopLossAbove,
+		StopOnJackpot:    req.StopOnJackpot,
+	}
+	result, err := h.autoplayUseCase.Execute(r.Context(), cmd, func(s *spin.SpinResult, _ *spin.AutoplayResult) {
+		if err := stream.send("spin", toPlayResponse(s)); err != nil {
+			h.logger.Error("failed to stream autoplay spin", "error", err, "spin_id", s.SpinID)
+		}
+	})
+	if err != nil {
+		// Ни один спин не сыгран, поэтому поток еще не начат и можно ответить обычной ошибкой
+		h.logger.Error("failed to start autoplay", "error", err, "game_id", cmd.GameID)
+		if errors.Is(err, spin.ErrInvalidAutoplay) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeSpinError(w, err)
+		return
+	}
+
+	if result.Err != nil {
+		h.logger.Error("autoplay stopped on error", "error", result.Err, "stop_reason", result.StopReason)
+	}
+	if err := stream.send("done", toAutoplayResponse(result)); err != nil {
+		h.logger.Error("failed to stream autoplay result", "error", err)
+	}
+}
+
+func toAutoplayResponse(result *spin.AutoplayResult) AutoplayResponse {
+	response := AutoplayResponse{
+		StopReason:    string(result.StopReason),
+		PaidSpins:     result.PaidSpins,
+		FreeSpins:     result.FreeSpins,
+		TotalBet:      result.TotalBet,
+		TotalWin:      result.TotalWin,
+		Net:           result.Net,
+		BiggestWin:    result.BiggestWin,
+		JackpotAmount: optionalAmount(result.JackpotAmount),
+		Balance:       result.Balance,
+	}
+	switch result.StopReason {
+	case spin.StopLimit:
+		// Сообщения лимитов и самоограничения предназначены игроку
+		response.Error = result.Err.Error()
+	case spin.StopError:
+		response.Error = "Ошибка при выполнении спина"
+	}
+	return response
+}
+
+// eventStream пишет события в ответ по мере их появления
+// Заголовки отправляются вместе с первым событием
+type eventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	sse     bool
+	started bool
+}
+
+func newEventStream(w http.ResponseWriter, sse bool) *eventStream {
+	return &eventStream{
+		w:   w,
+		rc:  http.NewResponseController(w),
+		sse: sse,
+	}
+}
+
+// send пишет событие и сразу отправляет его клиенту
+func (s *eventStream) send(event string, data any) error {
+	if !s.started {
+		if s.sse {
+			s.w.Header().Set("Content-Type", "text/event-stream")
+		} else {
+			s.w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if s.sse {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+			return err
+		}
+	} else if err := json.NewEncoder(s.w).Encode(AutoplayEvent{Event: event, Data: data}); err != nil {
+		return err
+	}
+
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"gambling/internal/application/use_case/balance"
+	"gambling/internal/domain/idempotency"
+	"gambling/internal/domain/money"
+	mvIdempotency "gambling/internal/interfaces/http/middleware/idempotency"
 	"log/slog"
 	"net/http"
-	"strconv"
 )
 
 // BalanceHandler обрабатывает HTTP запросы для работы с балансом
@@ -23,27 +26,22 @@ func NewBalanceHandler(depositUseCase *balance.DepositUseCase, logger *slog.Logg
 }
 
 // DepositRequest представляет запрос на пополнение баланса
+// Сумма принимается строкой ("100.50") или числом и разбирается без потери точности
 type DepositRequest struct {
-	Amount float64 `json:"amount"`
+	Amount money.Money `json:"amount"`
 }
 
 // DepositResponse представляет ответ на пополнение баланса
+// Баланс сериализуется точной десятичной строкой
 type DepositResponse struct {
-	Balance float64 `json:"balance"`
+	Balance money.Money `json:"balance"`
 }
 
 // Deposit обрабатывает запрос на пополнение баланса
 func (h *BalanceHandler) Deposit(w http.ResponseWriter, r *http.Request) {
-	// Получаем userID из параметров запроса (в реальном приложении из JWT токена)
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		http.Error(w, "user_id обязателен", http.StatusBadRequest)
-		return
-	}
-
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		http.Error(w, "Неверный формат user_id", http.StatusBadRequest)
+	// Получаем userID из access токена (его проверяет middleware auth)
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
 		return
 	}
 
@@ -56,14 +54,22 @@ func (h *BalanceHandler) Deposit(w http.ResponseWriter, r *http.Request) {
 
 	// Преобразуем HTTP запрос в команду use case
 	cmd := balance.DepositCommand{
-		UserID: uint(userID),
-		Amount: req.Amount,
+		UserID:         userID,
+		Amount:         req.Amount,
+		IdempotencyKey: mvIdempotency.KeyFromContext(r.Context()),
 	}
 
 	// Выполняем use case
 	result, err := h.depositUseCase.Execute(cmd)
 	if err != nil {
 		h.logger.Error("failed to deposit", "error", err)
+		if writeExcluded(w, err) || writeLimitExceeded(w, err) {
+			return
+		}
+		if errors.Is(err, idempotency.ErrAlreadyApplied) {
+			http.Error(w, "Операция с этим ключом идемпотентности уже выполнена", http.StatusConflict)
+			return
+		}
 		if err.Error() == "неверная сумма" {
 			http.Error(w, "Неверная сумма", http.StatusBadRequest)
 			return
@@ -81,4 +87,3 @@ func (h *BalanceHandler) Deposit(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("failed to encode response", "error", err)
 	}
 }
-
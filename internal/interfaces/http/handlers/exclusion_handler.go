@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/exclusions"
+	"gambling/internal/domain/exclusion"
+	"gambling/internal/domain/user"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ExclusionHandler обрабатывает HTTP запросы самоограничений игроков
+type ExclusionHandler struct {
+	excludeUseCase *exclusions.ExcludeUseCase
+	liftUseCase    *exclusions.LiftExclusionUseCase
+	getUseCase     *exclusions.GetExclusionUseCase
+	logger         *slog.Logger
+}
+
+// NewExclusionHandler создает новый экземпляр ExclusionHandler
+func NewExclusionHandler(
+	excludeUseCase *exclusions.ExcludeUseCase,
+	liftUseCase *exclusions.LiftExclusionUseCase,
+	getUseCase *exclusions.GetExclusionUseCase,
+	logger *slog.Logger,
+) *ExclusionHandler {
+	return &ExclusionHandler{
+		excludeUseCase: excludeUseCase,
+		liftUseCase:    liftUseCase,
+		getUseCase:     getUseCase,
+		logger:         logger,
+	}
+}
+
+// ExcludeRequest представляет запрос на самоограничение
+// kind: cool_off (period: 24h, 7d, 30d) или self_exclusion (period: 6m, 1y, 5y, permanent)
+type ExcludeRequest struct {
+	Kind   string `json:"kind"`
+	Period string `json:"period"`
+	Reason string `json:"reason"`
+}
+
+// LiftExclusionRequest представляет запрос оператора на снятие самоограничения
+type LiftExclusionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ExclusionResponse представляет действующее самоограничение
+// until отсутствует у бессрочного самоисключения
+type ExclusionResponse struct {
+	Kind      string     `json:"kind"`
+	StartedAt time.Time  `json:"started_at"`
+	Until     *time.Time `json:"until,omitempty"`
+}
+
+// ExclusionEventResponse представляет запись журнала аудита
+type ExclusionEventResponse struct {
+	ID        uint       `json:"id"`
+	ActorID   uint       `json:"actor_id"`
+	Action    string     `json:"action"`
+	Kind      string     `json:"kind"`
+	Until     *time.Time `json:"until,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ExclusionStatusResponse представляет самоограничение игрока и журнал аудита
+type ExclusionStatusResponse struct {
+	Active *ExclusionResponse       `json:"active"`
+	Events []ExclusionEventResponse `json:"events"`
+}
+
+// ExcludeSelf устанавливает самоограничение текущему пользователю
+// После этого все его сессии завершаются
+func (h *ExclusionHandler) ExcludeSelf(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	h.exclude(w, r, userID, userID)
+}
+
+// ExcludeUser устанавливает самоограничение игроку из пути по его обращению. Доступно операторам
+func (h *ExclusionHandler) ExcludeUser(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	h.exclude(w, r, userID, operatorID)
+}
+
+// Lift досрочно снимает самоограничение игрока из пути. Доступно операторам
+func (h *ExclusionHandler) Lift(w http.ResponseWriter, r *http.Request) {
+	operatorID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// Тело запроса необязательно: причину можно не указывать
+	var req LiftExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	err := h.liftUseCase.Execute(exclusions.LiftExclusionCommand{
+		UserID:     userID,
+		OperatorID: operatorID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.handleError(w, "failed to lift exclusion", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get возвращает самоограничение игрока из пути и журнал аудита. Доступно операторам
+func (h *ExclusionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.getUseCase.Execute(userID)
+	if err != nil {
+		h.handleError(w, "failed to get exclusion", err)
+		return
+	}
+
+	response := ExclusionStatusResponse{Events: make([]ExclusionEventResponse, len(status.Events))}
+	if status.Active != nil {
+		active := toExclusionResponse(status.Active)
+		response.Active = &active
+	}
+	for i, event := range status.Events {
+		response.Events[i] = ExclusionEventResponse{
+			ID:        event.ID,
+			ActorID:   event.ActorID,
+			Action:    string(event.Action),
+			Kind:      string(event.Kind),
+			Until:     event.Until,
+			Reason:    event.Reason,
+			CreatedAt: event.CreatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.encode(w, response)
+}
+
+func (h *ExclusionHandler) exclude(w http.ResponseWriter, r *http.Request, userID, actorID uint) {
+	var req ExcludeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.excludeUseCase.Execute(exclusions.ExcludeCommand{
+		UserID:  userID,
+		ActorID: actorID,
+		Kind:    req.Kind,
+		Period:  req.Period,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		h.handleError(w, "failed to set exclusion", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	h.encode(w, toExclusionResponse(result))
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *ExclusionHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, user.ErrInvalidExclusion):
+		http.Error(w, "Неизвестный вид или срок самоограничения", http.StatusBadRequest)
+	case errors.Is(err, exclusion.ErrInvalidReason):
+		http.Error(w, "Слишком длинный комментарий", http.StatusBadRequest)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	case errors.Is(err, user.ErrExclusionShortened):
+		http.Error(w, "Действующее самоограничение нельзя сократить", http.StatusConflict)
+	case errors.Is(err, user.ErrNotExcluded):
+		http.Error(w, "Самоограничение не действует", http.StatusConflict)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func (h *ExclusionHandler) encode(w http.ResponseWriter, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// writeExcluded отвечает 403, если игрок ограничил себе игру
+// Возвращает false, если err не связана с самоограничением
+func writeExcluded(w http.ResponseWriter, err error) bool {
+	var excluded *user.ExcludedError
+	if !errors.As(err, &excluded) {
+		return false
+	}
+	http.Error(w, excluded.Error(), http.StatusForbidden)
+	return true
+}
+
+// userIDFromPath получает ID игрока из пути; при ошибке пишет ответ 400
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID пользователя", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+func toExclusionResponse(result *exclusions.ExclusionResult) ExclusionResponse {
+	return ExclusionResponse{
+		Kind:      string(result.Kind),
+		StartedAt: result.StartedAt,
+		Until:     result.Until,
+	}
+}
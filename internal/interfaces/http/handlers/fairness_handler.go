@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	fairnessUseCase "gambling/internal/application/use_case/fairness"
+	"gambling/internal/domain/fairness"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/spin"
+	"gambling/internal/domain/user"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// revealedSeedsLimit - сколько последних раскрытых сидов возвращать
+const revealedSeedsLimit = 50
+
+// FairnessHandler обрабатывает HTTP запросы доказуемо честной игры
+type FairnessHandler struct {
+	getSeedsUseCase     *fairnessUseCase.GetSeedsUseCase
+	rotateSeedsUseCase  *fairnessUseCase.RotateSeedsUseCase
+	listRevealedUseCase *fairnessUseCase.ListRevealedSeedsUseCase
+	verifySpinUseCase   *fairnessUseCase.VerifySpinUseCase
+	logger              *slog.Logger
+}
+
+// NewFairnessHandler создает новый экземпляр FairnessHandler
+func NewFairnessHandler(
+	getSeedsUseCase *fairnessUseCase.GetSeedsUseCase,
+	rotateSeedsUseCase *fairnessUseCase.RotateSeedsUseCase,
+	listRevealedUseCase *fairnessUseCase.ListRevealedSeedsUseCase,
+	verifySpinUseCase *fairnessUseCase.VerifySpinUseCase,
+	logger *slog.Logger,
+) *FairnessHandler {
+	return &FairnessHandler{
+		getSeedsUseCase:     getSeedsUseCase,
+		rotateSeedsUseCase:  rotateSeedsUseCase,
+		listRevealedUseCase: listRevealedUseCase,
+		verifySpinUseCase:   verifySpinUseCase,
+		logger:              logger,
+	}
+}
+
+// SeedsResponse представляет активную пару сидов
+type SeedsResponse struct {
+	ServerSeedHash string `json:"server_seed_hash"`
+	ClientSeed     string `json:"client_seed"`
+	Nonce          uint64 `json:"nonce"`
+}
+
+// RevealedSeedResponse представляет раскрытую пару сидов
+type RevealedSeedResponse struct {
+	ServerSeed     string    `json:"server_seed"`
+	ServerSeedHash string    `json:"server_seed_hash"`
+	ClientSeed     string    `json:"client_seed"`
+	Rounds         uint64    `json:"rounds"`
+	RevealedAt     time.Time `json:"revealed_at"`
+}
+
+// RotateSeedsRequest представляет запрос на ротацию сидов
+type RotateSeedsRequest struct {
+	ClientSeed string `json:"client_seed"`
+}
+
+// RotateSeedsResponse представляет ответ на ротацию сидов
+type RotateSeedsResponse struct {
+	Previous *RevealedSeedResponse `json:"previous"`
+	Current  SeedsResponse         `json:"current"`
+}
+
+// VerifySpinResponse представляет результат проверки спина
+type VerifySpinResponse struct {
+	SpinID          uint            `json:"spin_id"`
+	GameID          string          `json:"game_id"`
+	ServerSeed      string          `json:"server_seed"`
+	ServerSeedHash  string          `json:"server_seed_hash"`
+	ClientSeed      string          `json:"client_seed"`
+	Nonce           uint64          `json:"nonce"`
+	RecordedOutcome json.RawMessage `json:"recorded_outcome"`
+	ComputedOutcome json.RawMessage `json:"computed_outcome"`
+	RecordedWin     money.Money     `json:"recorded_win"`
+	ComputedWin     money.Money     `json:"computed_win"`
+	// FreeSpinMultiplier - коэффициент бонуса, на который умножен выигрыш бесплатного спина
+	FreeSpinMultiplier *spin.Multiplier `json:"free_spin_multiplier,omitempty"`
+	Valid              bool             `json:"valid"`
+}
+
+// GetSeeds возвращает активную пару сидов (хеш серверного сида, клиентский сид, nonce)
+func (h *FairnessHandler) GetSeeds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.getSeedsUseCase.Execute(fairnessUseCase.GetSeedsCommand{UserID: userID})
+	if err != nil {
+		h.handleError(w, "failed to get seeds", err)
+		return
+	}
+
+	h.writeJSON(w, toSeedsResponse(result))
+}
+
+// RotateSeeds раскрывает текущий серверный сид и выдает новую пару
+func (h *FairnessHandler) RotateSeeds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	// Тело запроса необязательно: без него клиентский сид генерируется случайно
+	var req RotateSeedsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.rotateSeedsUseCase.Execute(fairnessUseCase.RotateSeedsCommand{
+		UserID:     userID,
+		ClientSeed: req.ClientSeed,
+	})
+	if err != nil {
+		h.handleError(w, "failed to rotate seeds", err)
+		return
+	}
+
+	response := RotateSeedsResponse{
+		Current: toSeedsResponse(result.Current),
+	}
+	if result.Previous != nil {
+		previous := toRevealedSeedResponse(result.Previous)
+		response.Previous = &previous
+	}
+
+	h.writeJSON(w, response)
+}
+
+// ListRevealedSeeds возвращает раскрытые серверные сиды пользователя
+func (h *FairnessHandler) ListRevealedSeeds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	seeds, err := h.listRevealedUseCase.Execute(fairnessUseCase.ListRevealedSeedsCommand{
+		UserID: userID,
+		Limit:  revealedSeedsLimit,
+	})
+	if err != nil {
+		h.handleError(w, "failed to list revealed seeds", err)
+		return
+	}
+
+	response := make([]RevealedSeedResponse, len(seeds))
+	for i, seed := range seeds {
+		response[i] = toRevealedSeedResponse(seed)
+	}
+
+	h.writeJSON(w, response)
+}
+
+// VerifySpin пересчитывает исход спина из раскрытого серверного сида
+func (h *FairnessHandler) VerifySpin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	spinID, err := strconv.ParseUint(chi.URLParam(r, "spinID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID спина", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.verifySpinUseCase.Execute(fairnessUseCase.VerifySpinCommand{
+		UserID: userID,
+		SpinID: uint(spinID),
+	})
+	if err != nil {
+		h.handleError(w, "failed to verify spin", err)
+		return
+	}
+
+	response := VerifySpinResponse{
+		SpinID:          result.SpinID,
+		GameID:          result.GameID,
+		ServerSeed:      result.ServerSeed,
+		ServerSeedHash:  result.ServerSeedHash,
+		ClientSeed:      result.ClientSeed,
+		Nonce:           result.Nonce,
+		RecordedOutcome: result.RecordedOutcome,
+		ComputedOutcome: result.ComputedOutcome,
+		RecordedWin:     result.RecordedWin,
+		ComputedWin:     result.ComputedWin,
+		Valid:           result.Valid,
+	}
+	if !result.WinMultiplier.IsZero() {
+		response.FreeSpinMultiplier = &result.WinMultiplier
+	}
+	h.writeJSON(w, response)
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *FairnessHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	case errors.Is(err, spin.ErrResultNotFound):
+		http.Error(w, "Спин не найден", http.StatusNotFound)
+	case errors.Is(err, fairness.ErrInvalidClientSeed):
+		http.Error(w, "Неверный клиентский сид", http.StatusBadRequest)
+	case errors.Is(err, fairness.ErrSeedNotRevealed):
+		http.Error(w, "Серверный сид еще не раскрыт: выполните ротацию сидов", http.StatusConflict)
+	case errors.Is(err, fairness.ErrNotProvablyFair):
+		http.Error(w, "Спин сыгран не в режиме доказуемо честной игры", http.StatusConflict)
+	case errors.Is(err, fairness.ErrPaytableMismatch):
+		http.Error(w, "Спин сыгран по неизвестной версии таблицы выплат", http.StatusConflict)
+	case errors.Is(err, game.ErrGameNotFound):
+		http.Error(w, "Игра, в которой сыгран спин, больше не доступна", http.StatusConflict)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func (h *FairnessHandler) writeJSON(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func toSeedsResponse(result *fairnessUseCase.SeedsResult) SeedsResponse {
+	return SeedsResponse{
+		ServerSeedHash: result.ServerSeedHash,
+		ClientSeed:     result.ClientSeed,
+		Nonce:          result.Nonce,
+	}
+}
+
+func toRevealedSeedResponse(result *fairnessUseCase.RevealedSeedResult) RevealedSeedResponse {
+	return RevealedSeedResponse{
+		ServerSeed:     result.ServerSeed,
+		ServerSeedHash: result.ServerSeedHash,
+		ClientSeed:     result.ClientSeed,
+		Rounds:         result.Rounds,
+		RevealedAt:     result.RevealedAt,
+	}
+}
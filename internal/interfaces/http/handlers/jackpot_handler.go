@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/jackpots"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/money"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// JackpotHandler обрабатывает HTTP запросы прогрессивного джекпота
+type JackpotHandler struct {
+	getUseCase *jackpots.GetJackpotUseCase
+	logger     *slog.Logger
+}
+
+// NewJackpotHandler создает новый экземпляр JackpotHandler
+func NewJackpotHandler(getUseCase *jackpots.GetJackpotUseCase, logger *slog.Logger) *JackpotHandler {
+	return &JackpotHandler{
+		getUseCase: getUseCase,
+		logger:     logger,
+	}
+}
+
+// JackpotResponse представляет текущую сумму пула и последние выигрыши
+type JackpotResponse struct {
+	Amount  money.Money             `json:"amount"`
+	Winners []JackpotWinnerResponse `json:"winners"`
+}
+
+// JackpotWinnerResponse представляет выигрыш джекпота
+// Ответ публичный, поэтому игрок не указывается
+type JackpotWinnerResponse struct {
+	Amount    money.Money `json:"amount"`
+	BetAmount money.Money `json:"bet_amount"`
+	WonAt     time.Time   `json:"won_at"`
+}
+
+// Get возвращает текущую сумму пула джекпота и последние выигрыши
+func (h *JackpotHandler) Get(w http.ResponseWriter, r *http.Request) {
+	status, err := h.getUseCase.Execute()
+	if err != nil {
+		if errors.Is(err, jackpot.ErrDisabled) {
+			http.Error(w, "Джекпот не разыгрывается", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to get jackpot", "error", err)
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+		return
+	}
+
+	response := JackpotResponse{
+		Amount:  status.Amount,
+		Winners: make([]JackpotWinnerResponse, len(status.Winners)),
+	}
+	for i, winner := range status.Winners {
+		response.Winners[i] = JackpotWinnerResponse{
+			Amount:    winner.Amount,
+			BetAmount: winner.BetAmount,
+			WonAt:     winner.CreatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
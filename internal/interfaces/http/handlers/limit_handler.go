@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/limits"
+	"gambling/internal/domain/limit"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/user"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LimitHandler обрабатывает HTTP запросы лимитов ответственной игры
+type LimitHandler struct {
+	setUseCase  *limits.SetLimitUseCase
+	listUseCase *limits.ListLimitsUseCase
+	logger      *slog.Logger
+}
+
+// NewLimitHandler создает новый экземпляр LimitHandler
+func NewLimitHandler(
+	setUseCase *limits.SetLimitUseCase,
+	listUseCase *limits.ListLimitsUseCase,
+	logger *slog.Logger,
+) *LimitHandler {
+	return &LimitHandler{
+		setUseCase:  setUseCase,
+		listUseCase: listUseCase,
+		logger:      logger,
+	}
+}
+
+// SetLimitRequest представляет запрос на установку лимита
+// Для денежных лимитов задается amount, для лимита сессии - duration ("2h30m");
+// нулевое значение снимает лимит
+type SetLimitRequest struct {
+	Kind     string      `json:"kind"`
+	Period   string      `json:"period"`
+	Amount   money.Money `json:"amount"`
+	Duration string      `json:"duration"`
+}
+
+// LimitResponse представляет лимит игрока
+// У денежных лимитов заполнены amount и used, у лимита сессии - duration и elapsed
+type LimitResponse struct {
+	Kind     string                `json:"kind"`
+	Period   string                `json:"period"`
+	Amount   *money.Money          `json:"amount,omitempty"`
+	Used     *money.Money          `json:"used,omitempty"`
+	Duration string                `json:"duration,omitempty"`
+	Elapsed  string                `json:"elapsed,omitempty"`
+	Pending  *PendingLimitResponse `json:"pending,omitempty"`
+}
+
+// PendingLimitResponse представляет ослабление лимита, ожидающее окончания периода охлаждения
+// removed означает, что по истечении периода лимит будет снят
+type PendingLimitResponse struct {
+	Amount      *money.Money `json:"amount,omitempty"`
+	Duration    string       `json:"duration,omitempty"`
+	Removed     bool         `json:"removed"`
+	EffectiveAt time.Time    `json:"effective_at"`
+}
+
+// List возвращает лимиты текущего пользователя
+func (h *LimitHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.listUseCase.Execute(userID)
+	if err != nil {
+		h.handleError(w, "failed to list limits", err)
+		return
+	}
+
+	response := make([]LimitResponse, len(results))
+	for i, result := range results {
+		response[i] = toLimitResponse(result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.encode(w, response)
+}
+
+// Set устанавливает, изменяет или снимает лимит текущего пользователя
+// Ужесточение действует сразу (200), ослабление откладывается на период охлаждения (202)
+func (h *LimitHandler) Set(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req SetLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	cmd := limits.SetLimitCommand{
+		UserID: userID,
+		Kind:   req.Kind,
+		Period: req.Period,
+		Amount: req.Amount,
+	}
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			http.Error(w, "Неверная длительность сессии", http.StatusBadRequest)
+			return
+		}
+		cmd.Duration = duration
+	}
+
+	h.set(w, cmd)
+}
+
+// Remove снимает лимит вида kind за период period
+// Снятие - это ослабление, поэтому оно вступает в силу после периода охлаждения
+func (h *LimitHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	h.set(w, limits.SetLimitCommand{
+		UserID: userID,
+		Kind:   chi.URLParam(r, "kind"),
+		Period: chi.URLParam(r, "period"),
+	})
+}
+
+func (h *LimitHandler) set(w http.ResponseWriter, cmd limits.SetLimitCommand) {
+	result, err := h.setUseCase.Execute(cmd)
+	if err != nil {
+		h.handleError(w, "failed to set limit", err)
+		return
+	}
+	// Снят лимит, который не был установлен
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if result.Pending {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	h.encode(w, toLimitResponse(result))
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *LimitHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, limit.ErrInvalidLimit):
+		http.Error(w, "Неизвестный вид или период лимита", http.StatusBadRequest)
+	case errors.Is(err, limit.ErrInvalidValue):
+		http.Error(w, "Неверное значение лимита", http.StatusBadRequest)
+	case errors.Is(err, money.ErrCurrencyMismatch):
+		http.Error(w, "Валюта лимита не совпадает с валютой счета", http.StatusBadRequest)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func (h *LimitHandler) encode(w http.ResponseWriter, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// writeLimitExceeded отвечает 403, если операцию не дает выполнить лимит ответственной игры
+// Возвращает false, если err не связана с лимитами
+func writeLimitExceeded(w http.ResponseWriter, err error) bool {
+	var exceeded *limit.ExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+	http.Error(w, exceeded.Error(), http.StatusForbidden)
+	return true
+}
+
+func toLimitResponse(result *limits.LimitResult) LimitResponse {
+	response := LimitResponse{
+		Kind:   string(result.Kind),
+		Period: string(result.Period),
+	}
+	if result.Kind == limit.KindSession {
+		response.Duration = result.Duration.String()
+		response.Elapsed = result.Elapsed.Truncate(time.Second).String()
+	} else {
+		amount, used := result.Amount, result.Used
+		response.Amount = &amount
+		response.Used = &used
+	}
+
+	if result.Pending {
+		pending := &PendingLimitResponse{EffectiveAt: result.PendingEffectiveAt}
+		switch {
+		case result.Kind == limit.KindSession && result.PendingDuration == 0,
+			result.Kind != limit.KindSession && result.PendingAmount.IsZero():
+			pending.Removed = true
+		case result.Kind == limit.KindSession:
+			pending.Duration = result.PendingDuration.String()
+		default:
+			amount := result.PendingAmount
+			pending.Amount = &amount
+		}
+		response.Pending = pending
+	}
+	return response
+}
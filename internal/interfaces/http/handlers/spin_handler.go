@@ -2,92 +2,486 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/history"
 	"gambling/internal/application/use_case/spin"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/idempotency"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/pagination"
+	spinDomain "gambling/internal/domain/spin"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	mvIdempotency "gambling/internal/interfaces/http/middleware/idempotency"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // SpinHandler обрабатывает HTTP запросы для игры на спинах
 type SpinHandler struct {
-	spinUseCase *spin.SpinUseCase
-	logger      *slog.Logger
+	games            *game.Registry
+	spinUseCase      *spin.SpinUseCase
+	freeSpinsUseCase *spin.GetFreeSpinsUseCase
+	listUseCase      *history.ListSpinsUseCase
+	getUseCase       *history.GetSpinUseCase
+	logger           *slog.Logger
 }
 
 // NewSpinHandler создает новый экземпляр SpinHandler
-func NewSpinHandler(spinUseCase *spin.SpinUseCase, logger *slog.Logger) *SpinHandler {
+func NewSpinHandler(
+	games *game.Registry,
+	spinUseCase *spin.SpinUseCase,
+	freeSpinsUseCase *spin.GetFreeSpinsUseCase,
+	listUseCase *history.ListSpinsUseCase,
+	getUseCase *history.GetSpinUseCase,
+	logger *slog.Logger,
+) *SpinHandler {
 	return &SpinHandler{
-		spinUseCase: spinUseCase,
-		logger:      logger,
+		games:            games,
+		spinUseCase:      spinUseCase,
+		freeSpinsUseCase: freeSpinsUseCase,
+		listUseCase:      listUseCase,
+		getUseCase:       getUseCase,
+		logger:           logger,
 	}
 }
 
 // SpinRequest представляет запрос на спин
+// Ставка принимается строкой ("10.00") или числом и разбирается без потери точности
 type SpinRequest struct {
-	BetAmount float64 `json:"bet_amount"`
+	BetAmount money.Money `json:"bet_amount"`
+	// LineBet - ставка на линию для игр с линиями выплат; заменяет bet_amount
+	LineBet money.Money `json:"line_bet"`
 }
 
-// SpinResponse представляет ответ на спин
+// SpinResponse представляет ответ на спин классического автомата
+// Суммы сериализуются точной десятичной строкой
+// Поля сидов заполняются в provably fair режиме и нужны для последующей проверки раунда
 type SpinResponse struct {
-	Reel1     int     `json:"reel1"`
-	Reel2     int     `json:"reel2"`
-	Reel3     int     `json:"reel3"`
-	IsWin     bool    `json:"is_win"`
-	WinAmount float64 `json:"win_amount"`
-	Balance   float64 `json:"balance"`
+	SpinID    uint        `json:"spin_id"`
+	RoundID   string      `json:"round_id"`
+	Reel1     int         `json:"reel1"`
+	Reel2     int         `json:"reel2"`
+	Reel3     int         `json:"reel3"`
+	IsWin     bool        `json:"is_win"`
+	WinAmount money.Money `json:"win_amount"`
+	Balance   money.Money `json:"balance"`
+	// JackpotAmount - выплата из пула джекпота, входит в win_amount
+	JackpotAmount *money.Money `json:"jackpot_amount,omitempty"`
+	// FreeSpinsAwarded - бесплатные спины, выигранные в раунде
+	FreeSpinsAwarded int `json:"free_spins_awarded,omitempty"`
+
+	ServerSeedHash string `json:"server_seed_hash,omitempty"`
+	ClientSeed     string `json:"client_seed,omitempty"`
+	Nonce          uint64 `json:"nonce"`
+}
+
+// PlayResponse представляет ответ на раунд игры из реестра
+// outcome - исход раунда в формате игры (для классического автомата {"reels": [...]})
+type PlayResponse struct {
+	SpinID    uint            `json:"spin_id"`
+	RoundID   string          `json:"round_id"`
+	GameID    string          `json:"game_id"`
+	Outcome   json.RawMessage `json:"outcome"`
+	BetAmount money.Money     `json:"bet_amount"`
+	IsWin     bool            `json:"is_win"`
+	WinAmount money.Money     `json:"win_amount"`
+	Balance   money.Money     `json:"balance"`
+	// JackpotAmount - выплата из пула джекпота, входит в win_amount
+	JackpotAmount *money.Money `json:"jackpot_amount,omitempty"`
+
+	// FreeSpin - раунд сыгран как бесплатный спин бонуса; trigger_spin_id - спин, запустивший бонус
+	FreeSpin      bool `json:"free_spin"`
+	TriggerSpinID uint `json:"trigger_spin_id,omitempty"`
+	// FreeSpinsAwarded - бесплатные спины, выигранные в раунде;
+	// FreeSpinsRemaining - сколько бесплатных спинов осталось после раунда
+	FreeSpinsAwarded   int `json:"free_spins_awarded,omitempty"`
+	FreeSpinsRemaining int `json:"free_spins_remaining"`
+
+	ServerSeedHash string `json:"server_seed_hash,omitempty"`
+	ClientSeed     string `json:"client_seed,omitempty"`
+	Nonce          uint64 `json:"nonce"`
+}
+
+// FreeSpinsResponse представляет бонус бесплатных спинов игрока
+// Если бонуса нет, active равно false, а остальные поля отсутствуют
+type FreeSpinsResponse struct {
+	Active        bool                   `json:"active"`
+	GameID        string                 `json:"game_id,omitempty"`
+	BetAmount     *money.Money           `json:"bet_amount,omitempty"`
+	Remaining     int                    `json:"remaining"`
+	Multiplier    *spinDomain.Multiplier `json:"multiplier,omitempty"`
+	TriggerSpinID uint                   `json:"trigger_spin_id,omitempty"`
+	Won           *money.Money           `json:"won,omitempty"`
+	AwardedAt     *time.Time             `json:"awarded_at,omitempty"`
+}
+
+// GameResponse представляет игру в списке доступных игр
+type GameResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Jackpot bool   `json:"jackpot"`
+	// Lines - число линий выплат (только у игр с линиями)
+	Lines int `json:"lines,omitempty"`
+}
+
+// ListGames возвращает игры, доступные для раундов через /games/{gameID}/play
+func (h *SpinHandler) ListGames(w http.ResponseWriter, r *http.Request) {
+	games := h.games.List()
+	response := make([]GameResponse, len(games))
+	for i, g := range games {
+		response[i] = GameResponse{
+			ID:      g.ID(),
+			Name:    g.Name(),
+			Version: g.Version(),
+			Jackpot: g.HasJackpot(),
+		}
+		if lines, ok := g.(game.LineGame); ok {
+			response[i].Lines = lines.Lines()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// Play обрабатывает запрос на раунд игры, указанной в пути
+func (h *SpinHandler) Play(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.play(w, r, chi.URLParam(r, "gameID"))
+	if !ok {
+		return
+	}
+	h.writePlayResponse(w, result)
+}
+
+// FreeSpin обрабатывает запрос на бесплатный спин из бонуса игрока в игре, указанной в пути
+// Тело запроса не нужно: ставка берется из бонуса
+func (h *SpinHandler) FreeSpin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	result, ok := h.execute(w, spin.SpinCommand{
+		UserID:         userID,
+		GameID:         chi.URLParam(r, "gameID"),
+		FreeSpin:       true,
+		IdempotencyKey: mvIdempotency.KeyFromContext(r.Context()),
+	})
+	if !ok {
+		return
+	}
+	h.writePlayResponse(w, result)
+}
+
+// GetFreeSpins возвращает несыгранный бонус бесплатных спинов текущего пользователя
+func (h *SpinHandler) GetFreeSpins(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.freeSpinsUseCase.Execute(userID)
+	if err != nil {
+		h.logger.Error("failed to get free spins", "error", err)
+		if errors.Is(err, user.ErrUserNotFound) {
+			http.Error(w, "Пользователь не найден", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+		return
+	}
+
+	response := FreeSpinsResponse{}
+	if status != nil {
+		response = FreeSpinsResponse{
+			Active:        true,
+			GameID:        status.GameID,
+			BetAmount:     &status.Bet,
+			Remaining:     status.Remaining,
+			Multiplier:    &status.Multiplier,
+			TriggerSpinID: status.TriggerSpinID,
+			Won:           &status.Won,
+			AwardedAt:     &status.AwardedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// writePlayResponse пишет ответ на раунд игры из реестра
+func (h *SpinHandler) writePlayResponse(w http.ResponseWriter, result *spin.SpinResult) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toPlayResponse(result)); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
 }
 
-// Spin обрабатывает запрос на выполнение спина
+func toPlayResponse(result *spin.SpinResult) PlayResponse {
+	return PlayResponse{
+		SpinID:    result.SpinID,
+		RoundID:   result.RoundID,
+		GameID:    result.GameID,
+		Outcome:   result.Outcome,
+		BetAmount: result.BetAmount,
+		IsWin:     result.IsWin,
+		WinAmount: result.WinAmount,
+		Balance:   result.Balance,
+
+		JackpotAmount: optionalAmount(result.JackpotAmount),
+
+		FreeSpin:           result.FreeSpin,
+		TriggerSpinID:      result.TriggerSpinID,
+		FreeSpinsAwarded:   result.FreeSpinsAwarded,
+		FreeSpinsRemaining: result.FreeSpinsRemaining,
+
+		ServerSeedHash: result.ServerSeedHash,
+		ClientSeed:     result.ClientSeed,
+		Nonce:          result.Nonce,
+	}
+}
+
+// Spin обрабатывает запрос на выполнение спина классического автомата
+// Сохранен для совместимости: то же, что /games/classic/play, но с полями reel1-reel3
 func (h *SpinHandler) Spin(w http.ResponseWriter, r *http.Request) {
-	// Получаем userID из параметров запроса (в реальном приложении из JWT токена)
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		http.Error(w, "user_id обязателен", http.StatusBadRequest)
+	result, ok := h.play(w, r, spinDomain.ClassicGameID)
+	if !ok {
 		return
 	}
 
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	reels, err := spinDomain.DecodeReels(result.Outcome)
 	if err != nil {
-		http.Error(w, "Неверный формат user_id", http.StatusBadRequest)
+		// Раунд уже сыгран и записан, поэтому ошибка только в представлении ответа
+		h.logger.Error("failed to decode reels", "error", err, "spin_id", result.SpinID)
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
 		return
 	}
 
+	response := SpinResponse{
+		SpinID:    result.SpinID,
+		RoundID:   result.RoundID,
+		Reel1:     reels[0],
+		Reel2:     reels[1],
+		Reel3:     reels[2],
+		IsWin:     result.IsWin,
+		WinAmount: result.WinAmount,
+		Balance:   result.Balance,
+
+		JackpotAmount:    optionalAmount(result.JackpotAmount),
+		FreeSpinsAwarded: result.FreeSpinsAwarded,
+
+		ServerSeedHash: result.ServerSeedHash,
+		ClientSeed:     result.ClientSeed,
+		Nonce:          result.Nonce,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// play разбирает ставку и разыгрывает раунд игры gameID
+// При ошибке пишет HTTP ответ и возвращает false
+func (h *SpinHandler) play(w http.ResponseWriter, r *http.Request, gameID string) (*spin.SpinResult, bool) {
+	// Получаем userID из access токена (его проверяет middleware auth)
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return nil, false
+	}
+
 	var req SpinRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.logger.Error("failed to decode request", "error", err)
 		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 
 	// Преобразуем HTTP запрос в команду use case
-	cmd := spin.SpinCommand{
-		UserID:    uint(userID),
-		BetAmount: req.BetAmount,
-	}
+	return h.execute(w, spin.SpinCommand{
+		UserID:         userID,
+		GameID:         gameID,
+		BetAmount:      req.BetAmount,
+		LineBet:        req.LineBet,
+		IdempotencyKey: mvIdempotency.KeyFromContext(r.Context()),
+	})
+}
 
-	// Выполняем use case
+// execute разыгрывает раунд по команде
+// При ошибке пишет HTTP ответ и возвращает false
+func (h *SpinHandler) execute(w http.ResponseWriter, cmd spin.SpinCommand) (*spin.SpinResult, bool) {
 	result, err := h.spinUseCase.Execute(cmd)
 	if err != nil {
-		h.logger.Error("failed to spin", "error", err)
-		if err.Error() == "неверная сумма" {
-			http.Error(w, "Неверная сумма ставки", http.StatusBadRequest)
+		h.logger.Error("failed to spin", "error", err, "game_id", cmd.GameID, "free_spin", cmd.FreeSpin)
+		writeSpinError(w, err)
+		return nil, false
+	}
+	return result, true
+}
+
+// writeSpinError пишет HTTP ответ для ошибки раунда
+func writeSpinError(w http.ResponseWriter, err error) {
+	if writeExcluded(w, err) || writeLimitExceeded(w, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, idempotency.ErrAlreadyApplied):
+		http.Error(w, "Операция с этим ключом идемпотентности уже выполнена", http.StatusConflict)
+	case errors.Is(err, game.ErrGameNotFound):
+		http.Error(w, "Игра не найдена", http.StatusNotFound)
+	case errors.Is(err, game.ErrInvalidBet):
+		http.Error(w, "Ставка не подходит для этой игры", http.StatusBadRequest)
+	case errors.Is(err, money.ErrOverflow):
+		http.Error(w, "Ставка слишком велика", http.StatusBadRequest)
+	case errors.Is(err, money.ErrCurrencyMismatch):
+		http.Error(w, "Валюта ставки не поддерживается", http.StatusBadRequest)
+	case errors.Is(err, user.ErrFreeSpinsPending):
+		http.Error(w, "Сначала сыграйте бесплатные спины: GET /api/v1/free-spins", http.StatusConflict)
+	case errors.Is(err, user.ErrNoFreeSpins):
+		http.Error(w, "Нет бесплатных спинов в этой игре", http.StatusConflict)
+	case err.Error() == "неверная сумма":
+		http.Error(w, "Неверная сумма ставки", http.StatusBadRequest)
+	case err.Error() == "недостаточно средств":
+		http.Error(w, "Недостаточно средств", http.StatusBadRequest)
+	default:
+		http.Error(w, "Ошибка при выполнении спина", http.StatusInternalServerError)
+	}
+}
+
+// SpinSummaryResponse представляет спин в истории
+// reels заполняется только для раундов классического автомата
+type SpinSummaryResponse struct {
+	ID        uint            `json:"id"`
+	RoundID   string          `json:"round_id,omitempty"`
+	GameID    string          `json:"game_id"`
+	Outcome   json.RawMessage `json:"outcome"`
+	Reels     *[3]int         `json:"reels,omitempty"`
+	BetAmount money.Money     `json:"bet_amount"`
+	WinAmount money.Money     `json:"win_amount"`
+	IsWin     bool            `json:"is_win"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	JackpotAmount *money.Money `json:"jackpot_amount,omitempty"`
+	// FreeSpin - раунд сыгран как бесплатный спин бонуса, запущенного спином trigger_spin_id
+	FreeSpin      bool `json:"free_spin,omitempty"`
+	TriggerSpinID uint `json:"trigger_spin_id,omitempty"`
+}
+
+// SpinsPageResponse представляет страницу истории спинов
+// next_cursor отсутствует на последней странице
+type SpinsPageResponse struct {
+	Spins      []SpinSummaryResponse `json:"spins"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+// SpinDetailsResponse представляет раунд вместе с его транзакциями
+type SpinDetailsResponse struct {
+	SpinSummaryResponse
+	PaytableVersion      string       `json:"paytable_version"`
+	BetTransactionID     uint         `json:"bet_transaction_id,omitempty"`
+	WinTransactionID     uint         `json:"win_transaction_id,omitempty"`
+	JackpotTransactionID uint         `json:"jackpot_transaction_id,omitempty"`
+	BalanceBefore        *money.Money `json:"balance_before,omitempty"`
+	BalanceAfter         *money.Money `json:"balance_after,omitempty"`
+	SeedPairID           uint         `json:"seed_pair_id,omitempty"`
+	Nonce                uint64       `json:"nonce,omitempty"`
+}
+
+// List возвращает историю спинов текущего пользователя, начиная с последних
+// Параметры запроса: outcome (win/loss), min_bet, max_bet, from, to, cursor, limit
+func (h *SpinHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	cmd := history.ListSpinsCommand{
+		UserID:  userID,
+		Outcome: query.Get("outcome"),
+		MinBet:  query.Get("min_bet"),
+		MaxBet:  query.Get("max_bet"),
+		Cursor:  query.Get("cursor"),
+	}
+	var err error
+	if s := query.Get("from"); s != "" {
+		if cmd.From, err = history.ParseDate(s, false); err != nil {
+			h.handleHistoryError(w, "invalid spins query", err)
 			return
 		}
-		if err.Error() == "недостаточно средств" {
-			http.Error(w, "Недостаточно средств", http.StatusBadRequest)
+	}
+	if s := query.Get("to"); s != "" {
+		if cmd.To, err = history.ParseDate(s, true); err != nil {
+			h.handleHistoryError(w, "invalid spins query", err)
 			return
 		}
-		http.Error(w, "Ошибка при выполнении спина", http.StatusInternalServerError)
+	}
+	if s := query.Get("limit"); s != "" {
+		if cmd.Limit, err = strconv.Atoi(s); err != nil || cmd.Limit == 0 {
+			h.handleHistoryError(w, "invalid spins query", pagination.ErrInvalidLimit)
+			return
+		}
+	}
+
+	page, err := h.listUseCase.Execute(cmd)
+	if err != nil {
+		h.handleHistoryError(w, "failed to list spins", err)
 		return
 	}
 
-	response := SpinResponse{
-		Reel1:     result.Reel1,
-		Reel2:     result.Reel2,
-		Reel3:     result.Reel3,
-		IsWin:     result.IsWin,
-		WinAmount: result.WinAmount,
-		Balance:   result.Balance,
+	response := SpinsPageResponse{
+		Spins:      make([]SpinSummaryResponse, len(page.Spins)),
+		NextCursor: page.NextCursor,
+	}
+	for i, s := range page.Spins {
+		response.Spins[i] = toSpinSummaryResponse(s)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// Get возвращает раунд текущего пользователя со ссылками на его транзакции
+func (h *SpinHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	spinID, err := strconv.ParseUint(chi.URLParam(r, "spinID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID спина", http.StatusBadRequest)
+		return
+	}
+
+	details, err := h.getUseCase.Execute(history.GetSpinCommand{UserID: userID, SpinID: uint(spinID)})
+	if err != nil {
+		h.handleHistoryError(w, "failed to get spin", err)
+		return
+	}
+
+	response := SpinDetailsResponse{
+		SpinSummaryResponse:  toSpinSummaryResponse(details.SpinSummary),
+		PaytableVersion:      details.PaytableVersion,
+		BetTransactionID:     details.BetTransactionID,
+		WinTransactionID:     details.WinTransactionID,
+		JackpotTransactionID: details.JackpotTransactionID,
+		BalanceBefore:        details.BalanceBefore,
+		BalanceAfter:         details.BalanceAfter,
+		SeedPairID:           details.SeedPairID,
+		Nonce:                details.Nonce,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -96,3 +490,54 @@ func (h *SpinHandler) Spin(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleHistoryError преобразует ошибки просмотра истории в HTTP ответы
+func (h *SpinHandler) handleHistoryError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, spinDomain.ErrInvalidOutcome):
+		http.Error(w, "Неизвестный исход: ожидается win или loss", http.StatusBadRequest)
+	case errors.Is(err, user.ErrInvalidAmount):
+		http.Error(w, "Неверная граница ставки", http.StatusBadRequest)
+	case errors.Is(err, spinDomain.ErrInvalidBetRange):
+		http.Error(w, "Минимальная ставка больше максимальной", http.StatusBadRequest)
+	case errors.Is(err, transaction.ErrInvalidPeriod):
+		http.Error(w, "Начало периода должно быть раньше конца", http.StatusBadRequest)
+	case errors.Is(err, history.ErrInvalidDate):
+		http.Error(w, "Неверный формат даты: ожидается YYYY-MM-DD или RFC 3339", http.StatusBadRequest)
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		http.Error(w, "Неверный курсор страницы", http.StatusBadRequest)
+	case errors.Is(err, pagination.ErrInvalidLimit):
+		http.Error(w, "Размер страницы должен быть от 1 до 100", http.StatusBadRequest)
+	case errors.Is(err, spinDomain.ErrResultNotFound):
+		http.Error(w, "Спин не найден", http.StatusNotFound)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func toSpinSummaryResponse(s history.SpinSummary) SpinSummaryResponse {
+	return SpinSummaryResponse{
+		ID:        s.ID,
+		RoundID:   s.RoundID,
+		GameID:    s.GameID,
+		Outcome:   s.Outcome,
+		Reels:     s.Reels,
+		BetAmount: s.BetAmount,
+		WinAmount: s.WinAmount,
+		IsWin:     s.IsWin,
+		CreatedAt: s.CreatedAt,
+
+		JackpotAmount: optionalAmount(s.JackpotAmount),
+		FreeSpin:      s.TriggerSpinID != 0,
+		TriggerSpinID: s.TriggerSpinID,
+	}
+}
+
+// optionalAmount возвращает nil для нулевой суммы, чтобы поле не попало в ответ
+func optionalAmount(amount money.Money) *money.Money {
+	if amount.IsZero() {
+		return nil
+	}
+	return &amount
+}
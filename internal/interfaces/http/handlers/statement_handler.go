@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/application/use_case/statement"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StatementHandler обрабатывает HTTP запросы выписок по счету
+type StatementHandler struct {
+	generateUseCase *statement.GenerateUseCase
+	logger          *slog.Logger
+}
+
+// NewStatementHandler создает новый экземпляр StatementHandler
+func NewStatementHandler(generateUseCase *statement.GenerateUseCase, logger *slog.Logger) *StatementHandler {
+	return &StatementHandler{
+		generateUseCase: generateUseCase,
+		logger:          logger,
+	}
+}
+
+// DownloadOwn выгружает выписку текущего пользователя
+// Параметры запроса: format (csv, json, pdf), from, to
+func (h *StatementHandler) DownloadOwn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	h.download(w, r, userID)
+}
+
+// DownloadByUser выгружает выписку игрока из пути. Доступно операторам
+func (h *StatementHandler) DownloadByUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID пользователя", http.StatusBadRequest)
+		return
+	}
+
+	h.download(w, r, uint(userID))
+}
+
+func (h *StatementHandler) download(w http.ResponseWriter, r *http.Request, userID uint) {
+	query := r.URL.Query()
+	format, err := statement.ParseFormat(query.Get("format"))
+	if err != nil {
+		h.handleError(w, "invalid statement query", err)
+		return
+	}
+
+	cmd := statement.GenerateCommand{UserID: userID, Format: format}
+	if s := query.Get("from"); s != "" {
+		if cmd.From, err = history.ParseDate(s, false); err != nil {
+			h.handleError(w, "invalid statement query", err)
+			return
+		}
+	}
+	if s := query.Get("to"); s != "" {
+		if cmd.To, err = history.ParseDate(s, true); err != nil {
+			h.handleError(w, "invalid statement query", err)
+			return
+		}
+	}
+
+	// Заголовки ответа выставляются при первой записи: пока выписка не начала
+	// выводиться, ошибку еще можно вернуть обычным ответом с кодом
+	out := &streamResponse{
+		ResponseWriter: w,
+		contentType:    format.ContentType(),
+		filename:       fmt.Sprintf("statement-%d-%s.%s", userID, time.Now().Format("20060102"), format),
+	}
+	if err := h.generateUseCase.Execute(cmd, out); err != nil {
+		if !out.started {
+			h.handleError(w, "failed to generate statement", err)
+			return
+		}
+		// Часть выписки уже отправлена: клиент получит оборванный файл
+		h.logger.Error("statement stream interrupted", "error", err, "user_id", userID)
+	}
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *StatementHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, statement.ErrUnsupportedFormat):
+		http.Error(w, "Неподдерживаемый формат: ожидается csv, json или pdf", http.StatusBadRequest)
+	case errors.Is(err, transaction.ErrInvalidPeriod):
+		http.Error(w, "Начало периода должно быть раньше конца", http.StatusBadRequest)
+	case errors.Is(err, history.ErrInvalidDate):
+		http.Error(w, "Неверный формат даты: ожидается YYYY-MM-DD или RFC 3339", http.StatusBadRequest)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+// streamResponse выставляет заголовки файла при первой записи тела
+type streamResponse struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (s *streamResponse) Write(b []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		s.Header().Set("Content-Type", s.contentType)
+		s.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", s.filename))
+		s.WriteHeader(http.StatusOK)
+	}
+	return s.ResponseWriter.Write(b)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/history"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/pagination"
+	"gambling/internal/domain/transaction"
+	"gambling/internal/domain/user"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TransactionHandler обрабатывает HTTP запросы истории транзакций
+type TransactionHandler struct {
+	listUseCase *history.ListTransactionsUseCase
+	logger      *slog.Logger
+}
+
+// NewTransactionHandler создает новый экземпляр TransactionHandler
+func NewTransactionHandler(listUseCase *history.ListTransactionsUseCase, logger *slog.Logger) *TransactionHandler {
+	return &TransactionHandler{
+		listUseCase: listUseCase,
+		logger:      logger,
+	}
+}
+
+// TransactionResponse представляет транзакцию в истории
+type TransactionResponse struct {
+	ID            uint        `json:"id"`
+	RoundID       string      `json:"round_id,omitempty"`
+	Type          string      `json:"type"`
+	Amount        money.Money `json:"amount"`
+	BalanceBefore money.Money `json:"balance_before"`
+	BalanceAfter  money.Money `json:"balance_after"`
+	Description   string      `json:"description,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// TransactionsPageResponse представляет страницу истории
+// next_cursor отсутствует на последней странице
+type TransactionsPageResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// ListOwn возвращает историю транзакций текущего пользователя
+// Параметры запроса: type (через запятую), from, to, sort, cursor, limit
+func (h *TransactionHandler) ListOwn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	h.list(w, r, userID)
+}
+
+// ListByUser возвращает историю транзакций игрока из пути. Доступно операторам
+func (h *TransactionHandler) ListByUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseUint(chi.URLParam(r, "userID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID пользователя", http.StatusBadRequest)
+		return
+	}
+
+	h.list(w, r, uint(userID))
+}
+
+func (h *TransactionHandler) list(w http.ResponseWriter, r *http.Request, userID uint) {
+	cmd, err := listTransactionsCommand(r.URL.Query())
+	if err != nil {
+		h.handleError(w, "invalid transactions query", err)
+		return
+	}
+	cmd.UserID = userID
+
+	page, err := h.listUseCase.Execute(cmd)
+	if err != nil {
+		h.handleError(w, "failed to list transactions", err)
+		return
+	}
+
+	response := TransactionsPageResponse{
+		Transactions: make([]TransactionResponse, len(page.Transactions)),
+		NextCursor:   page.NextCursor,
+	}
+	for i, tx := range page.Transactions {
+		response.Transactions[i] = TransactionResponse{
+			ID:            tx.ID,
+			RoundID:       tx.RoundID,
+			Type:          string(tx.Type),
+			Amount:        tx.Amount,
+			BalanceBefore: tx.BalanceBefore,
+			BalanceAfter:  tx.BalanceAfter,
+			Description:   tx.Description,
+			CreatedAt:     tx.CreatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+// listTransactionsCommand собирает команду из параметров запроса
+// Типы принимаются через запятую (type=deposit,win) или повтором параметра
+func listTransactionsCommand(query url.Values) (history.ListTransactionsCommand, error) {
+	cmd := history.ListTransactionsCommand{
+		Sort:   query.Get("sort"),
+		Cursor: query.Get("cursor"),
+	}
+	for _, value := range query["type"] {
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				cmd.Types = append(cmd.Types, t)
+			}
+		}
+	}
+
+	var err error
+	if s := query.Get("from"); s != "" {
+		if cmd.From, err = history.ParseDate(s, false); err != nil {
+			return cmd, err
+		}
+	}
+	if s := query.Get("to"); s != "" {
+		if cmd.To, err = history.ParseDate(s, true); err != nil {
+			return cmd, err
+		}
+	}
+	if s := query.Get("limit"); s != "" {
+		if cmd.Limit, err = strconv.Atoi(s); err != nil || cmd.Limit == 0 {
+			return cmd, pagination.ErrInvalidLimit
+		}
+	}
+	return cmd, nil
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *TransactionHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, transaction.ErrInvalidType):
+		http.Error(w, "Неизвестный тип транзакции", http.StatusBadRequest)
+	case errors.Is(err, transaction.ErrInvalidSort):
+		http.Error(w, "Неизвестный порядок сортировки", http.StatusBadRequest)
+	case errors.Is(err, transaction.ErrInvalidPeriod):
+		http.Error(w, "Начало периода должно быть раньше конца", http.StatusBadRequest)
+	case errors.Is(err, history.ErrInvalidDate):
+		http.Error(w, "Неверный формат даты: ожидается YYYY-MM-DD или RFC 3339", http.StatusBadRequest)
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		http.Error(w, "Неверный курсор страницы", http.StatusBadRequest)
+	case errors.Is(err, pagination.ErrInvalidLimit):
+		http.Error(w, "Размер страницы должен быть от 1 до 100", http.StatusBadRequest)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
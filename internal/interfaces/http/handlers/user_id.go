@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	authMiddleware "gambling/internal/interfaces/http/middleware/auth"
+	"net/http"
+)
+
+// userIDFromContext получает ID пользователя, аутентифицированного middleware auth
+// Если маршрут не защищен middleware, пишет ответ 401 и возвращает false
+func userIDFromContext(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userID, ok := authMiddleware.UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Требуется авторизация", http.StatusUnauthorized)
+		return 0, false
+	}
+	return userID, true
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gambling/internal/application/use_case/balance"
+	"gambling/internal/domain/money"
+	"gambling/internal/domain/user"
+	"gambling/internal/domain/withdrawal"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// withdrawalsListLimit - сколько заявок возвращать в списке
+const withdrawalsListLimit = 100
+
+// WithdrawalHandler обрабатывает HTTP запросы вывода средств
+type WithdrawalHandler struct {
+	withdrawUseCase *balance.WithdrawUseCase
+	approveUseCase  *balance.ApproveWithdrawalUseCase
+	rejectUseCase   *balance.RejectWithdrawalUseCase
+	listUseCase     *balance.ListWithdrawalsUseCase
+	logger          *slog.Logger
+}
+
+// NewWithdrawalHandler создает новый экземпляр WithdrawalHandler
+func NewWithdrawalHandler(
+	withdrawUseCase *balance.WithdrawUseCase,
+	approveUseCase *balance.ApproveWithdrawalUseCase,
+	rejectUseCase *balance.RejectWithdrawalUseCase,
+	listUseCase *balance.ListWithdrawalsUseCase,
+	logger *slog.Logger,
+) *WithdrawalHandler {
+	return &WithdrawalHandler{
+		withdrawUseCase: withdrawUseCase,
+		approveUseCase:  approveUseCase,
+		rejectUseCase:   rejectUseCase,
+		listUseCase:     listUseCase,
+		logger:          logger,
+	}
+}
+
+// WithdrawRequest представляет запрос на вывод средств
+type WithdrawRequest struct {
+	Amount      money.Money `json:"amount"`
+	Destination string      `json:"destination"`
+}
+
+// WithdrawalResponse представляет заявку на вывод
+type WithdrawalResponse struct {
+	ID          uint        `json:"id"`
+	UserID      uint        `json:"user_id"`
+	Amount      money.Money `json:"amount"`
+	Destination string      `json:"destination"`
+	Status      string      `json:"status"`
+	Reason      string      `json:"reason,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	ReviewedAt  *time.Time  `json:"reviewed_at,omitempty"`
+}
+
+// WithdrawResponse представляет ответ на создание заявки
+type WithdrawResponse struct {
+	Withdrawal WithdrawalResponse `json:"withdrawal"`
+	Balance    money.Money        `json:"balance"`
+}
+
+// RejectWithdrawalRequest представляет запрос на отклонение заявки
+type RejectWithdrawalRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Withdraw создает заявку на вывод и резервирует средства
+func (h *WithdrawalHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req WithdrawRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.withdrawUseCase.Execute(balance.WithdrawCommand{
+		UserID:      userID,
+		Amount:      req.Amount,
+		Destination: req.Destination,
+	})
+	if err != nil {
+		h.handleError(w, "failed to create withdrawal", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	h.encode(w, WithdrawResponse{
+		Withdrawal: toWithdrawalResponse(result.Withdrawal),
+		Balance:    result.Balance,
+	})
+}
+
+// ListOwn возвращает заявки на вывод текущего пользователя
+func (h *WithdrawalHandler) ListOwn(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(w, r)
+	if !ok {
+		return
+	}
+
+	h.list(w, balance.ListWithdrawalsCommand{UserID: userID, Limit: withdrawalsListLimit})
+}
+
+// ListByStatus возвращает заявки в состоянии из параметра status (по умолчанию pending)
+// Доступно операторам
+func (h *WithdrawalHandler) ListByStatus(w http.ResponseWriter, r *http.Request) {
+	status := withdrawal.StatusPending
+	if s := r.URL.Query().Get("status"); s != "" {
+		status = withdrawal.Status(s)
+	}
+
+	h.list(w, balance.ListWithdrawalsCommand{Status: status, Limit: withdrawalsListLimit})
+}
+
+// Approve одобряет заявку на вывод. Доступно операторам
+func (h *WithdrawalHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := h.reviewCommand(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.approveUseCase.Execute(cmd)
+	if err != nil {
+		h.handleError(w, "failed to approve withdrawal", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.encode(w, toWithdrawalResponse(result))
+}
+
+// Reject отклоняет заявку на вывод и возвращает средства игроку. Доступно операторам
+func (h *WithdrawalHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	cmd, ok := h.reviewCommand(w, r)
+	if !ok {
+		return
+	}
+
+	// Тело запроса необязательно: причину отказа можно не указывать
+	var req RejectWithdrawalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error("failed to decode request", "error", err)
+		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+		return
+	}
+	cmd.Reason = req.Reason
+
+	result, err := h.rejectUseCase.Execute(cmd)
+	if err != nil {
+		h.handleError(w, "failed to reject withdrawal", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.encode(w, toWithdrawalResponse(result))
+}
+
+func (h *WithdrawalHandler) list(w http.ResponseWriter, cmd balance.ListWithdrawalsCommand) {
+	withdrawals, err := h.listUseCase.Execute(cmd)
+	if err != nil {
+		h.handleError(w, "failed to list withdrawals", err)
+		return
+	}
+
+	response := make([]WithdrawalResponse, len(withdrawals))
+	for i, result := range withdrawals {
+		response[i] = toWithdrawalResponse(result)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	h.encode(w, response)
+}
+
+func (h *WithdrawalHandler) reviewCommand(w http.ResponseWriter, r *http.Request) (balance.ReviewWithdrawalCommand, bool) {
+	operatorID, ok := userIDFromContext(w, r)
+	if !ok {
+		return balance.ReviewWithdrawalCommand{}, false
+	}
+
+	withdrawalID, err := strconv.ParseUint(chi.URLParam(r, "withdrawalID"), 10, 32)
+	if err != nil {
+		http.Error(w, "Неверный формат ID заявки", http.StatusBadRequest)
+		return balance.ReviewWithdrawalCommand{}, false
+	}
+
+	return balance.ReviewWithdrawalCommand{
+		WithdrawalID: uint(withdrawalID),
+		OperatorID:   operatorID,
+	}, true
+}
+
+// handleError преобразует доменные ошибки в HTTP ответы
+func (h *WithdrawalHandler) handleError(w http.ResponseWriter, msg string, err error) {
+	h.logger.Error(msg, "error", err)
+
+	switch {
+	case errors.Is(err, user.ErrInsufficientFunds):
+		http.Error(w, "Недостаточно средств", http.StatusBadRequest)
+	case errors.Is(err, user.ErrInvalidAmount), errors.Is(err, withdrawal.ErrInvalidAmount),
+		errors.Is(err, money.ErrCurrencyMismatch):
+		http.Error(w, "Неверная сумма", http.StatusBadRequest)
+	case errors.Is(err, withdrawal.ErrInvalidDestination):
+		http.Error(w, "Неверные реквизиты для выплаты", http.StatusBadRequest)
+	case errors.Is(err, withdrawal.ErrInvalidReason):
+		http.Error(w, "Слишком длинная причина отказа", http.StatusBadRequest)
+	case errors.Is(err, user.ErrUserNotFound):
+		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	case errors.Is(err, withdrawal.ErrWithdrawalNotFound):
+		http.Error(w, "Заявка не найдена", http.StatusNotFound)
+	case errors.Is(err, withdrawal.ErrNotPending):
+		http.Error(w, "Заявка уже рассмотрена", http.StatusConflict)
+	default:
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+func (h *WithdrawalHandler) encode(w http.ResponseWriter, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error("failed to encode response", "error", err)
+	}
+}
+
+func toWithdrawalResponse(result *balance.WithdrawalResult) WithdrawalResponse {
+	return WithdrawalResponse{
+		ID:          result.ID,
+		UserID:      result.UserID,
+		Amount:      result.Amount,
+		Destination: result.Destination,
+		Status:      string(result.Status),
+		Reason:      result.Reason,
+		CreatedAt:   result.CreatedAt,
+		ReviewedAt:  result.ReviewedAt,
+	}
+}
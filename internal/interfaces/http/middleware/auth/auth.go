@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"gambling/internal/domain/session"
+	"gambling/internal/domain/user"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// contextKey - тип ключа контекста, недоступный другим пакетам
+type contextKey struct{}
+
+// claimsKey - ключ, под которым в контексте хранится содержимое access токена
+var claimsKey = contextKey{}
+
+// New создает middleware, которое требует access токен в заголовке
+// Authorization: Bearer <token> и кладет ID пользователя в контекст запроса
+func New(signer session.TokenSigner, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				unauthorized(w, "Требуется авторизация")
+				return
+			}
+
+			claims, err := signer.Parse(token)
+			if err != nil {
+				log.Debug("rejected access token", slog.Any("error", err), slog.String("path", r.URL.Path))
+				if errors.Is(err, session.ErrTokenExpired) {
+					unauthorized(w, "Срок действия токена истек")
+					return
+				}
+				unauthorized(w, "Неверный токен")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserIDFromContext возвращает ID аутентифицированного пользователя
+// Второе значение false, если запрос не прошел через middleware
+func UserIDFromContext(ctx context.Context) (uint, bool) {
+	claims, ok := ctx.Value(claimsKey).(*session.Claims)
+	if !ok {
+		return 0, false
+	}
+	return claims.UserID, true
+}
+
+// RequireRole создает middleware, которое пропускает только пользователей с ролью role
+// Должно подключаться после New
+func RequireRole(role user.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value(claimsKey).(*session.Claims)
+			if !ok {
+				unauthorized(w, "Требуется авторизация")
+				return
+			}
+			if claims.Role != role {
+				http.Error(w, "Недостаточно прав", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="gambling"`)
+	http.Error(w, msg, http.StatusUnauthorized)
+}
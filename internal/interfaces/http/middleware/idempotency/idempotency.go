@@ -0,0 +1,159 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	idempotencyUseCase "gambling/internal/application/use_case/idempotency"
+	"gambling/internal/domain/idempotency"
+	authMiddleware "gambling/internal/interfaces/http/middleware/auth"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// Header - заголовок, в котором клиент передает ключ идемпотентности
+const Header = "Idempotency-Key"
+
+// ReplayedHeader отмечает ответ, возвращенный из сохраненного первого ответа
+const ReplayedHeader = "Idempotent-Replayed"
+
+// maxBodySize ограничивает тело запроса, которое читается для отпечатка
+const maxBodySize = 1 << 20
+
+// contextKey - тип ключа контекста, недоступный другим пакетам
+type contextKey struct{}
+
+// keyCtx - ключ, под которым в контексте хранится ключ идемпотентности
+var keyCtx = contextKey{}
+
+// New создает middleware, которое выполняет запрос с заголовком Idempotency-Key
+// не больше одного раза: повтор с тем же ключом и телом получает сохраненный ответ,
+// повтор с другим телом - 409 Conflict. Запросы без заголовка проходят как есть.
+// Должно подключаться после middleware auth: ключи уникальны в пределах пользователя
+func New(guard *idempotencyUseCase.Guard, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID, ok := authMiddleware.UserIDFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Требуется авторизация", http.StatusUnauthorized)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+			if err != nil {
+				http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+				return
+			}
+			if len(body) > maxBodySize {
+				http.Error(w, "Слишком большой запрос", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			stored, err := guard.Begin(idempotencyUseCase.BeginCommand{
+				UserID:      userID,
+				Key:         key,
+				Fingerprint: idempotency.Fingerprint(r.Method, r.URL.Path, body),
+			})
+			if err != nil {
+				writeError(w, log, err)
+				return
+			}
+			if stored != nil {
+				replay(w, stored)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w}
+			ctx := context.WithValue(r.Context(), keyCtx, key)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			// Ошибка сервера означает, что операция не выполнена: ключ освобождается
+			// для повтора. Остальные ответы, включая 4xx, сохраняются
+			if rec.status() >= http.StatusInternalServerError {
+				if err := guard.Release(userID, key); err != nil {
+					log.Error("failed to release idempotency key", slog.Any("error", err))
+				}
+				return
+			}
+			err = guard.Complete(idempotencyUseCase.CompleteCommand{
+				UserID: userID,
+				Key:    key,
+				Response: idempotencyUseCase.StoredResponse{
+					StatusCode:  rec.status(),
+					ContentType: w.Header().Get("Content-Type"),
+					Body:        rec.body.Bytes(),
+				},
+			})
+			if err != nil {
+				log.Error("failed to store idempotent response", slog.Any("error", err))
+			}
+		})
+	}
+}
+
+// KeyFromContext возвращает ключ идемпотентности запроса или пустую строку,
+// если запрос пришел без заголовка Idempotency-Key
+func KeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(keyCtx).(string)
+	return key
+}
+
+func replay(w http.ResponseWriter, stored *idempotencyUseCase.StoredResponse) {
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.Body)
+}
+
+func writeError(w http.ResponseWriter, log *slog.Logger, err error) {
+	switch {
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		http.Error(w, "Неверный ключ идемпотентности", http.StatusBadRequest)
+	case errors.Is(err, idempotency.ErrKeyReused):
+		http.Error(w, "Ключ идемпотентности уже использован с другим запросом", http.StatusConflict)
+	case errors.Is(err, idempotency.ErrInProgress):
+		http.Error(w, "Запрос с этим ключом идемпотентности еще выполняется", http.StatusConflict)
+	default:
+		log.Error("idempotency check failed", slog.Any("error", err))
+		http.Error(w, "Внутренняя ошибка сервера", http.StatusInternalServerError)
+	}
+}
+
+// recorder передает ответ клиенту и одновременно запоминает его для повторов
+type recorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	if rec.statusCode == 0 {
+		rec.statusCode = code
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if rec.statusCode == 0 {
+		rec.statusCode = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+func (rec *recorder) status() int {
+	if rec.statusCode == 0 {
+		return http.StatusOK
+	}
+	return rec.statusCode
+}
@@ -40,3 +40,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap возвращает исходный ResponseWriter, чтобы http.ResponseController
+// мог отправлять потоковые ответы по частям
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -3,13 +3,29 @@ package router
 import (
 	"gambling/internal/application/use_case/auth"
 	"gambling/internal/application/use_case/balance"
+	"gambling/internal/application/use_case/exclusions"
+	fairnessUseCase "gambling/internal/application/use_case/fairness"
+	"gambling/internal/application/use_case/history"
+	idempotencyUseCase "gambling/internal/application/use_case/idempotency"
+	"gambling/internal/application/use_case/jackpots"
+	"gambling/internal/application/use_case/limits"
 	spinUseCase "gambling/internal/application/use_case/spin"
+	"gambling/internal/application/use_case/statement"
+	"gambling/internal/config"
+	"gambling/internal/domain/game"
+	"gambling/internal/domain/jackpot"
+	"gambling/internal/domain/rng"
+	"gambling/internal/domain/session"
 	"gambling/internal/domain/spin"
+	"gambling/internal/domain/user"
 	"gambling/internal/infrastructure/database/pgsql"
-	"gambling/internal/interfaces/http/handlers"
+	"gambling/internal/infrastructure/export"
 	"gambling/internal/infrastructure/repository"
+	"gambling/internal/interfaces/http/handlers"
 	"net/http"
 
+	mvAuth "gambling/internal/interfaces/http/middleware/auth"
+	mvIdempotency "gambling/internal/interfaces/http/middleware/idempotency"
 	mvLog "gambling/internal/interfaces/http/middleware/logger"
 	"log/slog"
 
@@ -19,7 +35,14 @@ import (
 
 // New создаёт новый Router с подключенными хэндлерами
 // Здесь происходит композиция всех слоев DDD архитектуры
-func New(storage *pgsql.Storage, logger *slog.Logger) http.Handler {
+func New(
+	storage *pgsql.Storage,
+	cfg *config.Config,
+	paytable *spin.Paytable,
+	videoPaytable *spin.VideoPaytable,
+	signer session.TokenSigner,
+	logger *slog.Logger,
+) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
@@ -35,29 +58,106 @@ func New(storage *pgsql.Storage, logger *slog.Logger) http.Handler {
 	userRepo := repository.NewUserRepository(storage.DB)
 	transactionRepo := repository.NewTransactionRepository(storage.DB)
 	spinRepo := repository.NewSpinRepository(storage.DB)
+	seedPairRepo := repository.NewSeedPairRepository(storage.DB)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(storage.DB)
+	withdrawalRepo := repository.NewWithdrawalRepository(storage.DB)
+	idempotencyRepo := repository.NewIdempotencyRepository(storage.DB)
+	exclusionRepo := repository.NewExclusionRepository(storage.DB)
+	jackpotRepo := repository.NewJackpotRepository(storage.DB)
+	unitOfWork := repository.NewUnitOfWork(storage.DB)
 
 	// ============================================
 	// ИНИЦИАЛИЗАЦИЯ ДОМЕННОГО СЛОЯ (Domain Layer)
 	// ============================================
-	// Создаем доменный сервис для логики игры
-	spinDomainService := spin.NewService()
+	// Создаем доменный сервис для логики игры по загруженной таблице выплат
+	// Источник случайных чисел - crypto/rand: он безопасен для конкурентных запросов
+	spinDomainService := spin.NewService(paytable, rng.NewCryptoSource())
+	// Реестр игр: раунды всех игр проходят через SpinUseCase
+	// Прежние версии встроенных таблиц нужны для проверки раундов, сыгранных по ним
+	games := game.NewRegistry(spinDomainService, spin.NewVideoService(videoPaytable)).
+		AddVersions(spin.EmbeddedGames()...)
 
 	// ============================================
 	// ИНИЦИАЛИЗАЦИЯ APPLICATION СЛОЯ (Application Layer)
 	// ============================================
 	// Создаем use cases - это бизнес-операции приложения
-	registerUseCase := auth.NewRegisterUseCase(userRepo)
-	loginUseCase := auth.NewLoginUseCase(userRepo)
-	depositUseCase := balance.NewDepositUseCase(userRepo, transactionRepo)
-	spinUC := spinUseCase.NewSpinUseCase(userRepo, transactionRepo, spinRepo, spinDomainService)
+	registerUseCase := auth.NewRegisterUseCase(userRepo, exclusionRepo)
+	tokenTTL := auth.TokenTTL{Access: cfg.AccessTokenTTL, Refresh: cfg.RefreshTokenTTL}
+	loginUseCase := auth.NewLoginUseCase(userRepo, refreshTokenRepo, signer, tokenTTL)
+	refreshUseCase := auth.NewRefreshUseCase(unitOfWork, signer, tokenTTL)
+	logoutUseCase := auth.NewLogoutUseCase(unitOfWork)
+	revokeSessionsUseCase := auth.NewRevokeSessionsUseCase(refreshTokenRepo)
+	depositUseCase := balance.NewDepositUseCase(unitOfWork)
+	withdrawUseCase := balance.NewWithdrawUseCase(unitOfWork)
+	approveWithdrawalUseCase := balance.NewApproveWithdrawalUseCase(unitOfWork)
+	rejectWithdrawalUseCase := balance.NewRejectWithdrawalUseCase(unitOfWork)
+	listWithdrawalsUseCase := balance.NewListWithdrawalsUseCase(withdrawalRepo)
+	listTransactionsUseCase := history.NewListTransactionsUseCase(transactionRepo, userRepo)
+	listSpinsUseCase := history.NewListSpinsUseCase(spinRepo, userRepo)
+	getSpinUseCase := history.NewGetSpinUseCase(spinRepo, transactionRepo)
+	generateStatementUseCase := statement.NewGenerateUseCase(transactionRepo, spinRepo, userRepo, export.NewExporter())
+	jackpotRules := jackpot.Rules{
+		ContributionBPS: cfg.JackpotContributionBPS,
+		Seed:            cfg.JackpotSeed,
+		FullBet:         cfg.JackpotFullBet,
+	}
+	spinUC := spinUseCase.NewSpinUseCase(unitOfWork, games, cfg.ProvablyFair, jackpotRules)
+	getFreeSpinsUseCase := spinUseCase.NewGetFreeSpinsUseCase(userRepo)
+	autoplayUseCase := spinUseCase.NewAutoplayUseCase(spinUC)
+	getJackpotUseCase := jackpots.NewGetJackpotUseCase(jackpotRepo, cfg.JackpotSeed, paytable.Jackpot != nil)
+	getSeedsUseCase := fairnessUseCase.NewGetSeedsUseCase(unitOfWork)
+	rotateSeedsUseCase := fairnessUseCase.NewRotateSeedsUseCase(unitOfWork)
+	listRevealedSeedsUseCase := fairnessUseCase.NewListRevealedSeedsUseCase(seedPairRepo)
+	verifySpinUseCase := fairnessUseCase.NewVerifySpinUseCase(spinRepo, seedPairRepo, games)
+	idempotencyGuard := idempotencyUseCase.NewGuard(idempotencyRepo, cfg.IdempotencyTTL)
+	setLimitUseCase := limits.NewSetLimitUseCase(unitOfWork, cfg.LimitCoolingPeriod)
+	listLimitsUseCase := limits.NewListLimitsUseCase(unitOfWork)
+	excludeUseCase := exclusions.NewExcludeUseCase(unitOfWork)
+	liftExclusionUseCase := exclusions.NewLiftExclusionUseCase(unitOfWork)
+	getExclusionUseCase := exclusions.NewGetExclusionUseCase(userRepo, exclusionRepo)
 
 	// ============================================
 	// ИНИЦИАЛИЗАЦИЯ INTERFACES СЛОЯ (Interfaces Layer)
 	// ============================================
 	// Создаем HTTP handlers - это адаптеры для HTTP протокола
-	authHandler := handlers.NewAuthHandler(registerUseCase, loginUseCase, logger)
+	authHandler := handlers.NewAuthHandler(
+		registerUseCase,
+		loginUseCase,
+		refreshUseCase,
+		logoutUseCase,
+		revokeSessionsUseCase,
+		logger,
+	)
 	balanceHandler := handlers.NewBalanceHandler(depositUseCase, logger)
-	spinHandler := handlers.NewSpinHandler(spinUC, logger)
+	withdrawalHandler := handlers.NewWithdrawalHandler(
+		withdrawUseCase,
+		approveWithdrawalUseCase,
+		rejectWithdrawalUseCase,
+		listWithdrawalsUseCase,
+		logger,
+	)
+	transactionHandler := handlers.NewTransactionHandler(listTransactionsUseCase, logger)
+	statementHandler := handlers.NewStatementHandler(generateStatementUseCase, logger)
+	spinHandler := handlers.NewSpinHandler(games, spinUC, getFreeSpinsUseCase, listSpinsUseCase, getSpinUseCase, logger)
+	autoplayHandler := handlers.NewAutoplayHandler(autoplayUseCase, logger)
+	jackpotHandler := handlers.NewJackpotHandler(getJackpotUseCase, logger)
+	limitHandler := handlers.NewLimitHandler(setLimitUseCase, listLimitsUseCase, logger)
+	exclusionHandler := handlers.NewExclusionHandler(
+		excludeUseCase,
+		liftExclusionUseCase,
+		getExclusionUseCase,
+		logger,
+	)
+	fairnessHandler := handlers.NewFairnessHandler(
+		getSeedsUseCase,
+		rotateSeedsUseCase,
+		listRevealedSeedsUseCase,
+		verifySpinUseCase,
+		logger,
+	)
+
+	// Повтор запроса с тем же Idempotency-Key не выполняет операцию второй раз
+	idempotent := mvIdempotency.New(idempotencyGuard, logger)
 
 	// Маршруты
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -73,14 +173,69 @@ func New(storage *pgsql.Storage, logger *slog.Logger) http.Handler {
 		// Аутентификация
 		r.Post("/register", authHandler.Register)
 		r.Post("/login", authHandler.Login)
+		r.Post("/token/refresh", authHandler.Refresh)
+
+		// Текущая сумма джекпота открыта без входа
+		r.Get("/jackpot", jackpotHandler.Get)
+		// Список игр тоже открыт: он нужен до входа, чтобы показать лобби
+		r.Get("/games", spinHandler.ListGames)
+
+		// Маршруты ниже требуют access токен: ID пользователя берется только из него
+		r.Group(func(r chi.Router) {
+			r.Use(mvAuth.New(signer, logger))
+
+			// Сессии
+			r.Post("/logout", authHandler.Logout)
+			r.Post("/sessions/revoke", authHandler.RevokeSessions)
 
-		// Баланс
-		r.Post("/balance/deposit", balanceHandler.Deposit)
+			// Баланс
+			r.With(idempotent).Post("/balance/deposit", balanceHandler.Deposit)
+			r.Post("/balance/withdraw", withdrawalHandler.Withdraw)
+			r.Get("/balance/withdrawals", withdrawalHandler.ListOwn)
 
-		// Игра
-		r.Post("/spin", spinHandler.Spin)
+			// История транзакций
+			r.Get("/transactions", transactionHandler.ListOwn)
+			r.Get("/statements", statementHandler.DownloadOwn)
+
+			// Ответственная игра: лимиты и самоограничение
+			r.Get("/limits", limitHandler.List)
+			r.Put("/limits", limitHandler.Set)
+			r.Delete("/limits/{kind}/{period}", limitHandler.Remove)
+			r.Post("/exclusion", exclusionHandler.ExcludeSelf)
+
+			// Игра
+			r.With(idempotent).Post("/spin", spinHandler.Spin)
+			r.With(idempotent).Post("/games/{gameID}/play", spinHandler.Play)
+			r.Get("/free-spins", spinHandler.GetFreeSpins)
+			r.With(idempotent).Post("/games/{gameID}/free-spin", spinHandler.FreeSpin)
+			// Автоигра - серия раундов с потоковым ответом, поэтому ключ идемпотентности к ней не применяется
+			r.Post("/games/{gameID}/autoplay", autoplayHandler.Autoplay)
+			r.Get("/spins", spinHandler.List)
+			r.Get("/spins/{spinID}", spinHandler.Get)
+
+			// Доказуемо честная игра
+			r.Route("/fairness", func(r chi.Router) {
+				r.Get("/seeds", fairnessHandler.GetSeeds)
+				r.Post("/seeds/rotate", fairnessHandler.RotateSeeds)
+				r.Get("/seeds/revealed", fairnessHandler.ListRevealedSeeds)
+				r.Get("/verify/{spinID}", fairnessHandler.VerifySpin)
+			})
+
+			// Операторские маршруты
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(mvAuth.RequireRole(user.RoleOperator))
+
+				r.Get("/withdrawals", withdrawalHandler.ListByStatus)
+				r.Post("/withdrawals/{withdrawalID}/approve", withdrawalHandler.Approve)
+				r.Post("/withdrawals/{withdrawalID}/reject", withdrawalHandler.Reject)
+				r.Get("/users/{userID}/transactions", transactionHandler.ListByUser)
+				r.Get("/users/{userID}/statements", statementHandler.DownloadByUser)
+				r.Get("/users/{userID}/exclusion", exclusionHandler.Get)
+				r.Post("/users/{userID}/exclusion", exclusionHandler.ExcludeUser)
+				r.Post("/users/{userID}/exclusion/lift", exclusionHandler.Lift)
+			})
+		})
 	})
 
 	return r
 }
-
@@ -0,0 +1,21 @@
+#!/usr/bin/env sh
+# Запускает тесты вместе с интеграционными: PostgreSQL поднимается во временном
+# Docker-контейнере и удаляется после тестов. Аргументы передаются в go test
+set -eu
+
+CONTAINER=gambling-test-postgres
+PORT=${TEST_DB_PORT:-55432}
+
+docker run -d --rm --name "$CONTAINER" \
+  -e POSTGRES_USER=postgres -e POSTGRES_PASSWORD=postgres -e POSTGRES_DB=gambling_test \
+  -p "$PORT:5432" postgres:16-alpine >/dev/null
+trap 'docker stop "$CONTAINER" >/dev/null' EXIT
+
+# Проверка по TCP: при инициализации образ сначала запускает сервер только на сокете
+until docker exec "$CONTAINER" pg_isready -h 127.0.0.1 -U postgres -d gambling_test >/dev/null 2>&1; do
+  sleep 1
+done
+
+cd "$(dirname "$0")/.."
+TEST_DB_HOST=localhost TEST_DB_PORT="$PORT" TEST_DB_USER=postgres TEST_DB_PASSWORD=postgres \
+  TEST_DB_NAME=gambling_test go test -count=1 "$@" ./...